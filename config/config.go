@@ -16,6 +16,10 @@ type Config struct {
 	DBName     string `envconfig:"DB_NAME"`
 	AppPort    string `envconfig:"APP_PORT"`
 	JWTSecret  string `envconfig:"JWT_SECRET"`
+
+	CertificateSecret string `envconfig:"CERTIFICATE_SECRET"`
+
+	ProgressThresholds map[string]int `envconfig:"PROGRESS_THRESHOLDS"`
 }
 
 var instance Config
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS announcement_read (
+    announcement_id varchar(255),
+    course_id varchar(255),
+    user_id varchar(255),
+    read_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (announcement_id, user_id)
+);
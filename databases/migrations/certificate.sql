@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS certificate (
+    id varchar(255) PRIMARY KEY,
+    user_id varchar(255),
+    course_id varchar(255),
+    learner_name varchar(255),
+    course_name varchar(255),
+    finish_date DATETIME,
+    final_score int,
+    signature varchar(255),
+    issued_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, course_id)
+);
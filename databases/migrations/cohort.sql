@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS cohort (
+    id varchar(255) PRIMARY KEY,
+    course_id varchar(255),
+    name varchar(255),
+    join_code varchar(255) UNIQUE,
+    start_date DATETIME,
+    end_date DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS cohort_instructor (
+    cohort_id varchar(255),
+    course_id varchar(255),
+    user_id varchar(255),
+    PRIMARY KEY (cohort_id, user_id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS cohort_member (
+    cohort_id varchar(255),
+    course_id varchar(255),
+    user_id varchar(255),
+    joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_id, user_id)
+);
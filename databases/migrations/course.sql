@@ -4,4 +4,10 @@ CREATE TABLE IF NOT EXISTS Course (
     description varchar(255),
     thumbnail varchar(255),
     creator varchar(255),
+    status varchar(255) DEFAULT 'draft',
+    sequential boolean DEFAULT false,
+    min_score int DEFAULT 0,
+    enrollment_mode varchar(255) DEFAULT 'open',
+    enrollment_key varchar(255) DEFAULT '',
+    capacity int DEFAULT 0,
 );
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS course_announcement (
+    id varchar(255) PRIMARY KEY,
+    course_id varchar(255),
+    author_id varchar(255),
+    title varchar(255),
+    content text,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS course_collaborator (
+    course_id varchar(255),
+    user_id varchar(255),
+    role varchar(255),
+    status varchar(255) DEFAULT 'pending',
+    invited_by varchar(255),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_id, user_id)
+);
@@ -5,6 +5,11 @@ CREATE TABLE IF NOT EXISTS course_material (
     name varchar(255),
     type varchar(255),
     section_id varchar(255),
-    content varchar(255),
-    content_text TEXT
+    content TEXT,
+    content_text TEXT,
+    metadata TEXT NULL,
+    content_html TEXT NULL,
+    position int DEFAULT 0,
+    release_at DATETIME NULL,
+    release_after_days int NULL
 );
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS course_prerequisite (
+    course_id varchar(255),
+    prerequisite_id varchar(255),
+    PRIMARY KEY (course_id, prerequisite_id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS course_review (
+    course_id varchar(255),
+    user_id varchar(255),
+    rating int,
+    review text,
+    hidden BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_id, user_id)
+);
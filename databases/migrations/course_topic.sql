@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS course_topic (
+    course_id varchar(255),
+    topic_id varchar(255),
+    PRIMARY KEY (course_id, topic_id)
+);
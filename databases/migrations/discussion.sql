@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS discussion (
+    id varchar(255) UNIQUE,
+    course_id varchar(255),
+    target_type varchar(255),
+    target_id varchar(255),
+    parent_id varchar(255) DEFAULT '',
+    user_id varchar(255),
+    content TEXT,
+    answered BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
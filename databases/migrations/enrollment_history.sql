@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS enrollment_history (
+    _id bigint(20) unsigned AUTO_INCREMENT PRIMARY KEY,
+    user_id varchar(255),
+    course_id varchar(255),
+    action varchar(255),
+    keep_progress BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS enrollment_request (
+    course_id varchar(255),
+    user_id varchar(255),
+    cohort_id varchar(255) DEFAULT '',
+    action varchar(255) DEFAULT 'enroll',
+    keep_progress boolean DEFAULT true,
+    status varchar(255),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_id, user_id)
+);
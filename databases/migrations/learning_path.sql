@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS learning_path (
+    id varchar(255) UNIQUE,
+    name varchar(255),
+    description TEXT,
+    creator varchar(255)
+);
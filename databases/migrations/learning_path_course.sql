@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS learning_path_course (
+    learning_path_id varchar(255),
+    course_id varchar(255),
+    position INT,
+    PRIMARY KEY (learning_path_id, course_id)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS material_progress (
+    user_id varchar(255),
+    course_id varchar(255),
+    material_id varchar(255),
+    time_spent int DEFAULT 0,
+    percentage int DEFAULT 0,
+    last_position int DEFAULT 0,
+    completed boolean DEFAULT false,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, material_id)
+);
@@ -1,8 +1,8 @@
-insert into Course (id, course_name, description, thumbnail, creator) VALUES 
-    (uuid(), "Introduction to Algorithm", "This is a course to introduction to algorithm", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to C++", "This is a course to introduction to C++", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to Javascript", "This is a course to introduction to Javascript", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to Python", "This is a course to introduction to Python", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to Web Dev", "This is a course to introduction to Web Development", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to Mobile Dev", "This is a course to introduction to Mobile Development", "assets/dummy/thumbnail.png", "danielmr"),
-    (uuid(), "Introduction to WebGL", "This is a course to introduction to WebGL", "assets/dummy/thumbnail.png", "danielmr");
\ No newline at end of file
+insert into Course (id, course_name, description, thumbnail, creator, status) VALUES 
+    (uuid(), "Introduction to Algorithm", "This is a course to introduction to algorithm", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to C++", "This is a course to introduction to C++", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to Javascript", "This is a course to introduction to Javascript", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to Python", "This is a course to introduction to Python", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to Web Dev", "This is a course to introduction to Web Development", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to Mobile Dev", "This is a course to introduction to Mobile Development", "assets/dummy/thumbnail.png", "danielmr", "published"),
+    (uuid(), "Introduction to WebGL", "This is a course to introduction to WebGL", "assets/dummy/thumbnail.png", "danielmr", "published");
\ No newline at end of file
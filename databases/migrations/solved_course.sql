@@ -4,4 +4,5 @@ CREATE TABLE IF NOT EXISTS solved_course (
     start_date DATETIME,
     finish_date DATETIME,
     final_score int,
+    PRIMARY KEY (user_id, course_id)
 );
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS topic (
+    id varchar(255) UNIQUE,
+    name varchar(255) UNIQUE
+);
@@ -0,0 +1,8 @@
+-- A material has a single progress row per user keeping the best score.
+-- Duplicated rows written by earlier versions are merged before the key is
+-- added.
+DELETE p FROM user_progress p
+JOIN user_progress q ON q.user_id = p.user_id AND q.material_id = p.material_id
+    AND (q.score > p.score OR (q.score = p.score AND q._id < p._id));
+
+ALTER TABLE user_progress ADD UNIQUE KEY user_material (user_id, material_id);
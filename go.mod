@@ -15,6 +15,8 @@ require (
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/labstack/echo v3.3.10+incompatible // indirect
 	github.com/labstack/echo/v4 v4.6.3
+	github.com/microcosm-cc/bluemonday v1.0.18
 	github.com/stretchr/testify v1.7.1
+	github.com/yuin/goldmark v1.4.8
 	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 )
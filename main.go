@@ -21,10 +21,19 @@ import (
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate/certificate_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion/discussion_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path/learning_path_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/user"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/user/user_repository"
 )
@@ -71,6 +80,19 @@ func (app *App) initRoutes() {
 	courseRepository := course_repository.NewRepository()
 	problemRepository := problem_repository.NewRepository()
 	assignmentRepository := assignment_repository.NewRepository()
+	searchRepository := search_repository.NewRepository()
+	certificateRepository := certificate_repository.NewRepository()
+	learningPathRepository := learning_path_repository.NewRepository()
+	discussionRepository := discussion_repository.NewRepository()
+
+	searchService := search.NewService(app.DBManager.DB)
+	_ = searchService.InjectSearchRepository(searchRepository)
+	_ = searchService.InjectSearchIndex(search_index.NewMemoryIndex())
+	if app.DBManager.DB == nil {
+		fmt.Println("search: database is not connected, skipping the index rebuild")
+	} else if err := searchService.Rebuild(context.Background()); err != nil {
+		fmt.Println(err)
+	}
 
 	userService := user.NewService(app.DBManager.DB)
 	_ = userService.InjectUserRepository(userRepository)
@@ -78,12 +100,29 @@ func (app *App) initRoutes() {
 	courseService := course.NewService(app.DBManager.DB)
 	_ = courseService.InjectCourseRepository(courseRepository)
 	_ = courseService.InjectUserRepository(userRepository)
+	_ = courseService.InjectAssignmentRepository(assignmentRepository)
+	_ = courseService.InjectProblemRepository(problemRepository)
+	_ = courseService.InjectSearchService(searchService)
 
 	problemService := problem.NewService(app.DBManager.DB)
 	_ = problemService.InjectRepository(problemRepository)
+	_ = problemService.InjectSearchService(searchService)
 
 	assignmentService := assignment.NewService(app.DBManager.DB)
 	_ = assignmentService.InjectAssignmentRepository(assignmentRepository)
+	_ = assignmentService.InjectCourseService(courseService)
+	_ = assignmentService.InjectCourseRepository(courseRepository)
+
+	certificateService := certificate.NewService(app.DBManager.DB)
+	_ = certificateService.InjectCertificateRepository(certificateRepository)
+
+	learningPathService := learning_path.NewService(app.DBManager.DB)
+	_ = learningPathService.InjectLearningPathRepository(learningPathRepository)
+	_ = learningPathService.InjectCourseService(courseService)
+
+	discussionService := discussion.NewService(app.DBManager.DB)
+	_ = discussionService.InjectDiscussionRepository(discussionRepository)
+	_ = discussionService.InjectCourseRepository(courseRepository)
 
 	userController := user.NewController(userService)
 	app.E.GET("/", userController.HandleGetUserData, mid.DecodeJWTToken())
@@ -98,9 +137,14 @@ func (app *App) initRoutes() {
 	course.GET("/:id", courseController.HandleGetCourseData, mid.DecodeJWTToken())
 	course.GET("/completed", courseController.HandleGetCompletedCoursePagination, mid.DecodeJWTToken())
 	course.GET("/on-progress", courseController.HandleGetOnProgressCoursePagination, mid.DecodeJWTToken())
+	course.GET("/on-progress/resume", courseController.HandleGetResumePoints, mid.DecodeJWTToken())
 	course.GET("/syllabus/:id", courseController.HandleGetCourseSyllabus, mid.DecodeJWTToken())
 	course.GET("/syllabus/:id/:sectId", courseController.HandleGetMaterial, mid.DecodeJWTToken())
+	course.PUT("/syllabus/:id/order", courseController.HandleReorderSyllabus, mid.DecodeJWTToken())
 	course.POST("/enroll/:id", courseController.HandleEnroll, mid.DecodeJWTToken())
+	course.DELETE("/enroll/:id", courseController.HandleUnenroll, mid.DecodeJWTToken())
+	course.POST("/re-enroll/:id", courseController.HandleReEnroll, mid.DecodeJWTToken())
+	course.GET("/enrollment-history", courseController.HandleGetEnrollmentHistory, mid.DecodeJWTToken())
 	course.GET("/progress/:id", courseController.HandleGetUserProgress, mid.DecodeJWTToken())
 	course.GET("/progress/percentage/:id", courseController.HandleGetUserProgressPercentage, mid.DecodeJWTToken())
 	course.POST("/progress/:id", courseController.HandleStoreProgress, mid.DecodeJWTToken())
@@ -108,12 +152,67 @@ func (app *App) initRoutes() {
 	course.POST("/create/description", courseController.HandleCreateDescription, mid.DecodeJWTToken())
 	course.POST("/create/section", courseController.HandleCreateSection, mid.DecodeJWTToken())
 	course.POST("/create/material", courseController.HandleCreateMaterial, mid.DecodeJWTToken())
+	course.POST("/:id/clone", courseController.HandleCloneCourse, mid.DecodeJWTToken())
+	course.GET("/:id/export", courseController.HandleExportCourse, mid.DecodeJWTToken())
+	course.POST("/import", courseController.HandleImportCourse, mid.DecodeJWTToken())
+	course.POST("/import/package", courseController.HandleImportContentPackage, mid.DecodeJWTToken())
 	course.POST("/upload-image", courseController.HandleUploadImage)
 	course.GET("/contributed", courseController.HandleContributedCourse, mid.DecodeJWTToken())
 	course.GET("/contributed/:userID", courseController.HandleCourseByCreatorId)
+	course.GET("/review", courseController.HandleGetCourseReviewList, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	course.PUT("/submit/:id", courseController.HandleSubmitCourse, mid.DecodeJWTToken())
+	course.PUT("/publish/:id", courseController.HandlePublishCourse, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	course.PUT("/reject/:id", courseController.HandleRejectCourse, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	course.PUT("/archive/:id", courseController.HandleArchiveCourse, mid.DecodeJWTToken())
+	course.PUT("/:id/topics", courseController.HandleSetCourseTopics, mid.DecodeJWTToken())
+	course.GET("/:id/prerequisites", courseController.HandleGetCoursePrerequisites, mid.DecodeJWTToken())
+	course.PUT("/:id/prerequisites", courseController.HandleSetCoursePrerequisites, mid.DecodeJWTToken())
+	course.PUT("/:id/access", courseController.HandleUpdateCourseAccess, mid.DecodeJWTToken())
+	course.GET("/invitations", courseController.HandleGetCollaborationInvitations, mid.DecodeJWTToken())
+	course.GET("/:id/collaborators", courseController.HandleGetCourseCollaborators, mid.DecodeJWTToken())
+	course.POST("/:id/collaborators", courseController.HandleInviteCollaborator, mid.DecodeJWTToken())
+	course.POST("/:id/collaborators/accept", courseController.HandleAcceptCollaboration, mid.DecodeJWTToken())
+	course.PUT("/:id/collaborators/:userId", courseController.HandleUpdateCollaborator, mid.DecodeJWTToken())
+	course.DELETE("/:id/collaborators/:userId", courseController.HandleRemoveCollaborator, mid.DecodeJWTToken())
+	course.GET("/:id/reviews", courseController.HandleGetCourseReviews)
+	course.POST("/:id/reviews", courseController.HandleReviewCourse, mid.DecodeJWTToken())
+	course.PUT("/:id/reviews/:userId", courseController.HandleModerateReview, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	course.GET("/announcements/unread", courseController.HandleGetUnreadAnnouncements, mid.DecodeJWTToken())
+	course.GET("/:id/announcements", courseController.HandleGetCourseAnnouncements, mid.DecodeJWTToken())
+	course.POST("/:id/announcements", courseController.HandleCreateAnnouncement, mid.DecodeJWTToken())
+	course.PUT("/announcement/:id", courseController.HandleUpdateAnnouncement, mid.DecodeJWTToken())
+	course.DELETE("/announcement/:id", courseController.HandleDeleteAnnouncement, mid.DecodeJWTToken())
+	course.POST("/announcement/:id/read", courseController.HandleMarkAnnouncementRead, mid.DecodeJWTToken())
+	course.GET("/:id/cohorts", courseController.HandleGetCourseCohorts, mid.DecodeJWTToken())
+	course.POST("/:id/cohorts", courseController.HandleCreateCohort, mid.DecodeJWTToken())
+	course.PUT("/cohort/:id", courseController.HandleUpdateCohort, mid.DecodeJWTToken())
+	course.DELETE("/cohort/:id", courseController.HandleDeleteCohort, mid.DecodeJWTToken())
+	course.GET("/cohort/:id/roster", courseController.HandleGetCohortRoster, mid.DecodeJWTToken())
+	course.PUT("/:id/enrollment", courseController.HandleUpdateEnrollmentSettings, mid.DecodeJWTToken())
+	course.GET("/:id/enrollment-requests", courseController.HandleGetEnrollmentRequests, mid.DecodeJWTToken())
+	course.POST("/:id/enrollment-requests/:userId/approve", courseController.HandleApproveEnrollmentRequest, mid.DecodeJWTToken())
+	course.POST("/:id/enrollment-requests/:userId/deny", courseController.HandleDenyEnrollmentRequest, mid.DecodeJWTToken())
+	course.GET("/:id/analytics/enrollments", courseController.HandleGetEnrollmentAnalytics, mid.DecodeJWTToken())
+	course.GET("/:id/analytics/funnel", courseController.HandleGetFunnelAnalytics, mid.DecodeJWTToken())
+	course.GET("/:id/analytics/scores", courseController.HandleGetScoreAnalytics, mid.DecodeJWTToken())
+	course.GET("/:id/analytics/completion", courseController.HandleGetCompletionAnalytics, mid.DecodeJWTToken())
+	course.PUT("/:id", courseController.HandleUpdateDescription, mid.DecodeJWTToken())
+	course.PATCH("/:id", courseController.HandlePatchDescription, mid.DecodeJWTToken())
+	course.DELETE("/:id", courseController.HandleDeleteCourse, mid.DecodeJWTToken())
+	course.PUT("/section/:id", courseController.HandleUpdateSection, mid.DecodeJWTToken())
+	course.DELETE("/section/:id", courseController.HandleDeleteSection, mid.DecodeJWTToken())
+	course.PUT("/material/:id", courseController.HandleUpdateMaterial, mid.DecodeJWTToken())
+	course.PATCH("/material/:id", courseController.HandlePatchMaterial, mid.DecodeJWTToken())
+	course.DELETE("/material/:id", courseController.HandleDeleteMaterial, mid.DecodeJWTToken())
 
 	app.E.Static("/static", "static")
 
+	topic := app.E.Group("/v1/topic")
+	topic.GET("/", courseController.HandleGetTopicList)
+	topic.POST("/create", courseController.HandleCreateTopic, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	topic.PUT("/:id", courseController.HandleUpdateTopic, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	topic.DELETE("/:id", courseController.HandleDeleteTopic, mid.DecodeJWTToken(), mid.VerifyAdmin())
+
 	problemController := problem.NewController(problemService)
 	problem := app.E.Group("/v1/problem")
 	problem.GET("/", problemController.HandleGetProblem, mid.DecodeJWTToken())
@@ -125,11 +224,41 @@ func (app *App) initRoutes() {
 	problem.PUT("/accept/:id", problemController.HandleAcceptProblem, mid.DecodeJWTToken(), mid.VerifyAdmin())
 	problem.PUT("/reject/:id", problemController.HandleRejectProblem, mid.DecodeJWTToken(), mid.VerifyAdmin())
 
+	certificateController := certificate.NewController(certificateService)
+	certificate := app.E.Group("/v1/certificate")
+	certificate.GET("/", certificateController.HandleGetUserCertificates, mid.DecodeJWTToken())
+	certificate.POST("/:id", certificateController.HandleIssueCertificate, mid.DecodeJWTToken())
+	certificate.GET("/verify/:id", certificateController.HandleVerifyCertificate)
+
+	learningPathController := learning_path.NewController(learningPathService)
+	learningPath := app.E.Group("/v1/learning-path")
+	learningPath.GET("/", learningPathController.HandleGetLearningPathList)
+	learningPath.GET("/:id", learningPathController.HandleGetLearningPath)
+	learningPath.GET("/:id/progress", learningPathController.HandleGetLearningPathProgress, mid.DecodeJWTToken())
+	learningPath.POST("/create", learningPathController.HandleCreateLearningPath, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	learningPath.PUT("/:id", learningPathController.HandleUpdateLearningPath, mid.DecodeJWTToken(), mid.VerifyAdmin())
+	learningPath.DELETE("/:id", learningPathController.HandleDeleteLearningPath, mid.DecodeJWTToken(), mid.VerifyAdmin())
+
+	searchController := search.NewController(searchService)
+	app.E.GET("/v1/search", searchController.HandleSearch)
+
 	assignmentController := assignment.NewController(assignmentService)
 	assignment := app.E.Group("v1/assignment")
 	assignment.GET("/:id", assignmentController.HandleGetAssignment, mid.DecodeJWTToken())
 	assignment.POST("/create", assignmentController.HandleCreateAssignment, mid.DecodeJWTToken())
 	assignment.POST("/:id", assignmentController.HandleGetScore, mid.DecodeJWTToken())
+
+	discussionController := discussion.NewController(discussionService)
+	discussion := app.E.Group("/v1/discussion")
+	discussion.GET("/material/:id", discussionController.HandleGetMaterialThreads, mid.DecodeJWTToken())
+	discussion.POST("/material/:id", discussionController.HandleCreateMaterialThread, mid.DecodeJWTToken())
+	discussion.GET("/assignment/:id", discussionController.HandleGetAssignmentThreads, mid.DecodeJWTToken())
+	discussion.POST("/assignment/:id", discussionController.HandleCreateAssignmentThread, mid.DecodeJWTToken())
+	discussion.GET("/:id/replies", discussionController.HandleGetReplies, mid.DecodeJWTToken())
+	discussion.POST("/:id/replies", discussionController.HandleCreateReply, mid.DecodeJWTToken())
+	discussion.PUT("/:id", discussionController.HandleUpdateDiscussion, mid.DecodeJWTToken())
+	discussion.DELETE("/:id", discussionController.HandleDeleteDiscussion, mid.DecodeJWTToken())
+	discussion.PUT("/:id/answered", discussionController.HandleMarkAnswered, mid.DecodeJWTToken())
 }
 
 func (app *App) initValidator() {
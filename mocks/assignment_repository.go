@@ -35,6 +35,29 @@ func (_m *AssignmentRepository) GetAssignmentById(ctx context.Context, _a1 *sqlx
 	return r0, r1
 }
 
+// GetAssignmentProblemIds provides a mock function with given fields: ctx, _a1, id
+func (_m *AssignmentRepository) GetAssignmentProblemIds(ctx context.Context, _a1 *sqlx.DB, id string) ([]string, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []string); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAssignmentProblemsById provides a mock function with given fields: ctx, _a1, id
 func (_m *AssignmentRepository) GetAssignmentProblemsById(ctx context.Context, _a1 *sqlx.DB, id string) ([]*db.ProblemTypeDetail, error) {
 	ret := _m.Called(ctx, _a1, id)
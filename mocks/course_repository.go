@@ -3,7 +3,6 @@
 package mocks
 
 import context "context"
-
 import db "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
 import mock "github.com/stretchr/testify/mock"
 import models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
@@ -36,52 +35,69 @@ func (_m *CourseRepository) CheckIsProgressLogged(ctx context.Context, _a1 *sqlx
 	return r0, r1
 }
 
-// GetCompletedCourseByUserID provides a mock function with given fields: ctx, _a1, meta, userid
-func (_m *CourseRepository) GetCompletedCourseByUserID(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, userid string) ([]*db.Course, uint64, error) {
-	ret := _m.Called(ctx, _a1, meta, userid)
+// CloneCourse provides a mock function with given fields: ctx, _a1, clone
+func (_m *CourseRepository) CloneCourse(ctx context.Context, _a1 *sqlx.DB, clone *db.CourseClone) error {
+	ret := _m.Called(ctx, _a1, clone)
 
-	var r0 []*db.Course
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.Course); ok {
-		r0 = rf(ctx, _a1, meta, userid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseClone) error); ok {
+		r0 = rf(ctx, _a1, clone)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Course)
-		}
+		r0 = ret.Error(0)
 	}
 
-	var r1 uint64
-	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
-		r1 = rf(ctx, _a1, meta, userid)
+	return r0
+}
+
+// CompleteCourse provides a mock function with given fields: ctx, _a1, userId, courseId
+func (_m *CourseRepository) CompleteCourse(ctx context.Context, _a1 *sqlx.DB, userId string, courseId string) error {
+	ret := _m.Called(ctx, _a1, userId, courseId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r0 = rf(ctx, _a1, userId, courseId)
 	} else {
-		r1 = ret.Get(1).(uint64)
+		r0 = ret.Error(0)
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
-		r2 = rf(ctx, _a1, meta, userid)
+	return r0
+}
+
+// CompleteMaterialProgress provides a mock function with given fields: ctx, _a1, userID, courseID, materialID
+func (_m *CourseRepository) CompleteMaterialProgress(ctx context.Context, _a1 *sqlx.DB, userID string, courseID string, materialID string) (bool, error) {
+	ret := _m.Called(ctx, _a1, userID, courseID, materialID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string, string) bool); ok {
+		r0 = rf(ctx, _a1, userID, courseID, materialID)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1, r2
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string, string) error); ok {
+		r1 = rf(ctx, _a1, userID, courseID, materialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetCourseByCreatorID provides a mock function with given fields: ctx, _a1, creatorId
-func (_m *CourseRepository) GetCourseByCreatorID(ctx context.Context, _a1 *sqlx.DB, creatorId string) ([]*db.Course, error) {
-	ret := _m.Called(ctx, _a1, creatorId)
+// CountEnrolledUsers provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) CountEnrolledUsers(ctx context.Context, _a1 *sqlx.DB, courseId string) (int, error) {
+	ret := _m.Called(ctx, _a1, courseId)
 
-	var r0 []*db.Course
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Course); ok {
-		r0 = rf(ctx, _a1, creatorId)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) int); ok {
+		r0 = rf(ctx, _a1, courseId)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Course)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
-		r1 = rf(ctx, _a1, creatorId)
+		r1 = rf(ctx, _a1, courseId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,16 +105,128 @@ func (_m *CourseRepository) GetCourseByCreatorID(ctx context.Context, _a1 *sqlx.
 	return r0, r1
 }
 
-// GetCourseById provides a mock function with given fields: ctx, _a1, id
-func (_m *CourseRepository) GetCourseById(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Course, error) {
+// DeleteCohort provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) DeleteCohort(ctx context.Context, _a1 *sqlx.DB, id string) error {
 	ret := _m.Called(ctx, _a1, id)
 
-	var r0 *db.Course
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Course); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) error); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCollaborator provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) DeleteCollaborator(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) error {
+	ret := _m.Called(ctx, _a1, courseId, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCourse provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) DeleteCourse(ctx context.Context, _a1 *sqlx.DB, id string) error {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) error); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCourseAnnouncement provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) DeleteCourseAnnouncement(ctx context.Context, _a1 *sqlx.DB, id string) error {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) error); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCourseMaterials provides a mock function with given fields: ctx, _a1, ids
+func (_m *CourseRepository) DeleteCourseMaterials(ctx context.Context, _a1 *sqlx.DB, ids []string) error {
+	ret := _m.Called(ctx, _a1, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, []string) error); ok {
+		r0 = rf(ctx, _a1, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEnrollment provides a mock function with given fields: ctx, _a1, values
+func (_m *CourseRepository) DeleteEnrollment(ctx context.Context, _a1 *sqlx.DB, values *models.EnrollInput) error {
+	ret := _m.Called(ctx, _a1, values)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *models.EnrollInput) error); ok {
+		r0 = rf(ctx, _a1, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEnrollmentRequest provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) DeleteEnrollmentRequest(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) error {
+	ret := _m.Called(ctx, _a1, courseId, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTopic provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) DeleteTopic(ctx context.Context, _a1 *sqlx.DB, id string) error {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) error); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCohortById provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) GetCohortById(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Cohort, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 *db.Cohort
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Cohort); ok {
 		r0 = rf(ctx, _a1, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Course)
+			r0 = ret.Get(0).(*db.Cohort)
 		}
 	}
 
@@ -112,20 +240,22 @@ func (_m *CourseRepository) GetCourseById(ctx context.Context, _a1 *sqlx.DB, id
 	return r0, r1
 }
 
-// GetCourseIDByMaterialID provides a mock function with given fields: ctx, _a1, materialID
-func (_m *CourseRepository) GetCourseIDByMaterialID(ctx context.Context, _a1 *sqlx.DB, materialID string) (string, error) {
-	ret := _m.Called(ctx, _a1, materialID)
+// GetCohortByJoinCode provides a mock function with given fields: ctx, _a1, joinCode
+func (_m *CourseRepository) GetCohortByJoinCode(ctx context.Context, _a1 *sqlx.DB, joinCode string) (*db.Cohort, error) {
+	ret := _m.Called(ctx, _a1, joinCode)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) string); ok {
-		r0 = rf(ctx, _a1, materialID)
+	var r0 *db.Cohort
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Cohort); ok {
+		r0 = rf(ctx, _a1, joinCode)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Cohort)
+		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
-		r1 = rf(ctx, _a1, materialID)
+		r1 = rf(ctx, _a1, joinCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,52 +263,45 @@ func (_m *CourseRepository) GetCourseIDByMaterialID(ctx context.Context, _a1 *sq
 	return r0, r1
 }
 
-// GetCourseList provides a mock function with given fields: ctx, _a1, meta
-func (_m *CourseRepository) GetCourseList(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta) ([]*db.Course, uint64, error) {
-	ret := _m.Called(ctx, _a1, meta)
+// GetCohortInstructors provides a mock function with given fields: ctx, _a1, cohortId
+func (_m *CourseRepository) GetCohortInstructors(ctx context.Context, _a1 *sqlx.DB, cohortId string) ([]*db.CohortUser, error) {
+	ret := _m.Called(ctx, _a1, cohortId)
 
-	var r0 []*db.Course
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta) []*db.Course); ok {
-		r0 = rf(ctx, _a1, meta)
+	var r0 []*db.CohortUser
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.CohortUser); ok {
+		r0 = rf(ctx, _a1, cohortId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Course)
+			r0 = ret.Get(0).([]*db.CohortUser)
 		}
 	}
 
-	var r1 uint64
-	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta) uint64); ok {
-		r1 = rf(ctx, _a1, meta)
-	} else {
-		r1 = ret.Get(1).(uint64)
-	}
-
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta) error); ok {
-		r2 = rf(ctx, _a1, meta)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, cohortId)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetCourseMaterialByCourseIDAndSectionID provides a mock function with given fields: ctx, _a1, courseId, sectionId
-func (_m *CourseRepository) GetCourseMaterialByCourseIDAndSectionID(ctx context.Context, _a1 *sqlx.DB, courseId string, sectionId string) ([]*db.Material, error) {
-	ret := _m.Called(ctx, _a1, courseId, sectionId)
+// GetCohortMembers provides a mock function with given fields: ctx, _a1, cohortId
+func (_m *CourseRepository) GetCohortMembers(ctx context.Context, _a1 *sqlx.DB, cohortId string) ([]*db.CohortUser, error) {
+	ret := _m.Called(ctx, _a1, cohortId)
 
-	var r0 []*db.Material
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.Material); ok {
-		r0 = rf(ctx, _a1, courseId, sectionId)
+	var r0 []*db.CohortUser
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.CohortUser); ok {
+		r0 = rf(ctx, _a1, cohortId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Material)
+			r0 = ret.Get(0).([]*db.CohortUser)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
-		r1 = rf(ctx, _a1, courseId, sectionId)
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, cohortId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -186,16 +309,16 @@ func (_m *CourseRepository) GetCourseMaterialByCourseIDAndSectionID(ctx context.
 	return r0, r1
 }
 
-// GetCourseSyllabusByCourseID provides a mock function with given fields: ctx, _a1, courseId
-func (_m *CourseRepository) GetCourseSyllabusByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Syllabus, error) {
+// GetCohortsByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetCohortsByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Cohort, error) {
 	ret := _m.Called(ctx, _a1, courseId)
 
-	var r0 []*db.Syllabus
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Syllabus); ok {
+	var r0 []*db.Cohort
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Cohort); ok {
 		r0 = rf(ctx, _a1, courseId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Syllabus)
+			r0 = ret.Get(0).([]*db.Cohort)
 		}
 	}
 
@@ -209,22 +332,22 @@ func (_m *CourseRepository) GetCourseSyllabusByCourseID(ctx context.Context, _a1
 	return r0, r1
 }
 
-// GetMaterialByID provides a mock function with given fields: ctx, _a1, id
-func (_m *CourseRepository) GetMaterialByID(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Material, error) {
-	ret := _m.Called(ctx, _a1, id)
+// GetCollaborationInvitations provides a mock function with given fields: ctx, _a1, userId
+func (_m *CourseRepository) GetCollaborationInvitations(ctx context.Context, _a1 *sqlx.DB, userId string) ([]*db.CourseCollaborator, error) {
+	ret := _m.Called(ctx, _a1, userId)
 
-	var r0 *db.Material
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Material); ok {
-		r0 = rf(ctx, _a1, id)
+	var r0 []*db.CourseCollaborator
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.CourseCollaborator); ok {
+		r0 = rf(ctx, _a1, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Material)
+			r0 = ret.Get(0).([]*db.CourseCollaborator)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
-		r1 = rf(ctx, _a1, id)
+		r1 = rf(ctx, _a1, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -232,66 +355,66 @@ func (_m *CourseRepository) GetMaterialByID(ctx context.Context, _a1 *sqlx.DB, i
 	return r0, r1
 }
 
-// GetOnProgressCourseByUserID provides a mock function with given fields: ctx, _a1, meta, userid
-func (_m *CourseRepository) GetOnProgressCourseByUserID(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, userid string) ([]*db.Course, uint64, error) {
-	ret := _m.Called(ctx, _a1, meta, userid)
+// GetCollaborator provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) GetCollaborator(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) (*db.CourseCollaborator, error) {
+	ret := _m.Called(ctx, _a1, courseId, userId)
 
-	var r0 []*db.Course
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.Course); ok {
-		r0 = rf(ctx, _a1, meta, userid)
+	var r0 *db.CourseCollaborator
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) *db.CourseCollaborator); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Course)
+			r0 = ret.Get(0).(*db.CourseCollaborator)
 		}
 	}
 
-	var r1 uint64
-	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
-		r1 = rf(ctx, _a1, meta, userid)
-	} else {
-		r1 = ret.Get(1).(uint64)
-	}
-
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
-		r2 = rf(ctx, _a1, meta, userid)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, userId)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetTableName provides a mock function with given fields:
-func (_m *CourseRepository) GetTableName() string {
-	ret := _m.Called()
+// GetCollaboratorRole provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) GetCollaboratorRole(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) (string, error) {
+	ret := _m.Called(ctx, _a1, courseId, userId)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) string); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetUserProgress provides a mock function with given fields: ctx, _a1, userID, courseID
-func (_m *CourseRepository) GetUserProgress(ctx context.Context, _a1 *sqlx.DB, userID string, courseID string) ([]*db.UserProgress, error) {
-	ret := _m.Called(ctx, _a1, userID, courseID)
+// GetCollaboratorsByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetCollaboratorsByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.CourseCollaborator, error) {
+	ret := _m.Called(ctx, _a1, courseId)
 
-	var r0 []*db.UserProgress
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.UserProgress); ok {
-		r0 = rf(ctx, _a1, userID, courseID)
+	var r0 []*db.CourseCollaborator
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.CourseCollaborator); ok {
+		r0 = rf(ctx, _a1, courseId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.UserProgress)
+			r0 = ret.Get(0).([]*db.CourseCollaborator)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
-		r1 = rf(ctx, _a1, userID, courseID)
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -299,21 +422,923 @@ func (_m *CourseRepository) GetUserProgress(ctx context.Context, _a1 *sqlx.DB, u
 	return r0, r1
 }
 
-// InsertCourse provides a mock function with given fields: ctx, _a1, course
-func (_m *CourseRepository) InsertCourse(ctx context.Context, _a1 *sqlx.DB, course *models.CourseCreation) error {
-	ret := _m.Called(ctx, _a1, course)
+// GetCompletedCourseByUserID provides a mock function with given fields: ctx, _a1, meta, userid
+func (_m *CourseRepository) GetCompletedCourseByUserID(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, userid string) ([]*db.Course, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, userid)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *models.CourseCreation) error); ok {
-		r0 = rf(ctx, _a1, course)
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.Course); ok {
+		r0 = rf(ctx, _a1, meta, userid)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
 	}
 
-	return r0
-}
-
-// InsertCourseData provides a mock function with given fields: ctx, _a1, course
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, userid)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
+		r2 = rf(ctx, _a1, meta, userid)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCompletionDurations provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetCompletionDurations(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]int64, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []int64); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseAnnouncement provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) GetCourseAnnouncement(ctx context.Context, _a1 *sqlx.DB, id string) (*db.CourseAnnouncement, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 *db.CourseAnnouncement
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.CourseAnnouncement); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.CourseAnnouncement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseAnnouncements provides a mock function with given fields: ctx, _a1, meta, courseId, userId
+func (_m *CourseRepository) GetCourseAnnouncements(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, courseId string, userId string) ([]*db.CourseAnnouncement, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, courseId, userId)
+
+	var r0 []*db.CourseAnnouncement
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) []*db.CourseAnnouncement); ok {
+		r0 = rf(ctx, _a1, meta, courseId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.CourseAnnouncement)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, courseId, userId)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) error); ok {
+		r2 = rf(ctx, _a1, meta, courseId, userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCourseByCreatorID provides a mock function with given fields: ctx, _a1, creatorId
+func (_m *CourseRepository) GetCourseByCreatorID(ctx context.Context, _a1 *sqlx.DB, creatorId string) ([]*db.Course, error) {
+	ret := _m.Called(ctx, _a1, creatorId)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Course); ok {
+		r0 = rf(ctx, _a1, creatorId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, creatorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseById provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) GetCourseById(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Course, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 *db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Course); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseIDByMaterialID provides a mock function with given fields: ctx, _a1, materialID
+func (_m *CourseRepository) GetCourseIDByMaterialID(ctx context.Context, _a1 *sqlx.DB, materialID string) (string, error) {
+	ret := _m.Called(ctx, _a1, materialID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) string); ok {
+		r0 = rf(ctx, _a1, materialID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, materialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseList provides a mock function with given fields: ctx, _a1, meta, filter
+func (_m *CourseRepository) GetCourseList(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, filter models.CourseFilter) ([]*db.Course, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, filter)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, models.CourseFilter) []*db.Course); ok {
+		r0 = rf(ctx, _a1, meta, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, models.CourseFilter) uint64); ok {
+		r1 = rf(ctx, _a1, meta, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, models.CourseFilter) error); ok {
+		r2 = rf(ctx, _a1, meta, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCourseListByStatus provides a mock function with given fields: ctx, _a1, meta, status, filter
+func (_m *CourseRepository) GetCourseListByStatus(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, status string, filter models.CourseFilter) ([]*db.Course, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, status, filter)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string, models.CourseFilter) []*db.Course); ok {
+		r0 = rf(ctx, _a1, meta, status, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string, models.CourseFilter) uint64); ok {
+		r1 = rf(ctx, _a1, meta, status, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string, models.CourseFilter) error); ok {
+		r2 = rf(ctx, _a1, meta, status, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCourseMaterialByCourseIDAndSectionID provides a mock function with given fields: ctx, _a1, courseId, sectionId
+func (_m *CourseRepository) GetCourseMaterialByCourseIDAndSectionID(ctx context.Context, _a1 *sqlx.DB, courseId string, sectionId string) ([]*db.Material, error) {
+	ret := _m.Called(ctx, _a1, courseId, sectionId)
+
+	var r0 []*db.Material
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.Material); ok {
+		r0 = rf(ctx, _a1, courseId, sectionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Material)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, sectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseRatings provides a mock function with given fields: ctx, _a1, courseIds
+func (_m *CourseRepository) GetCourseRatings(ctx context.Context, _a1 *sqlx.DB, courseIds []string) ([]*db.CourseRating, error) {
+	ret := _m.Called(ctx, _a1, courseIds)
+
+	var r0 []*db.CourseRating
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, []string) []*db.CourseRating); ok {
+		r0 = rf(ctx, _a1, courseIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.CourseRating)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, []string) error); ok {
+		r1 = rf(ctx, _a1, courseIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseReview provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) GetCourseReview(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) (*db.CourseReview, error) {
+	ret := _m.Called(ctx, _a1, courseId, userId)
+
+	var r0 *db.CourseReview
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) *db.CourseReview); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.CourseReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseReviews provides a mock function with given fields: ctx, _a1, meta, courseId
+func (_m *CourseRepository) GetCourseReviews(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, courseId string) ([]*db.CourseReview, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, courseId)
+
+	var r0 []*db.CourseReview
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.CourseReview); ok {
+		r0 = rf(ctx, _a1, meta, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.CourseReview)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, courseId)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
+		r2 = rf(ctx, _a1, meta, courseId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCourseSyllabusByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetCourseSyllabusByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Syllabus, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.Syllabus
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Syllabus); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Syllabus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollmentHistoryByUserID provides a mock function with given fields: ctx, _a1, meta, userId
+func (_m *CourseRepository) GetEnrollmentHistoryByUserID(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, userId string) ([]*db.EnrollmentHistory, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, userId)
+
+	var r0 []*db.EnrollmentHistory
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.EnrollmentHistory); ok {
+		r0 = rf(ctx, _a1, meta, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EnrollmentHistory)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, userId)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
+		r2 = rf(ctx, _a1, meta, userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetEnrollmentRequest provides a mock function with given fields: ctx, _a1, courseId, userId
+func (_m *CourseRepository) GetEnrollmentRequest(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string) (*db.EnrollmentRequest, error) {
+	ret := _m.Called(ctx, _a1, courseId, userId)
+
+	var r0 *db.EnrollmentRequest
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) *db.EnrollmentRequest); ok {
+		r0 = rf(ctx, _a1, courseId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.EnrollmentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollmentRequests provides a mock function with given fields: ctx, _a1, meta, courseId, status
+func (_m *CourseRepository) GetEnrollmentRequests(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, courseId string, status string) ([]*db.EnrollmentRequest, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, courseId, status)
+
+	var r0 []*db.EnrollmentRequest
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) []*db.EnrollmentRequest); ok {
+		r0 = rf(ctx, _a1, meta, courseId, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EnrollmentRequest)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, courseId, status)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string, string) error); ok {
+		r2 = rf(ctx, _a1, meta, courseId, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetEnrollmentStartDate provides a mock function with given fields: ctx, _a1, userId, courseId
+func (_m *CourseRepository) GetEnrollmentStartDate(ctx context.Context, _a1 *sqlx.DB, userId string, courseId string) (*string, error) {
+	ret := _m.Called(ctx, _a1, userId, courseId)
+
+	var r0 *string
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) *string); ok {
+		r0 = rf(ctx, _a1, userId, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollmentStats provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetEnrollmentStats(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.EnrollmentStat, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.EnrollmentStat
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.EnrollmentStat); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EnrollmentStat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialByID provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) GetMaterialByID(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Material, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 *db.Material
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Material); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Material)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialCompletions provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetMaterialCompletions(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.MaterialCompletion, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.MaterialCompletion
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.MaterialCompletion); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.MaterialCompletion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialProgress provides a mock function with given fields: ctx, _a1, userID, materialID
+func (_m *CourseRepository) GetMaterialProgress(ctx context.Context, _a1 *sqlx.DB, userID string, materialID string) (*db.MaterialProgress, error) {
+	ret := _m.Called(ctx, _a1, userID, materialID)
+
+	var r0 *db.MaterialProgress
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) *db.MaterialProgress); ok {
+		r0 = rf(ctx, _a1, userID, materialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.MaterialProgress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userID, materialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialProgressByCourseID provides a mock function with given fields: ctx, _a1, userID, courseID
+func (_m *CourseRepository) GetMaterialProgressByCourseID(ctx context.Context, _a1 *sqlx.DB, userID string, courseID string) ([]*db.MaterialProgress, error) {
+	ret := _m.Called(ctx, _a1, userID, courseID)
+
+	var r0 []*db.MaterialProgress
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.MaterialProgress); ok {
+		r0 = rf(ctx, _a1, userID, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.MaterialProgress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userID, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialsByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetMaterialsByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Material, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.Material
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Material); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Material)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMissingPrerequisites provides a mock function with given fields: ctx, _a1, userId, courseId
+func (_m *CourseRepository) GetMissingPrerequisites(ctx context.Context, _a1 *sqlx.DB, userId string, courseId string) ([]*db.Course, error) {
+	ret := _m.Called(ctx, _a1, userId, courseId)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.Course); ok {
+		r0 = rf(ctx, _a1, userId, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextMaterialPosition provides a mock function with given fields: ctx, _a1, courseId, sectionId
+func (_m *CourseRepository) GetNextMaterialPosition(ctx context.Context, _a1 *sqlx.DB, courseId string, sectionId string) (int, error) {
+	ret := _m.Called(ctx, _a1, courseId, sectionId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) int); ok {
+		r0 = rf(ctx, _a1, courseId, sectionId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, courseId, sectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOnProgressCourseByUserID provides a mock function with given fields: ctx, _a1, meta, userid
+func (_m *CourseRepository) GetOnProgressCourseByUserID(ctx context.Context, _a1 *sqlx.DB, meta *pagination.Meta, userid string) ([]*db.Course, uint64, error) {
+	ret := _m.Called(ctx, _a1, meta, userid)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *pagination.Meta, string) []*db.Course); ok {
+		r0 = rf(ctx, _a1, meta, userid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, *pagination.Meta, string) uint64); ok {
+		r1 = rf(ctx, _a1, meta, userid)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sqlx.DB, *pagination.Meta, string) error); ok {
+		r2 = rf(ctx, _a1, meta, userid)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPrerequisitesByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetPrerequisitesByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Course, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.Course
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Course); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTableName provides a mock function with given fields:
+func (_m *CourseRepository) GetTableName() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTopicById provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) GetTopicById(ctx context.Context, _a1 *sqlx.DB, id string) (*db.Topic, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 *db.Topic
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) *db.Topic); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Topic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTopicList provides a mock function with given fields: ctx, _a1
+func (_m *CourseRepository) GetTopicList(ctx context.Context, _a1 *sqlx.DB) ([]*db.Topic, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 []*db.Topic
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB) []*db.Topic); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Topic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTopicsByCourseID provides a mock function with given fields: ctx, _a1, courseId
+func (_m *CourseRepository) GetTopicsByCourseID(ctx context.Context, _a1 *sqlx.DB, courseId string) ([]*db.Topic, error) {
+	ret := _m.Called(ctx, _a1, courseId)
+
+	var r0 []*db.Topic
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.Topic); ok {
+		r0 = rf(ctx, _a1, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Topic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnreadAnnouncements provides a mock function with given fields: ctx, _a1, userId
+func (_m *CourseRepository) GetUnreadAnnouncements(ctx context.Context, _a1 *sqlx.DB, userId string) ([]*db.UnreadAnnouncement, error) {
+	ret := _m.Called(ctx, _a1, userId)
+
+	var r0 []*db.UnreadAnnouncement
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) []*db.UnreadAnnouncement); ok {
+		r0 = rf(ctx, _a1, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.UnreadAnnouncement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProgress provides a mock function with given fields: ctx, _a1, userID, courseID
+func (_m *CourseRepository) GetUserProgress(ctx context.Context, _a1 *sqlx.DB, userID string, courseID string) ([]*db.UserProgress, error) {
+	ret := _m.Called(ctx, _a1, userID, courseID)
+
+	var r0 []*db.UserProgress
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) []*db.UserProgress); ok {
+		r0 = rf(ctx, _a1, userID, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.UserProgress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userID, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasEnrollmentHistory provides a mock function with given fields: ctx, _a1, userId, courseId
+func (_m *CourseRepository) HasEnrollmentHistory(ctx context.Context, _a1 *sqlx.DB, userId string, courseId string) (bool, error) {
+	ret := _m.Called(ctx, _a1, userId, courseId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) bool); ok {
+		r0 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCourse provides a mock function with given fields: ctx, _a1, input
+func (_m *CourseRepository) ImportCourse(ctx context.Context, _a1 *sqlx.DB, input *db.CourseImport) error {
+	ret := _m.Called(ctx, _a1, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseImport) error); ok {
+		r0 = rf(ctx, _a1, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertCohort provides a mock function with given fields: ctx, _a1, cohort, instructors
+func (_m *CourseRepository) InsertCohort(ctx context.Context, _a1 *sqlx.DB, cohort *db.Cohort, instructors []string) error {
+	ret := _m.Called(ctx, _a1, cohort, instructors)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Cohort, []string) error); ok {
+		r0 = rf(ctx, _a1, cohort, instructors)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertCollaborator provides a mock function with given fields: ctx, _a1, collaborator
+func (_m *CourseRepository) InsertCollaborator(ctx context.Context, _a1 *sqlx.DB, collaborator *db.CourseCollaborator) error {
+	ret := _m.Called(ctx, _a1, collaborator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseCollaborator) error); ok {
+		r0 = rf(ctx, _a1, collaborator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertCourse provides a mock function with given fields: ctx, _a1, course
+func (_m *CourseRepository) InsertCourse(ctx context.Context, _a1 *sqlx.DB, course *models.CourseCreation) error {
+	ret := _m.Called(ctx, _a1, course)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *models.CourseCreation) error); ok {
+		r0 = rf(ctx, _a1, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertCourseAnnouncement provides a mock function with given fields: ctx, _a1, announcement
+func (_m *CourseRepository) InsertCourseAnnouncement(ctx context.Context, _a1 *sqlx.DB, announcement *db.CourseAnnouncement) error {
+	ret := _m.Called(ctx, _a1, announcement)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseAnnouncement) error); ok {
+		r0 = rf(ctx, _a1, announcement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertCourseData provides a mock function with given fields: ctx, _a1, course
 func (_m *CourseRepository) InsertCourseData(ctx context.Context, _a1 *sqlx.DB, course *db.Course) error {
 	ret := _m.Called(ctx, _a1, course)
 
@@ -355,6 +1380,83 @@ func (_m *CourseRepository) InsertEnrollment(ctx context.Context, _a1 *sqlx.DB,
 	return r0
 }
 
+// InsertTopic provides a mock function with given fields: ctx, _a1, topic
+func (_m *CourseRepository) InsertTopic(ctx context.Context, _a1 *sqlx.DB, topic *db.Topic) error {
+	ret := _m.Called(ctx, _a1, topic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Topic) error); ok {
+		r0 = rf(ctx, _a1, topic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsAssignmentExists provides a mock function with given fields: ctx, _a1, id
+func (_m *CourseRepository) IsAssignmentExists(ctx context.Context, _a1 *sqlx.DB, id string) (bool, error) {
+	ret := _m.Called(ctx, _a1, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string) bool); ok {
+		r0 = rf(ctx, _a1, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string) error); ok {
+		r1 = rf(ctx, _a1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsCohortInstructor provides a mock function with given fields: ctx, _a1, cohortId, userId
+func (_m *CourseRepository) IsCohortInstructor(ctx context.Context, _a1 *sqlx.DB, cohortId string, userId string) (bool, error) {
+	ret := _m.Called(ctx, _a1, cohortId, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) bool); ok {
+		r0 = rf(ctx, _a1, cohortId, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, cohortId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsCourseCompleted provides a mock function with given fields: ctx, _a1, userId, courseId
+func (_m *CourseRepository) IsCourseCompleted(ctx context.Context, _a1 *sqlx.DB, userId string, courseId string) (bool, error) {
+	ret := _m.Called(ctx, _a1, userId, courseId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string) bool); ok {
+		r0 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqlx.DB, string, string) error); ok {
+		r1 = rf(ctx, _a1, userId, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsUserEnrolledToCourse provides a mock function with given fields: ctx, _a1, userid, courseid
 func (_m *CourseRepository) IsUserEnrolledToCourse(ctx context.Context, _a1 *sqlx.DB, userid string, courseid string) (bool, error) {
 	ret := _m.Called(ctx, _a1, userid, courseid)
@@ -376,6 +1478,76 @@ func (_m *CourseRepository) IsUserEnrolledToCourse(ctx context.Context, _a1 *sql
 	return r0, r1
 }
 
+// MarkAnnouncementRead provides a mock function with given fields: ctx, _a1, announcement, userId
+func (_m *CourseRepository) MarkAnnouncementRead(ctx context.Context, _a1 *sqlx.DB, announcement *db.CourseAnnouncement, userId string) error {
+	ret := _m.Called(ctx, _a1, announcement, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseAnnouncement, string) error); ok {
+		r0 = rf(ctx, _a1, announcement, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCoursePrerequisites provides a mock function with given fields: ctx, _a1, courseId, prerequisiteIds
+func (_m *CourseRepository) SetCoursePrerequisites(ctx context.Context, _a1 *sqlx.DB, courseId string, prerequisiteIds []string) error {
+	ret := _m.Called(ctx, _a1, courseId, prerequisiteIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, []string) error); ok {
+		r0 = rf(ctx, _a1, courseId, prerequisiteIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCourseReviewHidden provides a mock function with given fields: ctx, _a1, courseId, userId, hidden
+func (_m *CourseRepository) SetCourseReviewHidden(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string, hidden bool) error {
+	ret := _m.Called(ctx, _a1, courseId, userId, hidden)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string, bool) error); ok {
+		r0 = rf(ctx, _a1, courseId, userId, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCourseTopics provides a mock function with given fields: ctx, _a1, courseId, topicIds
+func (_m *CourseRepository) SetCourseTopics(ctx context.Context, _a1 *sqlx.DB, courseId string, topicIds []string) error {
+	ret := _m.Called(ctx, _a1, courseId, topicIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, []string) error); ok {
+		r0 = rf(ctx, _a1, courseId, topicIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEnrollmentRequestStatus provides a mock function with given fields: ctx, _a1, courseId, userId, status
+func (_m *CourseRepository) SetEnrollmentRequestStatus(ctx context.Context, _a1 *sqlx.DB, courseId string, userId string, status string) error {
+	ret := _m.Called(ctx, _a1, courseId, userId, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, string, string, string) error); ok {
+		r0 = rf(ctx, _a1, courseId, userId, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreUserProgress provides a mock function with given fields: ctx, _a1, materialID, courseID, userID, score
 func (_m *CourseRepository) StoreUserProgress(ctx context.Context, _a1 *sqlx.DB, materialID string, courseID string, userID string, score int) error {
 	ret := _m.Called(ctx, _a1, materialID, courseID, userID, score)
@@ -389,3 +1561,185 @@ func (_m *CourseRepository) StoreUserProgress(ctx context.Context, _a1 *sqlx.DB,
 
 	return r0
 }
+
+// UpdateCohort provides a mock function with given fields: ctx, _a1, cohort, instructors
+func (_m *CourseRepository) UpdateCohort(ctx context.Context, _a1 *sqlx.DB, cohort *db.Cohort, instructors []string) error {
+	ret := _m.Called(ctx, _a1, cohort, instructors)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Cohort, []string) error); ok {
+		r0 = rf(ctx, _a1, cohort, instructors)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCollaborator provides a mock function with given fields: ctx, _a1, collaborator
+func (_m *CourseRepository) UpdateCollaborator(ctx context.Context, _a1 *sqlx.DB, collaborator *db.CourseCollaborator) error {
+	ret := _m.Called(ctx, _a1, collaborator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseCollaborator) error); ok {
+		r0 = rf(ctx, _a1, collaborator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourseAccess provides a mock function with given fields: ctx, _a1, course
+func (_m *CourseRepository) UpdateCourseAccess(ctx context.Context, _a1 *sqlx.DB, course *db.Course) error {
+	ret := _m.Called(ctx, _a1, course)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Course) error); ok {
+		r0 = rf(ctx, _a1, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourseAnnouncement provides a mock function with given fields: ctx, _a1, announcement
+func (_m *CourseRepository) UpdateCourseAnnouncement(ctx context.Context, _a1 *sqlx.DB, announcement *db.CourseAnnouncement) error {
+	ret := _m.Called(ctx, _a1, announcement)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseAnnouncement) error); ok {
+		r0 = rf(ctx, _a1, announcement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourseData provides a mock function with given fields: ctx, _a1, course
+func (_m *CourseRepository) UpdateCourseData(ctx context.Context, _a1 *sqlx.DB, course *db.Course) error {
+	ret := _m.Called(ctx, _a1, course)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Course) error); ok {
+		r0 = rf(ctx, _a1, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourseMaterial provides a mock function with given fields: ctx, _a1, material
+func (_m *CourseRepository) UpdateCourseMaterial(ctx context.Context, _a1 *sqlx.DB, material *db.Material) error {
+	ret := _m.Called(ctx, _a1, material)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Material) error); ok {
+		r0 = rf(ctx, _a1, material)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourseStatus provides a mock function with given fields: ctx, _a1, input
+func (_m *CourseRepository) UpdateCourseStatus(ctx context.Context, _a1 *sqlx.DB, input *models.CourseStatusUpdate) error {
+	ret := _m.Called(ctx, _a1, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *models.CourseStatusUpdate) error); ok {
+		r0 = rf(ctx, _a1, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEnrollmentSettings provides a mock function with given fields: ctx, _a1, values
+func (_m *CourseRepository) UpdateEnrollmentSettings(ctx context.Context, _a1 *sqlx.DB, values *db.Course) error {
+	ret := _m.Called(ctx, _a1, values)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Course) error); ok {
+		r0 = rf(ctx, _a1, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMaterialPositions provides a mock function with given fields: ctx, _a1, positions
+func (_m *CourseRepository) UpdateMaterialPositions(ctx context.Context, _a1 *sqlx.DB, positions []*db.MaterialPosition) error {
+	ret := _m.Called(ctx, _a1, positions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, []*db.MaterialPosition) error); ok {
+		r0 = rf(ctx, _a1, positions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTopic provides a mock function with given fields: ctx, _a1, topic
+func (_m *CourseRepository) UpdateTopic(ctx context.Context, _a1 *sqlx.DB, topic *db.Topic) error {
+	ret := _m.Called(ctx, _a1, topic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.Topic) error); ok {
+		r0 = rf(ctx, _a1, topic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertCourseReview provides a mock function with given fields: ctx, _a1, review
+func (_m *CourseRepository) UpsertCourseReview(ctx context.Context, _a1 *sqlx.DB, review *db.CourseReview) error {
+	ret := _m.Called(ctx, _a1, review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.CourseReview) error); ok {
+		r0 = rf(ctx, _a1, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertEnrollmentRequest provides a mock function with given fields: ctx, _a1, request
+func (_m *CourseRepository) UpsertEnrollmentRequest(ctx context.Context, _a1 *sqlx.DB, request *db.EnrollmentRequest) error {
+	ret := _m.Called(ctx, _a1, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.EnrollmentRequest) error); ok {
+		r0 = rf(ctx, _a1, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertMaterialProgress provides a mock function with given fields: ctx, _a1, progress
+func (_m *CourseRepository) UpsertMaterialProgress(ctx context.Context, _a1 *sqlx.DB, progress *db.MaterialProgress) error {
+	ret := _m.Called(ctx, _a1, progress)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sqlx.DB, *db.MaterialProgress) error); ok {
+		r0 = rf(ctx, _a1, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
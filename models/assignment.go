@@ -60,5 +60,6 @@ type ProblemAnswer struct {
 }
 
 type AssignmentScore struct {
-	Score int `json:"score"`
+	Score     int  `json:"score"`
+	Completed bool `json:"completed"`
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package models
+
+type Certificate struct {
+	ID          string `json:"id"`
+	CourseID    string `json:"course_id"`
+	LearnerName string `json:"learner_name"`
+	CourseName  string `json:"course_name"`
+	FinishDate  string `json:"finish_date"`
+	FinalScore  int    `json:"final_score"`
+	Signature   string `json:"signature"`
+	URL         string `json:"url"`
+	VerifyURL   string `json:"verify_url"`
+}
+
+type CertificateVerification struct {
+	Valid       bool         `json:"valid"`
+	Certificate *Certificate `json:"certificate"`
+}
@@ -1,17 +1,189 @@
 package models
 
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	CourseStatusDraft     = "draft"
+	CourseStatusInReview  = "in_review"
+	CourseStatusPublished = "published"
+	CourseStatusArchived  = "archived"
+)
+
 type Course struct {
-	ID          string `json:"id"`
-	CourseName  string `json:"course_name"`
-	Description string `json:"description"`
-	Thumbnail   string `json:"thumbnail"`
-	Creator     string `json:"creator"`
-	// TODO: Topic
+	ID             string   `json:"id"`
+	CourseName     string   `json:"course_name"`
+	Description    string   `json:"description"`
+	Thumbnail      string   `json:"thumbnail"`
+	Creator        string   `json:"creator"`
+	Status         string   `json:"status"`
+	Sequential     bool     `json:"sequential"`
+	MinScore       int      `json:"min_score"`
+	EnrollmentMode string   `json:"enrollment_mode"`
+	Capacity       int      `json:"capacity"`
+	Topics         []*Topic `json:"topics"`
+	Rating         float64  `json:"rating"`
+	ReviewCount    int      `json:"review_count"`
+}
+
+type Topic struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TopicInput struct {
+	Name string `json:"name" validate:"required" label:"name"`
+}
+
+type TopicResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ID      string `json:"id"`
+}
+
+type CourseTopicInput struct {
+	Topics []string `json:"topics"`
+}
+
+type Prerequisite struct {
+	ID         string `json:"id"`
+	CourseName string `json:"course_name"`
+	Completed  bool   `json:"completed"`
+}
+
+type CoursePrerequisiteInput struct {
+	Prerequisites []string `json:"prerequisites"`
+}
+
+// CourseAccessInput configures the sequential mode of a course. In sequential
+// mode a material stays locked until the previous material in syllabus order
+// has been completed with at least MinScore.
+type CourseAccessInput struct {
+	Sequential bool `json:"sequential"`
+	MinScore   int  `json:"min_score" validate:"min=0,max=100" label:"min_score"`
+}
+
+// CourseCloneInput describes the copy made by the clone endpoint. The copy is
+// always a draft owned by the caller.
+type CourseCloneInput struct {
+	CourseName string `json:"course_name"`
+}
+
+// The creator of a course is always its owner. Other users join a course by
+// accepting an invitation: owners manage the course and its collaborators,
+// editors maintain its content and teaching assistants help learners without
+// changing the course.
+const (
+	CourseRoleOwner             = "owner"
+	CourseRoleEditor            = "editor"
+	CourseRoleTeachingAssistant = "teaching_assistant"
+
+	CollaboratorStatusPending  = "pending"
+	CollaboratorStatusAccepted = "accepted"
+)
+
+type CourseCollaborator struct {
+	UserID    string `json:"user_id"`
+	Fullname  string `json:"fullname"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	InvitedBy string `json:"invited_by"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CollaboratorInvitation struct {
+	CourseID   string `json:"course_id"`
+	CourseName string `json:"course_name"`
+	Role       string `json:"role"`
+	InvitedBy  string `json:"invited_by"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type CollaboratorInviteInput struct {
+	Email string `json:"email" validate:"required" label:"email"`
+	Role  string `json:"role" validate:"required" label:"role"`
+}
+
+type CollaboratorRoleInput struct {
+	Role string `json:"role" validate:"required" label:"role"`
+}
+
+const CourseSortRating = "rating"
+
+type CourseFilter struct {
+	Topic   string `json:"topic"`
+	Creator string `json:"creator"`
+	Query   string `json:"q"`
+	Sort    string `json:"sort"`
+}
+
+func (f *CourseFilter) FromContext(c echo.Context) *CourseFilter {
+	f.Topic = c.QueryParam("topic")
+	f.Creator = c.QueryParam("creator")
+	f.Query = c.QueryParam("q")
+	f.Sort = c.QueryParam("sort")
+	return f
 }
 
+const (
+	EnrollmentActionEnroll   = "enroll"
+	EnrollmentActionReEnroll = "re_enroll"
+	EnrollmentActionUnenroll = "unenroll"
+	EnrollmentActionComplete = "complete"
+)
+
 type EnrollInput struct {
-	UserID   string `db:"user_id"`
-	CourseID string `db:"course_id"`
+	UserID       string `db:"user_id"`
+	CourseID     string `db:"course_id"`
+	Action       string `db:"action"`
+	KeepProgress bool   `db:"keep_progress"`
+	// CohortID is set when the user enrolls with the join code of a cohort.
+	CohortID string `db:"cohort_id"`
+	// Capacity is set to the seats of a capped course, the enrollment is
+	// refused with StatusConflict once every seat is taken.
+	Capacity int `db:"-"`
+}
+
+// A course is open to everyone by default. Code protected courses require
+// the enrollment key or the join code of one of their cohorts, capped courses
+// put learners on a waitlist once every seat is taken and approval courses
+// queue learners until the staff approves them.
+const (
+	EnrollmentModeOpen     = "open"
+	EnrollmentModeCode     = "code"
+	EnrollmentModeCapped   = "capped"
+	EnrollmentModeApproval = "approval"
+)
+
+const (
+	EnrollmentRequestPending    = "pending"
+	EnrollmentRequestWaitlisted = "waitlisted"
+	EnrollmentRequestDenied     = "denied"
+)
+
+type EnrollmentSettingsInput struct {
+	Mode          string `json:"mode" validate:"required" label:"mode"`
+	EnrollmentKey string `json:"enrollment_key"`
+	Capacity      int    `json:"capacity"`
+}
+
+type EnrollmentRequest struct {
+	UserID    string `json:"user_id"`
+	Fullname  string `json:"fullname"`
+	Email     string `json:"email"`
+	CohortID  string `json:"cohort_id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+type EnrollmentHistory struct {
+	CourseID     string `json:"course_id"`
+	CourseName   string `json:"course_name"`
+	Action       string `json:"action"`
+	KeepProgress bool   `json:"keep_progress"`
+	CreatedAt    string `json:"created_at"`
 }
 
 type EnrollResponse struct {
@@ -20,8 +192,32 @@ type EnrollResponse struct {
 }
 
 type StoreProgressResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Status    string            `json:"status"`
+	Message   string            `json:"message"`
+	Completed bool              `json:"completed"`
+	Progress  *MaterialProgress `json:"progress,omitempty"`
+}
+
+// ProgressEvent is the heartbeat sent while a material is open. TimeSpent is
+// the number of seconds since the previous event and Position is where the
+// learner is in the material, in seconds for videos.
+type ProgressEvent struct {
+	TimeSpent  int `json:"timeSpent" validate:"min=0" label:"timeSpent"`
+	Percentage int `json:"percentage" validate:"min=0,max=100" label:"percentage"`
+	Position   int `json:"position" validate:"min=0" label:"position"`
+}
+
+// MaterialProgress is the accumulated progress of a learner on a material.
+// Threshold is the percentage needed to complete the material, -1 when only
+// a submission completes it.
+type MaterialProgress struct {
+	MaterialID   string `json:"materialId"`
+	TimeSpent    int    `json:"timeSpent"`
+	Percentage   int    `json:"percentage"`
+	LastPosition int    `json:"lastPosition"`
+	Threshold    int    `json:"threshold"`
+	Completed    bool   `json:"completed"`
+	UpdatedAt    string `json:"updatedAt"`
 }
 
 type GetProgressPercentageResponse struct {
@@ -35,8 +231,30 @@ type UserProgress struct {
 	Score      int    `json:"score"`
 }
 
+// ResumeMaterial is a material of the syllabus along with the section it
+// belongs to. LastPosition is only set for the last visited material.
+type ResumeMaterial struct {
+	MaterialID   string `json:"materialID"`
+	MaterialName string `json:"materialName"`
+	MaterialType string `json:"materialType"`
+	SectionID    string `json:"sectionID"`
+	SectionName  string `json:"sectionName"`
+	LastPosition int    `json:"lastPosition"`
+}
+
+// CourseResume tells a learner where to continue a course. Next is nil once
+// every material is completed and LastVisited until a material is opened.
+type CourseResume struct {
+	CourseID    string          `json:"courseId"`
+	CourseName  string          `json:"courseName"`
+	Thumbnail   string          `json:"thumbnail"`
+	Next        *ResumeMaterial `json:"next"`
+	LastVisited *ResumeMaterial `json:"lastVisited"`
+}
+
 type GetProgressResponse struct {
-	Progress []*UserProgress `json:"progress"`
+	Progress  []*UserProgress     `json:"progress"`
+	Materials []*MaterialProgress `json:"materials"`
 }
 
 type CourseCreation struct {
@@ -45,17 +263,20 @@ type CourseCreation struct {
 }
 
 type SectionCreation struct {
-	ID          string              `json:"sectionID"`
-	Name        string              `json:"sectionName" validate:"required" label:"sectionName"`
-	Subsections []*MaterialCreation `json:"subSections" validate:"required" label:"subSections"`
+	ID               string              `json:"sectionID"`
+	Name             string              `json:"sectionName" validate:"required" label:"sectionName"`
+	ReleaseAt        *string             `json:"releaseAt"`
+	ReleaseAfterDays *int                `json:"releaseAfterDays" validate:"omitempty,min=0" label:"releaseAfterDays"`
+	Subsections      []*MaterialCreation `json:"subSections" validate:"required" label:"subSections"`
 }
 
 type MaterialCreation struct {
-	ID          string `json:"materialID"`
-	Name        string `json:"materialName" validate:"required" label:"materialName"`
-	Type        string `json:"materialType" validate:"required" label:"materialType"`
-	Content     string `json:"materialContent" validate:"required"`
-	ContentText string `json:"materialContentText" validate:"required"`
+	ID          string            `json:"materialID"`
+	Name        string            `json:"materialName" validate:"required" label:"materialName"`
+	Type        string            `json:"materialType" validate:"required" label:"materialType"`
+	Content     string            `json:"materialContent" validate:"required"`
+	ContentText string            `json:"materialContentText" validate:"required"`
+	Metadata    *MaterialMetadata `json:"materialMetadata,omitempty"`
 }
 
 type CourseCreationResponse struct {
@@ -76,15 +297,61 @@ type CourseDescriptionInput struct {
 	Thumbnail   string `json:"thumbnail" validate:"required" label:"thumbnail"`
 }
 
+// Sections are released right away unless they carry a release rule, either
+// an absolute ReleaseAt date (RFC3339) or ReleaseAfterDays counted from the
+// learner's enrollment.
 type CourseSectionInput struct {
-	Name     string `json:"sectionName" validate:"required" label:"sectionName"`
-	CourseID string `json:"courseID" validate:"required" label:"courseID"`
+	Name             string  `json:"sectionName" validate:"required" label:"sectionName"`
+	CourseID         string  `json:"courseID" validate:"required" label:"courseID"`
+	ReleaseAt        *string `json:"releaseAt"`
+	ReleaseAfterDays *int    `json:"releaseAfterDays" validate:"omitempty,min=0" label:"releaseAfterDays"`
 }
 
+// CourseMaterialInput is validated against the registered material types,
+// the type decides what Content holds and which Metadata is required.
 type CourseMaterialInput struct {
-	Name   string `json:"materialName" validate:"required" label:"materialName"`
-	Type        string `json:"materialType" validate:"required" label:"materialType"`
-	Content     string `json:"materialContent"`
-	ContentText string `json:"materialContentText"`
-	SectionID string `json:"sectionID" validate:"required" label:"sectionID"`
+	Name   string            `json:"materialName" validate:"required" label:"materialName"`
+	Type        string            `json:"materialType" validate:"required" label:"materialType"`
+	Content     string            `json:"materialContent"`
+	ContentText string            `json:"materialContentText"`
+	Metadata    *MaterialMetadata `json:"materialMetadata"`
+	SectionID string            `json:"sectionID" validate:"required" label:"sectionID"`
+}
+
+type CourseDescriptionUpdate struct {
+	CourseName  *string `json:"course_name"`
+	Description *string `json:"description"`
+	Thumbnail   *string `json:"thumbnail"`
+}
+
+type CourseSectionUpdateInput struct {
+	Name             string  `json:"sectionName" validate:"required" label:"sectionName"`
+	ReleaseAt        *string `json:"releaseAt"`
+	ReleaseAfterDays *int    `json:"releaseAfterDays" validate:"omitempty,min=0" label:"releaseAfterDays"`
+}
+
+type CourseMaterialUpdateInput struct {
+	Name        string            `json:"materialName" validate:"required" label:"materialName"`
+	Type        string            `json:"materialType" validate:"required" label:"materialType"`
+	Content     string            `json:"materialContent"`
+	ContentText string            `json:"materialContentText"`
+	Metadata    *MaterialMetadata `json:"materialMetadata"`
+}
+
+type CourseMaterialUpdate struct {
+	Name        *string           `json:"materialName"`
+	Type        *string           `json:"materialType"`
+	Content     *string           `json:"materialContent"`
+	ContentText *string           `json:"materialContentText"`
+	Metadata    *MaterialMetadata `json:"materialMetadata"`
+}
+
+type CourseUpdateResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type CourseStatusUpdate struct {
+	Id     string
+	Status string
 }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package models
+
+// EnrollmentStat counts the learners who started the course on Date, Total
+// is the running count up to and including that day.
+type EnrollmentStat struct {
+	Date        string `json:"date"`
+	Enrollments int    `json:"enrollments"`
+	Total       int    `json:"total"`
+}
+
+// MaterialFunnel tells how many learners completed the material, DropOff is
+// the number of learners who completed the step before it but not this one.
+type MaterialFunnel struct {
+	MaterialID   string `json:"material_id"`
+	MaterialName string `json:"material_name"`
+	MaterialType string `json:"material_type"`
+	SectionName  string `json:"section_name"`
+	Completed    int    `json:"completed"`
+	Percentage   int    `json:"percentage"`
+	DropOff      int    `json:"drop_off"`
+}
+
+// CourseFunnel lists the materials in syllabus order. DropOffPoint is the
+// material losing the most learners, empty when nobody dropped off.
+type CourseFunnel struct {
+	Learners     int               `json:"learners"`
+	DropOffPoint string            `json:"drop_off_point"`
+	Materials    []*MaterialFunnel `json:"materials"`
+}
+
+type AssignmentScoreStat struct {
+	MaterialID   string  `json:"material_id"`
+	MaterialName string  `json:"material_name"`
+	Submissions  int     `json:"submissions"`
+	AverageScore float64 `json:"average_score"`
+}
+
+type CompletionTime struct {
+	Completed  int     `json:"completed"`
+	MedianDays float64 `json:"median_days"`
+}
@@ -0,0 +1,26 @@
+package models
+
+type CourseAnnouncement struct {
+	ID        string `json:"id"`
+	CourseID  string `json:"course_id"`
+	AuthorID  string `json:"author_id"`
+	Author    string `json:"author"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type CourseAnnouncementInput struct {
+	Title   string `json:"title" validate:"required" label:"title"`
+	Content string `json:"content" validate:"required" label:"content"`
+}
+
+// UnreadAnnouncement counts the announcements of an on progress course the
+// learner has not read yet.
+type UnreadAnnouncement struct {
+	CourseID   string `json:"course_id"`
+	CourseName string `json:"course_name"`
+	Unread     int    `json:"unread"`
+}
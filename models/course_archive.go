@@ -0,0 +1,52 @@
+package models
+
+// CourseArchiveVersion is bumped whenever the layout of the archive changes
+// in a way older instances cannot read.
+const CourseArchiveVersion = 1
+
+// CourseArchive is the manifest.json of an exported course. The archive also
+// holds the uploaded images listed in Images under images/. Ids are only
+// meaningful inside the archive, every one of them is replaced on import.
+type CourseArchive struct {
+	Version     int                   `json:"version"`
+	Course      CourseCreation        `json:"course"`
+	Assignments []*ArchivedAssignment `json:"assignments"`
+	Problems    []*ArchivedProblem    `json:"problems"`
+	Images      []string              `json:"images"`
+}
+
+// ArchivedAssignment shares its id with the assignment material linking it.
+type ArchivedAssignment struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Duration   int      `json:"duration"`
+	Topic      string   `json:"topic"`
+	Difficulty string   `json:"difficulty"`
+	Problems   []string `json:"problems"`
+}
+
+type ArchivedProblem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty"`
+	Detail     string `json:"detail"`
+}
+
+// ContentPackageImportResponse lists the items of an IMS Common Cartridge or
+// SCORM package which could not be turned into materials.
+type ContentPackageImportResponse struct {
+	Status      string                    `json:"status"`
+	Message     string                    `json:"message"`
+	Id          string                    `json:"id"`
+	Format      string                    `json:"format"`
+	Unsupported []*UnsupportedPackageItem `json:"unsupported"`
+}
+
+type UnsupportedPackageItem struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Reason     string `json:"reason"`
+}
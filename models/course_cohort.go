@@ -0,0 +1,41 @@
+package models
+
+// Cohort is a class running a course on its own schedule. Learners join a
+// cohort by enrolling to the course with its join code.
+type Cohort struct {
+	ID          string        `json:"id"`
+	CourseID    string        `json:"course_id"`
+	Name        string        `json:"name"`
+	JoinCode    string        `json:"join_code"`
+	StartDate   string        `json:"start_date"`
+	EndDate     string        `json:"end_date"`
+	Instructors []*CohortUser `json:"instructors"`
+}
+
+type CohortUser struct {
+	UserID   string `json:"user_id"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+}
+
+type CohortMember struct {
+	UserID     string `json:"user_id"`
+	Fullname   string `json:"fullname"`
+	Email      string `json:"email"`
+	Percentage int    `json:"percentage"`
+}
+
+// CohortInput takes the dates in RFC3339 and the instructors by email.
+type CohortInput struct {
+	Name        string   `json:"name" validate:"required" label:"name"`
+	StartDate   string   `json:"start_date" validate:"required" label:"start_date"`
+	EndDate     string   `json:"end_date" validate:"required" label:"end_date"`
+	Instructors []string `json:"instructors"`
+}
+
+type CohortResponse struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	ID       string `json:"id"`
+	JoinCode string `json:"join_code"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// Learners may only rate a course once their progress on it reaches
+// MinReviewProgress percent.
+const (
+	MinReviewProgress = 50
+	MinCourseRating   = 1
+	MaxCourseRating   = 5
+)
+
+type CourseReview struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	Rating    int    `json:"rating"`
+	Review    string `json:"review"`
+	Hidden    bool   `json:"hidden"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type CourseReviewInput struct {
+	Rating int    `json:"rating" validate:"required" label:"rating"`
+	Review string `json:"review"`
+}
+
+type ReviewModerationInput struct {
+	Hidden bool `json:"hidden"`
+}
@@ -0,0 +1,21 @@
+package db
+
+type CourseCompletion struct {
+	UserID      string `db:"user_id"`
+	CourseID    string `db:"course_id"`
+	LearnerName string `db:"learner_name"`
+	CourseName  string `db:"course_name"`
+	FinishDate  string `db:"finish_date"`
+	FinalScore  int    `db:"final_score"`
+}
+
+type Certificate struct {
+	ID          string `db:"id"`
+	UserID      string `db:"user_id"`
+	CourseID    string `db:"course_id"`
+	LearnerName string `db:"learner_name"`
+	CourseName  string `db:"course_name"`
+	FinishDate  string `db:"finish_date"`
+	FinalScore  int    `db:"final_score"`
+	Signature   string `db:"signature"`
+}
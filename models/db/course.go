@@ -6,7 +6,43 @@ type Course struct {
 	Description string `db:"description"`
 	Thumbnail   string `db:"thumbnail"`
 	Creator     string `db:"creator"`
-	// TODO: Topic
+	Status      string `db:"status"`
+	Sequential  bool   `db:"sequential"`
+	MinScore    int    `db:"min_score"`
+	// EnrollmentKey is only used in the code enrollment mode and Capacity
+	// only in the capped mode.
+	EnrollmentMode string `db:"enrollment_mode"`
+	EnrollmentKey  string `db:"enrollment_key"`
+	Capacity       int    `db:"capacity"`
+}
+
+// CourseClone holds a deep copy of SourceID ready to be stored. Materials
+// already carry new ids, Assignments maps the linked assignments of the
+// source to the ids of their copies.
+type CourseClone struct {
+	SourceID    string
+	Course      Course
+	Materials   []Material
+	Assignments []ClonedAssignment
+}
+
+type ClonedAssignment struct {
+	SourceID string
+	ID       string
+}
+
+// CourseImport holds a course read from an archive together with the
+// problems and assignments it brings along. Every id is already new.
+type CourseImport struct {
+	Course      Course
+	Materials   []Material
+	Problems    []ProblemCandidate
+	Assignments []AssignmentCreation
+}
+
+type Topic struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
 }
 
 type UserProgress struct {
@@ -15,3 +51,115 @@ type UserProgress struct {
 	MaterialID string `db:"material_id"`
 	Score      int    `db:"score"`
 }
+
+type MaterialProgress struct {
+	UserID       string `db:"user_id"`
+	CourseID     string `db:"course_id"`
+	MaterialID   string `db:"material_id"`
+	TimeSpent    int    `db:"time_spent"`
+	Percentage   int    `db:"percentage"`
+	LastPosition int    `db:"last_position"`
+	Completed    bool   `db:"completed"`
+	UpdatedAt    string `db:"updated_at"`
+}
+
+type EnrollmentHistory struct {
+	UserID       string `db:"user_id"`
+	CourseID     string `db:"course_id"`
+	CourseName   string `db:"course_name"`
+	Action       string `db:"action"`
+	KeepProgress bool   `db:"keep_progress"`
+	CreatedAt    string `db:"created_at"`
+}
+
+type CourseCollaborator struct {
+	CourseID   string `db:"course_id"`
+	CourseName string `db:"course_name"`
+	UserID     string `db:"user_id"`
+	Fullname   string `db:"fullname"`
+	Email      string `db:"email"`
+	Role       string `db:"role"`
+	Status     string `db:"status"`
+	InvitedBy  string `db:"invited_by"`
+	CreatedAt  string `db:"created_at"`
+}
+
+type CourseReview struct {
+	CourseID  string `db:"course_id"`
+	UserID    string `db:"user_id"`
+	Username  string `db:"username"`
+	Rating    int    `db:"rating"`
+	Review    string `db:"review"`
+	Hidden    bool   `db:"hidden"`
+	CreatedAt string `db:"created_at"`
+	UpdatedAt string `db:"updated_at"`
+}
+
+// CourseRating only accounts for reviews which are not hidden.
+type CourseRating struct {
+	CourseID string  `db:"course_id"`
+	Average  float64 `db:"average"`
+	Count    int     `db:"count"`
+}
+
+// CourseAnnouncement is read from the point of view of a single user, Read
+// tells whether that user has marked the announcement as read.
+type CourseAnnouncement struct {
+	ID        string `db:"id"`
+	CourseID  string `db:"course_id"`
+	AuthorID  string `db:"author_id"`
+	Author    string `db:"author"`
+	Title     string `db:"title"`
+	Content   string `db:"content"`
+	Read      bool   `db:"is_read"`
+	CreatedAt string `db:"created_at"`
+	UpdatedAt string `db:"updated_at"`
+}
+
+type UnreadAnnouncement struct {
+	CourseID   string `db:"course_id"`
+	CourseName string `db:"course_name"`
+	Unread     int    `db:"unread"`
+}
+
+type Cohort struct {
+	ID        string `db:"id"`
+	CourseID  string `db:"course_id"`
+	Name      string `db:"name"`
+	JoinCode  string `db:"join_code"`
+	StartDate string `db:"start_date"`
+	EndDate   string `db:"end_date"`
+}
+
+// CohortUser is either an instructor or a member of a cohort.
+type CohortUser struct {
+	CohortID string `db:"cohort_id"`
+	UserID   string `db:"user_id"`
+	Fullname string `db:"fullname"`
+	Email    string `db:"email"`
+}
+
+// EnrollmentRequest keeps the action and progress choice of the learner so a
+// queued re-enrollment is carried out as requested.
+type EnrollmentRequest struct {
+	CourseID     string `db:"course_id"`
+	UserID       string `db:"user_id"`
+	Fullname     string `db:"fullname"`
+	Email        string `db:"email"`
+	CohortID     string `db:"cohort_id"`
+	Action       string `db:"action"`
+	KeepProgress bool   `db:"keep_progress"`
+	Status       string `db:"status"`
+	CreatedAt    string `db:"created_at"`
+}
+
+type EnrollmentStat struct {
+	Date        string `db:"date"`
+	Enrollments int    `db:"enrollments"`
+}
+
+type MaterialCompletion struct {
+	MaterialID   string  `db:"material_id"`
+	Completed    int     `db:"completed"`
+	AverageScore float64 `db:"average_score"`
+}
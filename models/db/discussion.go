@@ -0,0 +1,17 @@
+package db
+
+// Discussion threads are stored with an empty ParentID.
+type Discussion struct {
+	ID         string `db:"id"`
+	CourseID   string `db:"course_id"`
+	TargetType string `db:"target_type"`
+	TargetID   string `db:"target_id"`
+	ParentID   string `db:"parent_id"`
+	UserID     string `db:"user_id"`
+	Username   string `db:"username"`
+	Content    string `db:"content"`
+	Answered   bool   `db:"answered"`
+	ReplyCount int    `db:"reply_count"`
+	CreatedAt  string `db:"created_at"`
+	UpdatedAt  string `db:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package db
+
+type LearningPath struct {
+	ID          string `db:"id"`
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	Creator     string `db:"creator"`
+}
+
+type LearningPathCourse struct {
+	LearningPathID string `db:"learning_path_id"`
+	CourseID       string `db:"course_id"`
+	CourseName     string `db:"course_name"`
+	Thumbnail      string `db:"thumbnail"`
+	Position       int    `db:"position"`
+}
+
+type LearningPathCourseStatus struct {
+	CourseID   string `db:"course_id"`
+	CourseName string `db:"course_name"`
+	Position   int    `db:"position"`
+	Completed  bool   `db:"completed"`
+	Enrolled   bool   `db:"enrolled"`
+}
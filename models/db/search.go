@@ -0,0 +1,8 @@
+package db
+
+type SearchDocument struct {
+	ID       string `db:"id"`
+	ParentID string `db:"parent_id"`
+	Title    string `db:"title"`
+	Body     string `db:"body"`
+}
@@ -1,11 +1,14 @@
 package db
 
 type Syllabus struct {
-	ID        string  `db:"id"`
-	CourseID  string  `db:"course_id"`
-	Name      string  `db:"name"`
-	Type      string  `db:"type"`
-	SectionID *string `db:"section_id"`
+	ID               string  `db:"id"`
+	CourseID         string  `db:"course_id"`
+	Name             string  `db:"name"`
+	Type             string  `db:"type"`
+	SectionID        *string `db:"section_id"`
+	Position         int     `db:"position"`
+	ReleaseAt        *string `db:"release_at"`
+	ReleaseAfterDays *int    `db:"release_after_days"`
 }
 
 type Material struct {
@@ -16,4 +19,15 @@ type Material struct {
 	SectionID		string `db:"section_id"`
 	Content			string `db:"content"`
 	ContentText	string `db:"content_text"`
+	Metadata		*string `db:"metadata"`
+	ContentHTML	*string `db:"content_html"`
+	Position		int    `db:"position"`
+	ReleaseAt		*string `db:"release_at"`
+	ReleaseAfterDays	*int   `db:"release_after_days"`
+}
+
+type MaterialPosition struct {
+	ID        string `db:"id"`
+	SectionID string `db:"section_id"`
+	Position  int    `db:"position"`
 }
\ No newline at end of file
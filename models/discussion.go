@@ -0,0 +1,37 @@
+package models
+
+// A discussion thread is attached either to a course material or to an
+// assignment taken from a course syllabus. Replies share the target of their
+// thread and can not be replied to themselves.
+const (
+	DiscussionTargetMaterial   = "material"
+	DiscussionTargetAssignment = "assignment"
+)
+
+type Discussion struct {
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	ParentID   string `json:"parent_id"`
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	Content    string `json:"content"`
+	Answered   bool   `json:"answered"`
+	ReplyCount int    `json:"reply_count"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type DiscussionInput struct {
+	Content string `json:"content" validate:"required" label:"content"`
+}
+
+type DiscussionAnsweredInput struct {
+	Answered bool `json:"answered"`
+}
+
+type DiscussionResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ID      string `json:"id"`
+}
@@ -0,0 +1,51 @@
+package models
+
+const (
+	LearningPathCourseCompleted  = "completed"
+	LearningPathCourseOnProgress = "on_progress"
+	LearningPathCourseNotStarted = "not_started"
+)
+
+type LearningPath struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Creator     string                `json:"creator"`
+	Courses     []*LearningPathCourse `json:"courses"`
+}
+
+type LearningPathCourse struct {
+	ID         string `json:"id"`
+	CourseName string `json:"course_name"`
+	Thumbnail  string `json:"thumbnail"`
+	Position   int    `json:"position"`
+}
+
+type LearningPathInput struct {
+	Name        string   `json:"name" validate:"required" label:"name"`
+	Description string   `json:"description" validate:"required" label:"description"`
+	Courses     []string `json:"courses" validate:"required" label:"courses"`
+}
+
+type LearningPathResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ID      string `json:"id"`
+}
+
+type LearningPathProgress struct {
+	LearningPathID   string                        `json:"learning_path_id"`
+	Percentage       int                           `json:"percentage"`
+	CompletedCourses int                           `json:"completed_courses"`
+	TotalCourses     int                           `json:"total_courses"`
+	Courses          []*LearningPathCourseProgress `json:"courses"`
+}
+
+type LearningPathCourseProgress struct {
+	ID         string `json:"id"`
+	CourseName string `json:"course_name"`
+	Position   int    `json:"position"`
+	Status     string `json:"status"`
+	Percentage int    `json:"percentage"`
+	Locked     bool   `json:"locked"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import "encoding/json"
+
+// Material types accepted when creating or updating a material. Text holds
+// HTML and is kept for the materials written before the other types existed.
+const (
+	MaterialTypeText       = "text"
+	MaterialTypeMarkdown   = "markdown"
+	MaterialTypeVideo      = "video"
+	MaterialTypeAssignment = "assignment"
+	MaterialTypeFile       = "file"
+	MaterialTypeLink       = "link"
+)
+
+// MaterialMetadata holds the fields some material types need next to their
+// content. Duration is in seconds and FileSize in bytes.
+type MaterialMetadata struct {
+	Duration int    `json:"duration,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+	FileSize int64  `json:"fileSize,omitempty"`
+	Title    string `json:"title,omitempty"`
+}
+
+// EncodeMaterialMetadata returns the JSON stored in course_material.metadata,
+// nil when there is nothing to store.
+func EncodeMaterialMetadata(metadata *MaterialMetadata) *string {
+	if metadata == nil || *metadata == (MaterialMetadata{}) {
+		return nil
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil
+	}
+
+	encoded := string(data)
+	return &encoded
+}
+
+// DecodeMaterialMetadata never returns nil, materials without metadata get an
+// empty one.
+func DecodeMaterialMetadata(metadata *string) *MaterialMetadata {
+	decoded := new(MaterialMetadata)
+	if metadata == nil {
+		return decoded
+	}
+
+	err := json.Unmarshal([]byte(*metadata), decoded)
+	if err != nil {
+		return new(MaterialMetadata)
+	}
+
+	return decoded
+}
+
+// TextPayload carries the sanitized HTML of the material.
+type TextPayload struct {
+	HTML string `json:"html"`
+}
+
+type MarkdownPayload struct {
+	Markdown string `json:"markdown"`
+	HTML     string `json:"html"`
+}
+
+type VideoPayload struct {
+	URL      string `json:"url"`
+	Duration int    `json:"duration"`
+}
+
+type AssignmentPayload struct {
+	AssignmentID string `json:"assignmentID"`
+}
+
+type FilePayload struct {
+	URL      string `json:"url"`
+	FileName string `json:"fileName"`
+	FileSize int64  `json:"fileSize"`
+}
+
+type LinkPayload struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
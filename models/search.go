@@ -0,0 +1,32 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchResult struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	CourseID string  `json:"course_id,omitempty"`
+	Title    string  `json:"title"`
+	Snippet  string  `json:"snippet"`
+	Score    float64 `json:"score"`
+}
+
+type SearchFilter struct {
+	Query string   `json:"q"`
+	Types []string `json:"type"`
+}
+
+func (f *SearchFilter) FromContext(c echo.Context) *SearchFilter {
+	f.Query = strings.TrimSpace(c.QueryParam("q"))
+	f.Types = []string{}
+	for _, t := range strings.Split(c.QueryParam("type"), ",") {
+		if t = strings.TrimSpace(t); len(t) > 0 {
+			f.Types = append(f.Types, t)
+		}
+	}
+	return f
+}
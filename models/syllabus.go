@@ -5,15 +5,20 @@ type SyllabusResponse struct {
 }
 
 type Section struct {
-	ID          string      `json:"sectionID"`
-	Name        string      `json:"sectionName"`
-	Subsections []*Material `json:"subSections"`
+	ID               string      `json:"sectionID"`
+	Name             string      `json:"sectionName"`
+	Position         int         `json:"position"`
+	ReleaseAt        *string     `json:"releaseAt"`
+	ReleaseAfterDays *int        `json:"releaseAfterDays"`
+	Locked           bool        `json:"locked"`
+	Subsections      []*Material `json:"subSections"`
 }
 
 type Material struct {
-	ID   string `json:"materialID"`
-	Name string `json:"materialName"`
-	Type string `json:"materialType"`
+	ID       string `json:"materialID"`
+	Name     string `json:"materialName"`
+	Type     string `json:"materialType"`
+	Position int    `json:"position"`
 }
 
 type SectionContentResponse struct {
@@ -21,10 +26,25 @@ type SectionContentResponse struct {
 	Subsections []*MaterialContent	`json:"subSections"`
 }
 
+// MaterialContent carries the typed payload of the material next to its raw
+// content, Payload is nil for locked materials. ContentHTML is the sanitized
+// rendering of the markdown of the material.
 type MaterialContent struct {
 	ID 					string `json:"materialID"`
 	Name 				string `json:"materialName"`
 	Type 				string `json:"materialType"`
 	Content 		string `json:"materialContent"`
 	ContentText string `json:"materialContentText"`
-}
\ No newline at end of file
+	ContentHTML string `json:"materialContentHTML"`
+	Payload 		interface{} `json:"materialPayload"`
+	Locked 			bool   `json:"locked"`
+}
+
+type SyllabusOrderInput struct {
+	Sections []*SectionOrder `json:"sections" validate:"required" label:"sections"`
+}
+
+type SectionOrder struct {
+	ID        string   `json:"sectionID" validate:"required" label:"sectionID"`
+	Materials []string `json:"materials"`
+}
@@ -167,3 +167,29 @@ func (repo *assignmentRepository) GetAssignmentProblemsById(ctx context.Context,
 
 	return problems, nil
 }
+
+// GetAssignmentProblemIds returns the problems of an assignment. Problems are
+// linked through both assignment_problem and assignment_problems, so both
+// tables are read.
+func (repo *assignmentRepository) GetAssignmentProblemIds(ctx context.Context, db *sqlx.DB, id string) ([]string, error) {
+	var ids []string
+
+	query, args, err := sq.Select("problem_id").
+		From(repo.GetProblemTableName()).
+		Where(sq.Eq{"assignment_id": id}).
+		Suffix("UNION SELECT problem_id FROM assignment_problems WHERE assignment_id = ?", id).
+		ToSql()
+	if err != nil {
+		return ids, err
+	}
+
+	err = db.SelectContext(ctx, &ids, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ids, nil
+		}
+		return ids, err
+	}
+
+	return ids, nil
+}
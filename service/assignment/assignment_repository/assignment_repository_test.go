@@ -242,6 +242,62 @@ func TestAssignmentRepository_GetAssignmentProblemsById(t *testing.T) {
 
 }
 
+func TestAssignmentRepository_GetAssignmentProblemIds(t *testing.T) {
+	var (
+		assignmentId = uuid.New().String()
+		problemId    = uuid.New().String()
+		problemId2   = uuid.New().String()
+	)
+
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "[GetAssignmentProblemIds] Success to get problem ids of an assignment",
+			args: args{
+				context.TODO(),
+				assignmentId,
+			},
+			want:    []string{problemId, problemId2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			rows := sqlmock.NewRows([]string{"problem_id"})
+			for _, id := range tt.want {
+				rows.AddRow(id)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT problem_id FROM assignment_problem WHERE assignment_id = ? UNION SELECT problem_id FROM assignment_problems WHERE assignment_id = ?`)).
+				WithArgs(tt.args.id, tt.args.id).
+				WillReturnRows(rows)
+
+			r := assignment_repository.NewRepository()
+			got, err := r.GetAssignmentProblemIds(tt.args.ctx, sqlxDB, tt.args.id)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
 func TestAssignmentRepository_InsertAssignmentDesc(t *testing.T) {
 	var (
 		assignmentId = uuid.New().String()
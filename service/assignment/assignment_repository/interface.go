@@ -13,6 +13,7 @@ type AssignmentRepository interface {
 	GetAssignmentById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Assignment, error)
 	InsertAssignment(ctx context.Context, db *sqlx.DB, value *db_models.AssignmentCreation) error
 	GetAssignmentProblemsById(ctx context.Context, db *sqlx.DB, id string) ([]*db_models.ProblemTypeDetail, error)
+	GetAssignmentProblemIds(ctx context.Context, db *sqlx.DB, id string) ([]string, error)
 	InsertAssignmentDesc(ctx context.Context, db *sqlx.DB, value *db_models.Assignment) error
 	InsertAssignmentProblem(ctx context.Context, db *sqlx.DB, values *[]db_models.AssignmentProblem) error
 }
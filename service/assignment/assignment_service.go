@@ -12,12 +12,15 @@ import (
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
 )
 
 type assignmentService struct {
-	db         *sqlx.DB
-	repository assignment_repository.AssignmentRepository
+	db               *sqlx.DB
+	repository       assignment_repository.AssignmentRepository
+	courseService    course.CourseService
+	courseRepository course_repository.CourseRepository
 }
 
 func NewService(db *sqlx.DB) AssignmentService {
@@ -185,26 +188,40 @@ func (svc *assignmentService) CalculateScore(ctx context.Context, answers *model
 }
 
 func (svc *assignmentService) GetScore(ctx context.Context, userId string, answers *models.AssignmentSubmission) (*models.AssignmentScore, error) {
+	courseId, err := svc.courseService.CheckMaterialAccess(ctx, userId, answers.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	score, err := svc.CalculateScore(ctx, answers)
 	if err != nil {
 		return nil, err
 	}
 
-	materialId := answers.ID
-	courseRepo := course_repository.NewRepository()
+	err = svc.courseRepository.StoreUserProgress(ctx, svc.db, answers.ID, courseId, userId, score)
+	if err != nil {
+		return nil, err
+	}
 
-	courseId, err := courseRepo.GetCourseIDByMaterialID(ctx, svc.db, materialId)
+	err = svc.courseRepository.UpsertMaterialProgress(ctx, svc.db, &db_models.MaterialProgress{
+		UserID:     userId,
+		CourseID:   courseId,
+		MaterialID: answers.ID,
+		Percentage: 100,
+		Completed:  true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = courseRepo.StoreUserProgress(ctx, svc.db, answers.ID, courseId, userId, score)
+	completed, err := svc.courseService.CheckCourseCompletion(ctx, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &models.AssignmentScore{
-		Score: score,
+		Score:     score,
+		Completed: completed,
 	}
 
 	return resp, nil
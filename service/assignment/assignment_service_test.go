@@ -2,12 +2,15 @@ package assignment_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
@@ -204,3 +207,80 @@ func TestAssignmentService_CreateAssignment(t *testing.T) {
 	}
 
 }
+
+func TestAssignmentService_GetScore(t *testing.T) {
+	userId := uuid.New().String()
+	courseId := uuid.New().String()
+
+	submission := &models.AssignmentSubmission{
+		ID: id,
+		Answers: []models.ProblemAnswer{
+			{ID: problem1, Type: "pilgan", Answer: []interface{}{float64(1)}},
+			{ID: problem2, Type: "pilgan", Answer: []interface{}{float64(0)}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		accessErr error
+		want      *models.AssignmentScore
+		wantErr   error
+	}{
+		{
+			name: "[GetScore] Success to store the score",
+			want: &models.AssignmentScore{
+				Score:     50,
+				Completed: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "[GetScore] Material is locked",
+			accessErr: er.NewError(fmt.Errorf("%s", "The material is locked until the previous material is completed"), http.StatusForbidden, nil),
+			want:      nil,
+			wantErr:   er.NewError(fmt.Errorf("%s", "The material is locked until the previous material is completed"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			assignmentRepoMock := new(mocks.AssignmentRepository)
+			courseRepoMock := new(mocks.CourseRepository)
+			courseServiceMock := new(mocks.CourseService)
+			svc := assignment.NewService(sqlxDB)
+			svc.InjectAssignmentRepository(assignmentRepoMock)
+			svc.InjectCourseRepository(courseRepoMock)
+			svc.InjectCourseService(courseServiceMock)
+
+			courseServiceMock.On("CheckMaterialAccess", mock.Anything, userId, id).Return(courseId, tt.accessErr)
+			courseServiceMock.On("CheckCourseCompletion", mock.Anything, userId, courseId).Return(true, nil)
+			assignmentRepoMock.On("GetAssignmentProblemsById", mock.Anything, mock.Anything, id).Return([]*db_models.ProblemTypeDetail{
+				{ID: problem1, Type: "pilgan", Detail: `{"choice": ["a", "b"], "answer": [1]}`},
+				{ID: problem2, Type: "pilgan", Detail: `{"choice": ["a", "b"], "answer": [1]}`},
+			}, nil)
+			courseRepoMock.On("StoreUserProgress", mock.Anything, mock.Anything, id, courseId, userId, 50).Return(nil)
+			courseRepoMock.On("UpsertMaterialProgress", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			got, err := svc.GetScore(context.TODO(), userId, submission)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+
+			if tt.wantErr != nil {
+				courseRepoMock.AssertNotCalled(t, "StoreUserProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			courseRepoMock.AssertCalled(t, "StoreUserProgress", mock.Anything, mock.Anything, id, courseId, userId, 50)
+			courseRepoMock.AssertCalled(t, "UpsertMaterialProgress", mock.Anything, mock.Anything, &db_models.MaterialProgress{
+				UserID:     userId,
+				CourseID:   courseId,
+				MaterialID: id,
+				Percentage: 100,
+				Completed:  true,
+			})
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
 )
 
 func (svc *assignmentService) InjectAssignmentRepository(repo assignment_repository.AssignmentRepository) error {
@@ -13,3 +15,19 @@ func (svc *assignmentService) InjectAssignmentRepository(repo assignment_reposit
 	}
 	return errors.New("assignment repository not found")
 }
+
+func (svc *assignmentService) InjectCourseService(courseService course.CourseService) error {
+	if courseService != nil {
+		svc.courseService = courseService
+		return nil
+	}
+	return errors.New("course service not found")
+}
+
+func (svc *assignmentService) InjectCourseRepository(repo course_repository.CourseRepository) error {
+	if repo != nil {
+		svc.courseRepository = repo
+		return nil
+	}
+	return errors.New("course repository not found")
+}
@@ -5,10 +5,14 @@ import (
 
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
 )
 
 type AssignmentService interface {
 	InjectAssignmentRepository(assignment_repository.AssignmentRepository) error
+	InjectCourseService(course.CourseService) error
+	InjectCourseRepository(course_repository.CourseRepository) error
 	GetAssignment(ctx context.Context, id string) (*models.AssignmentResponse, error)
 	CreateAssignment(ctx context.Context, input *models.AssignmentCreation) (*models.AssignmentCreationResponse, error)
 	CalculateScore(ctx context.Context, answers *models.AssignmentSubmission) (int, error)
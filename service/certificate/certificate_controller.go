@@ -0,0 +1,58 @@
+package certificate
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CertificateController struct {
+	certificateService CertificateService
+}
+
+func NewController(svc CertificateService) *CertificateController {
+	return &CertificateController{
+		certificateService: svc,
+	}
+}
+
+func (ctl *CertificateController) HandleIssueCertificate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	url := "http://" + c.Request().Host + "/"
+
+	resp, err := ctl.certificateService.IssueCertificate(ctx, userId, courseId, url)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CertificateController) HandleGetUserCertificates(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	url := "http://" + c.Request().Host + "/"
+
+	resp, err := ctl.certificateService.GetUserCertificates(ctx, userId, url)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CertificateController) HandleVerifyCertificate(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+	signature := c.QueryParam("signature")
+	url := "http://" + c.Request().Host + "/"
+
+	resp, err := ctl.certificateService.VerifyCertificate(ctx, id, signature, url)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,63 @@
+package certificate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+var certificateTemplate = template.Must(template.New("certificate").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="1123" height="794" viewBox="0 0 1123 794">
+  <rect x="0" y="0" width="1123" height="794" fill="#ffffff"/>
+  <rect x="24" y="24" width="1075" height="746" fill="none" stroke="#1f3c88" stroke-width="6"/>
+  <rect x="40" y="40" width="1043" height="714" fill="none" stroke="#1f3c88" stroke-width="1"/>
+  <text x="561" y="170" font-family="Georgia, serif" font-size="54" fill="#1f3c88" text-anchor="middle">Certificate of Completion</text>
+  <text x="561" y="250" font-family="Helvetica, Arial, sans-serif" font-size="22" fill="#555555" text-anchor="middle">This certifies that</text>
+  <text x="561" y="330" font-family="Georgia, serif" font-size="44" fill="#222222" text-anchor="middle">{{.LearnerName}}</text>
+  <text x="561" y="400" font-family="Helvetica, Arial, sans-serif" font-size="22" fill="#555555" text-anchor="middle">has successfully completed the course</text>
+  <text x="561" y="470" font-family="Georgia, serif" font-size="36" fill="#222222" text-anchor="middle">{{.CourseName}}</text>
+  <text x="561" y="540" font-family="Helvetica, Arial, sans-serif" font-size="22" fill="#555555" text-anchor="middle">on {{.Date}} with a final score of {{.FinalScore}}</text>
+  <text x="80" y="700" font-family="Helvetica, Arial, sans-serif" font-size="14" fill="#777777">Certificate ID: {{.ID}}</text>
+  <text x="80" y="724" font-family="Helvetica, Arial, sans-serif" font-size="14" fill="#777777">Verify at: {{.VerifyURL}}</text>
+  <text x="1043" y="724" font-family="Helvetica, Arial, sans-serif" font-size="14" fill="#1f3c88" text-anchor="end">FreeOCP</text>
+</svg>
+`))
+
+// SignCertificate derives the certificate signature from every field printed
+// on it, so editing any of them invalidates the certificate.
+func SignCertificate(secret []byte, cert *db_models.Certificate) string {
+	payload := strings.Join([]string{
+		cert.ID,
+		cert.UserID,
+		cert.CourseID,
+		cert.LearnerName,
+		cert.CourseName,
+		cert.FinishDate,
+		fmt.Sprintf("%d", cert.FinalScore),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RenderCertificate writes the certificate as an SVG document.
+func RenderCertificate(w io.Writer, cert *models.Certificate) error {
+	date := cert.FinishDate
+	if finish, err := time.Parse("2006-01-02 15:04:05", cert.FinishDate); err == nil {
+		date = finish.Format("2 January 2006")
+	}
+
+	return certificateTemplate.Execute(w, struct {
+		*models.Certificate
+		Date string
+	}{cert, date})
+}
@@ -0,0 +1,166 @@
+package certificate_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+type certificateRepository struct{}
+
+func NewRepository() CertificateRepository {
+	return &certificateRepository{}
+}
+
+func (repo *certificateRepository) GetTableName() string {
+	return "certificate"
+}
+
+func (repo *certificateRepository) querySelectCertificate() sq.SelectBuilder {
+	builder := sq.Select(
+		"id",
+		"user_id",
+		"course_id",
+		"learner_name",
+		"course_name",
+		"finish_date",
+		"final_score",
+		"signature",
+	).From(repo.GetTableName())
+
+	return builder
+}
+
+// GetCourseCompletion returns the solved_course record of the user together
+// with the learner and course names. It returns nil when the user has not
+// completed the course.
+func (repo *certificateRepository) GetCourseCompletion(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*db_models.CourseCompletion, error) {
+	out := new(db_models.CourseCompletion)
+
+	query, args, err := sq.Select(
+		"solved_course.user_id",
+		"solved_course.course_id",
+		"COALESCE(User.fullname, User.username) AS learner_name",
+		"Course.course_name",
+		"solved_course.finish_date",
+		"COALESCE(solved_course.final_score, 0) AS final_score",
+	).From("solved_course").
+		Join("User ON User.id = solved_course.user_id").
+		Join("Course ON Course.id = solved_course.course_id").
+		Where(sq.Eq{"solved_course.user_id": userId}).
+		Where(sq.Eq{"solved_course.course_id": courseId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *certificateRepository) GetCertificateById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Certificate, error) {
+	out := new(db_models.Certificate)
+
+	query, args, err := repo.querySelectCertificate().Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Certificate not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetCertificateByUserAndCourse returns nil when no certificate has been
+// issued yet.
+func (repo *certificateRepository) GetCertificateByUserAndCourse(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*db_models.Certificate, error) {
+	out := new(db_models.Certificate)
+
+	query, args, err := repo.querySelectCertificate().
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *certificateRepository) GetCertificatesByUserID(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.Certificate, error) {
+	certificates := []*db_models.Certificate{}
+
+	query, args, err := repo.querySelectCertificate().
+		Where(sq.Eq{"user_id": userId}).
+		OrderBy("issued_at DESC").ToSql()
+	if err != nil {
+		return certificates, err
+	}
+
+	err = db.SelectContext(ctx, &certificates, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return certificates, nil
+		}
+		return certificates, err
+	}
+
+	return certificates, nil
+}
+
+func (repo *certificateRepository) InsertCertificate(ctx context.Context, db *sqlx.DB, value *db_models.Certificate) error {
+	query, args, err := sq.Insert(repo.GetTableName()).Columns(
+		"id",
+		"user_id",
+		"course_id",
+		"learner_name",
+		"course_name",
+		"finish_date",
+		"final_score",
+		"signature",
+	).Values(
+		value.ID,
+		value.UserID,
+		value.CourseID,
+		value.LearnerName,
+		value.CourseName,
+		value.FinishDate,
+		value.FinalScore,
+		value.Signature,
+	).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
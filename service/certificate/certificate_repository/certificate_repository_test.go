@@ -0,0 +1,144 @@
+package certificate_repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate/certificate_repository"
+)
+
+var (
+	userId   = uuid.New().String()
+	courseId = uuid.New().String()
+)
+
+func TestCertificateRepository_GetCourseCompletion(t *testing.T) {
+
+	type args struct {
+		ctx      context.Context
+		userId   string
+		courseId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *db_models.CourseCompletion
+		wantErr error
+	}{
+		{
+			name: "[GetCourseCompletion] Success to get course completion.",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			want: &db_models.CourseCompletion{
+				UserID:      userId,
+				CourseID:    courseId,
+				LearnerName: "Jane Doe",
+				CourseName:  "Golang Basics",
+				FinishDate:  "2022-05-01 10:00:00",
+				FinalScore:  90,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCourseCompletion] Course is not completed.",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			want:    nil,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			selectQuery := regexp.QuoteMeta(`SELECT solved_course.user_id, solved_course.course_id, COALESCE(User.fullname, User.username) AS learner_name, Course.course_name, solved_course.finish_date, COALESCE(solved_course.final_score, 0) AS final_score FROM solved_course JOIN User ON User.id = solved_course.user_id JOIN Course ON Course.id = solved_course.course_id WHERE solved_course.user_id = ? AND solved_course.course_id = ?`)
+
+			if tt.want != nil {
+				rows := sqlmock.NewRows([]string{"user_id", "course_id", "learner_name", "course_name", "finish_date", "final_score"}).
+					AddRow(tt.want.UserID, tt.want.CourseID, tt.want.LearnerName, tt.want.CourseName, tt.want.FinishDate, tt.want.FinalScore)
+				mock.ExpectQuery(selectQuery).WithArgs(tt.args.userId, tt.args.courseId).WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery(selectQuery).WithArgs(tt.args.userId, tt.args.courseId).WillReturnError(sql.ErrNoRows)
+			}
+
+			r := certificate_repository.NewRepository()
+			got, err := r.GetCourseCompletion(tt.args.ctx, sqlxDB, tt.args.userId, tt.args.courseId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCertificateRepository_InsertCertificate(t *testing.T) {
+
+	type args struct {
+		ctx   context.Context
+		value *db_models.Certificate
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[InsertCertificate] Success to insert certificate.",
+			args: args{
+				context.TODO(),
+				&db_models.Certificate{
+					ID:          uuid.New().String(),
+					UserID:      userId,
+					CourseID:    courseId,
+					LearnerName: "Jane Doe",
+					CourseName:  "Golang Basics",
+					FinishDate:  "2022-05-01 10:00:00",
+					FinalScore:  90,
+					Signature:   "signature",
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			v := tt.args.value
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO certificate (id,user_id,course_id,learner_name,course_name,finish_date,final_score,signature) VALUES (?,?,?,?,?,?,?,?)`)).
+				WithArgs(v.ID, v.UserID, v.CourseID, v.LearnerName, v.CourseName, v.FinishDate, v.FinalScore, v.Signature).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			r := certificate_repository.NewRepository()
+			err = r.InsertCertificate(tt.args.ctx, sqlxDB, tt.args.value)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package certificate_repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+type CertificateRepository interface {
+	GetTableName() string
+	GetCourseCompletion(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*db_models.CourseCompletion, error)
+	GetCertificateById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Certificate, error)
+	GetCertificateByUserAndCourse(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*db_models.Certificate, error)
+	GetCertificatesByUserID(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.Certificate, error)
+	InsertCertificate(ctx context.Context, db *sqlx.DB, value *db_models.Certificate) error
+}
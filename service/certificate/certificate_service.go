@@ -0,0 +1,163 @@
+package certificate
+
+import (
+	"context"
+	"crypto/hmac"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/config"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate/certificate_repository"
+)
+
+// CertificateDir is where the generated certificates are written, it is
+// served under /static.
+const CertificateDir = "static/certificate/"
+
+type certificateService struct {
+	db         *sqlx.DB
+	repository certificate_repository.CertificateRepository
+}
+
+func NewService(db *sqlx.DB) CertificateService {
+	return &certificateService{
+		db: db,
+	}
+}
+
+func signingSecret() []byte {
+	conf := config.GetConfig()
+	if len(conf.CertificateSecret) > 0 {
+		return []byte(conf.CertificateSecret)
+	}
+
+	return []byte(conf.JWTSecret)
+}
+
+func toCertificate(cert *db_models.Certificate, baseURL string) *models.Certificate {
+	return &models.Certificate{
+		ID:          cert.ID,
+		CourseID:    cert.CourseID,
+		LearnerName: cert.LearnerName,
+		CourseName:  cert.CourseName,
+		FinishDate:  cert.FinishDate,
+		FinalScore:  cert.FinalScore,
+		Signature:   cert.Signature,
+		URL:         baseURL + CertificateDir + cert.ID + ".svg",
+		VerifyURL:   fmt.Sprintf("%sv1/certificate/verify/%s?signature=%s", baseURL, cert.ID, cert.Signature),
+	}
+}
+
+// writeCertificateFile renders the certificate into CertificateDir unless it
+// has already been generated.
+func writeCertificateFile(cert *models.Certificate) error {
+	path := filepath.Join(CertificateDir, cert.ID+".svg")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	err := os.MkdirAll(CertificateDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return RenderCertificate(file, cert)
+}
+
+// IssueCertificate creates the certificate of a completed course. Issuing it
+// again returns the certificate created the first time.
+func (svc *certificateService) IssueCertificate(ctx context.Context, userId string, courseId string, baseURL string) (*models.Certificate, error) {
+	cert, err := svc.repository.GetCertificateByUserAndCourse(ctx, svc.db, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert == nil {
+		completion, err := svc.repository.GetCourseCompletion(ctx, svc.db, userId, courseId)
+		if err != nil {
+			return nil, err
+		}
+
+		if completion == nil {
+			return nil, er.NewError(fmt.Errorf("%s", "You have not completed the course"), http.StatusBadRequest, nil)
+		}
+
+		cert = &db_models.Certificate{
+			ID:          uuid.New().String(),
+			UserID:      completion.UserID,
+			CourseID:    completion.CourseID,
+			LearnerName: completion.LearnerName,
+			CourseName:  completion.CourseName,
+			FinishDate:  completion.FinishDate,
+			FinalScore:  completion.FinalScore,
+		}
+		cert.Signature = SignCertificate(signingSecret(), cert)
+
+		err = svc.repository.InsertCertificate(ctx, svc.db, cert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := toCertificate(cert, baseURL)
+
+	err = writeCertificateFile(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (svc *certificateService) GetUserCertificates(ctx context.Context, userId string, baseURL string) ([]*models.Certificate, error) {
+	db_certificates, err := svc.repository.GetCertificatesByUserID(ctx, svc.db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := []*models.Certificate{}
+	for _, cert := range db_certificates {
+		certificates = append(certificates, toCertificate(cert, baseURL))
+	}
+
+	return certificates, nil
+}
+
+// VerifyCertificate checks that the stored certificate still matches its
+// signature and, when given, the signature printed on the certificate.
+func (svc *certificateService) VerifyCertificate(ctx context.Context, id string, signature string, baseURL string) (*models.CertificateVerification, error) {
+	cert, err := svc.repository.GetCertificateById(ctx, svc.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := []byte(SignCertificate(signingSecret(), cert))
+
+	valid := hmac.Equal(expected, []byte(cert.Signature))
+	if len(signature) > 0 {
+		valid = valid && hmac.Equal(expected, []byte(signature))
+	}
+
+	resp := &models.CertificateVerification{
+		Valid: valid,
+	}
+
+	if valid {
+		resp.Certificate = toCertificate(cert, baseURL)
+	}
+
+	return resp, nil
+}
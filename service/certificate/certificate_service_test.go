@@ -0,0 +1,174 @@
+package certificate_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/config"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate"
+)
+
+var (
+	certificateId = uuid.New().String()
+	userId        = uuid.New().String()
+	courseId      = uuid.New().String()
+	baseURL       = "http://localhost/"
+)
+
+func signedCertificate() *db_models.Certificate {
+	cert := &db_models.Certificate{
+		ID:          certificateId,
+		UserID:      userId,
+		CourseID:    courseId,
+		LearnerName: "Jane Doe",
+		CourseName:  "Golang Basics",
+		FinishDate:  "2022-05-01 10:00:00",
+		FinalScore:  90,
+	}
+
+	conf := config.GetConfig()
+	secret := conf.CertificateSecret
+	if len(secret) == 0 {
+		secret = conf.JWTSecret
+	}
+	cert.Signature = certificate.SignCertificate([]byte(secret), cert)
+
+	return cert
+}
+
+func TestCertificateService_IssueCertificate(t *testing.T) {
+	type mockRepo struct {
+		cert *db_models.Certificate
+	}
+
+	tests := []struct {
+		name    string
+		mock    mockRepo
+		want    *models.Certificate
+		wantErr error
+	}{
+		{
+			name: "[IssueCertificate] Course is not completed",
+			mock: mockRepo{
+				cert: nil,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have not completed the course"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CertificateRepository)
+			svc := certificate.NewService(sqlxDB)
+			svc.InjectCertificateRepository(repoMock)
+
+			repoMock.
+				On("GetCertificateByUserAndCourse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.cert, nil)
+
+			repoMock.
+				On("GetCourseCompletion", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+
+			got, err := svc.IssueCertificate(context.TODO(), userId, courseId, baseURL)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCertificateService_VerifyCertificate(t *testing.T) {
+	valid := signedCertificate()
+
+	tampered := signedCertificate()
+	tampered.FinalScore = 100
+
+	tests := []struct {
+		name      string
+		cert      *db_models.Certificate
+		signature string
+		wantValid bool
+	}{
+		{
+			name:      "[VerifyCertificate] Valid certificate",
+			cert:      valid,
+			signature: valid.Signature,
+			wantValid: true,
+		},
+		{
+			name:      "[VerifyCertificate] Valid certificate without signature",
+			cert:      valid,
+			signature: "",
+			wantValid: true,
+		},
+		{
+			name:      "[VerifyCertificate] Wrong signature",
+			cert:      valid,
+			signature: "0000",
+			wantValid: false,
+		},
+		{
+			name:      "[VerifyCertificate] Tampered certificate",
+			cert:      tampered,
+			signature: tampered.Signature,
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CertificateRepository)
+			svc := certificate.NewService(sqlxDB)
+			svc.InjectCertificateRepository(repoMock)
+
+			repoMock.
+				On("GetCertificateById", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.cert, nil)
+
+			got, err := svc.VerifyCertificate(context.TODO(), certificateId, tt.signature, baseURL)
+
+			assert.Nil(t, err, tt.name)
+			assert.Equal(t, tt.wantValid, got.Valid, tt.name)
+			if tt.wantValid {
+				assert.Equal(t, tt.cert.CourseName, got.Certificate.CourseName, tt.name)
+			} else {
+				assert.Nil(t, got.Certificate, tt.name)
+			}
+		})
+	}
+}
+
+func TestRenderCertificate(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := certificate.RenderCertificate(&buf, &models.Certificate{
+		ID:          certificateId,
+		LearnerName: "Jane <Doe>",
+		CourseName:  "Golang Basics",
+		FinishDate:  "2022-05-01 10:00:00",
+		FinalScore:  90,
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(buf.String(), "Jane &lt;Doe&gt;"))
+	assert.True(t, strings.Contains(buf.String(), "1 May 2022"))
+	assert.True(t, strings.Contains(buf.String(), certificateId))
+}
@@ -0,0 +1,15 @@
+package certificate
+
+import (
+	"errors"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate/certificate_repository"
+)
+
+func (svc *certificateService) InjectCertificateRepository(repo certificate_repository.CertificateRepository) error {
+	if repo != nil {
+		svc.repository = repo
+		return nil
+	}
+	return errors.New("certificate repository not found")
+}
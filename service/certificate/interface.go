@@ -0,0 +1,15 @@
+package certificate
+
+import (
+	"context"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/certificate/certificate_repository"
+)
+
+type CertificateService interface {
+	InjectCertificateRepository(certificate_repository.CertificateRepository) error
+	IssueCertificate(ctx context.Context, userId string, courseId string, baseURL string) (*models.Certificate, error)
+	GetUserCertificates(ctx context.Context, userId string, baseURL string) ([]*models.Certificate, error)
+	VerifyCertificate(ctx context.Context, id string, signature string, baseURL string) (*models.CertificateVerification, error)
+}
@@ -0,0 +1,219 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+// authorizeAnalytics only lets admins and the owners of the course read its
+// analytics.
+func (serv *courseService) authorizeAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) error {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin {
+		return nil
+	}
+
+	allowed, err := serv.hasCourseRole(ctx, course, userId, courseOwnerRoles)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return er.NewError(fmt.Errorf("%s", "You are not allowed to view the analytics of this course"), http.StatusForbidden, nil)
+	}
+
+	return nil
+}
+
+func (serv *courseService) getEnrollmentStats(ctx context.Context, courseId string) ([]*models.EnrollmentStat, error) {
+	stats := []*models.EnrollmentStat{}
+
+	db_stats, err := serv.courseRepository.GetEnrollmentStats(ctx, serv.db, courseId)
+	if err != nil {
+		return stats, err
+	}
+
+	total := 0
+	for _, stat := range db_stats {
+		total += stat.Enrollments
+		stats = append(stats, &models.EnrollmentStat{
+			Date:        stat.Date,
+			Enrollments: stat.Enrollments,
+			Total:       total,
+		})
+	}
+
+	return stats, nil
+}
+
+func (serv *courseService) GetEnrollmentAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.EnrollmentStat, error) {
+	err := serv.authorizeAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return serv.getEnrollmentStats(ctx, courseId)
+}
+
+func (serv *courseService) getMaterialCompletions(ctx context.Context, courseId string) (map[string]*db.MaterialCompletion, error) {
+	db_completions, err := serv.courseRepository.GetMaterialCompletions(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	completions := make(map[string]*db.MaterialCompletion)
+	for _, completion := range db_completions {
+		completions[completion.MaterialID] = completion
+	}
+
+	return completions, nil
+}
+
+// GetFunnelAnalytics walks the syllabus in order and counts how many of the
+// learners who started the course completed each material.
+func (serv *courseService) GetFunnelAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CourseFunnel, error) {
+	err := serv.authorizeAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := serv.getEnrollmentStats(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	completions, err := serv.getMaterialCompletions(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	syllabus, err := serv.getCourseSyllabus(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel := &models.CourseFunnel{
+		Materials: []*models.MaterialFunnel{},
+	}
+
+	if len(stats) > 0 {
+		funnel.Learners = stats[len(stats)-1].Total
+	}
+
+	previous := funnel.Learners
+	biggestDropOff := 0
+	for _, section := range syllabus.Syllabus {
+		for _, material := range section.Subsections {
+			step := &models.MaterialFunnel{
+				MaterialID:   material.ID,
+				MaterialName: material.Name,
+				MaterialType: material.Type,
+				SectionName:  section.Name,
+			}
+
+			if completion, ok := completions[material.ID]; ok {
+				step.Completed = completion.Completed
+			}
+
+			if funnel.Learners != 0 {
+				step.Percentage = int(math.Round(float64(step.Completed) / float64(funnel.Learners) * 100))
+			}
+
+			if previous > step.Completed {
+				step.DropOff = previous - step.Completed
+			}
+
+			if step.DropOff > biggestDropOff {
+				biggestDropOff = step.DropOff
+				funnel.DropOffPoint = step.MaterialID
+			}
+
+			previous = step.Completed
+			funnel.Materials = append(funnel.Materials, step)
+		}
+	}
+
+	return funnel, nil
+}
+
+func (serv *courseService) GetScoreAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.AssignmentScoreStat, error) {
+	err := serv.authorizeAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	completions, err := serv.getMaterialCompletions(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	syllabus, err := serv.getCourseSyllabus(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := []*models.AssignmentScoreStat{}
+	for _, section := range syllabus.Syllabus {
+		for _, material := range section.Subsections {
+			if material.Type != "assignment" {
+				continue
+			}
+
+			score := &models.AssignmentScoreStat{
+				MaterialID:   material.ID,
+				MaterialName: material.Name,
+			}
+
+			if completion, ok := completions[material.ID]; ok {
+				score.Submissions = completion.Completed
+				score.AverageScore = math.Round(completion.AverageScore*100) / 100
+			}
+
+			scores = append(scores, score)
+		}
+	}
+
+	return scores, nil
+}
+
+// GetCompletionAnalytics reports the median number of days the learners
+// took to complete the course.
+func (serv *courseService) GetCompletionAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CompletionTime, error) {
+	err := serv.authorizeAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := serv.courseRepository.GetCompletionDurations(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.CompletionTime{
+		Completed: len(durations),
+	}
+
+	if len(durations) == 0 {
+		return out, nil
+	}
+
+	middle := len(durations) / 2
+	median := float64(durations[middle])
+	if len(durations)%2 == 0 {
+		median = float64(durations[middle-1]+durations[middle]) / 2
+	}
+
+	out.MedianDays = math.Round(median/(24*60*60)*10) / 10
+
+	return out, nil
+}
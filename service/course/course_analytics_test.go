@@ -0,0 +1,191 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_GetFunnelAnalytics(t *testing.T) {
+	quizId := uuid.New().String()
+
+	syllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Name: "Introduction", Type: "section"},
+		{ID: materialId, CourseID: courseId, Name: "Welcome", Type: "video", SectionID: &syllabusId},
+		{ID: quizId, CourseID: courseId, Name: "Quiz 1", Type: "assignment", SectionID: &syllabusId, Position: 1},
+	}
+
+	tests := []struct {
+		name    string
+		userId  string
+		isAdmin bool
+		want    *models.CourseFunnel
+		wantErr error
+	}{
+		{
+			name:   "[GetFunnelAnalytics] Creator reads the funnel",
+			userId: creatorId,
+			want: &models.CourseFunnel{
+				Learners:     4,
+				DropOffPoint: quizId,
+				Materials: []*models.MaterialFunnel{
+					{MaterialID: materialId, MaterialName: "Welcome", MaterialType: "video", SectionName: "Introduction", Completed: 3, Percentage: 75, DropOff: 1},
+					{MaterialID: quizId, MaterialName: "Quiz 1", MaterialType: "assignment", SectionName: "Introduction", Completed: 1, Percentage: 25, DropOff: 2},
+				},
+			},
+		},
+		{
+			name:    "[GetFunnelAnalytics] Admin reads the funnel",
+			userId:  userId,
+			isAdmin: true,
+			want: &models.CourseFunnel{
+				Learners:     4,
+				DropOffPoint: quizId,
+				Materials: []*models.MaterialFunnel{
+					{MaterialID: materialId, MaterialName: "Welcome", MaterialType: "video", SectionName: "Introduction", Completed: 3, Percentage: 75, DropOff: 1},
+					{MaterialID: quizId, MaterialName: "Quiz 1", MaterialType: "assignment", SectionName: "Introduction", Completed: 1, Percentage: 25, DropOff: 2},
+				},
+			},
+		},
+		{
+			name:    "[GetFunnelAnalytics] Learners are not allowed to read analytics",
+			userId:  userId,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to view the analytics of this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+				Return("", nil)
+
+			repoMock.
+				On("GetEnrollmentStats", mock.Anything, mock.Anything, courseId).
+				Return([]*db_models.EnrollmentStat{
+					{Date: "2022-05-01", Enrollments: 3},
+					{Date: "2022-05-03", Enrollments: 1},
+				}, nil)
+
+			repoMock.
+				On("GetMaterialCompletions", mock.Anything, mock.Anything, courseId).
+				Return([]*db_models.MaterialCompletion{
+					{MaterialID: materialId, Completed: 3, AverageScore: 100},
+					{MaterialID: quizId, Completed: 1, AverageScore: 80},
+				}, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, courseId).
+				Return(syllabus, nil)
+
+			got, err := svc.GetFunnelAnalytics(context.TODO(), courseId, tt.userId, tt.isAdmin)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetScoreAnalytics(t *testing.T) {
+	quizId := uuid.New().String()
+	quizId2 := uuid.New().String()
+
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+	repoMock.
+		On("GetMaterialCompletions", mock.Anything, mock.Anything, courseId).
+		Return([]*db_models.MaterialCompletion{
+			{MaterialID: materialId, Completed: 3, AverageScore: 100},
+			{MaterialID: quizId, Completed: 3, AverageScore: 73.3333},
+		}, nil)
+
+	repoMock.
+		On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, courseId).
+		Return([]*db_models.Syllabus{
+			{ID: materialId, CourseID: courseId, Name: "Welcome", Type: "video"},
+			{ID: quizId, CourseID: courseId, Name: "Quiz 1", Type: "assignment", Position: 1},
+			{ID: quizId2, CourseID: courseId, Name: "Quiz 2", Type: "assignment", Position: 2},
+		}, nil)
+
+	got, err := svc.GetScoreAnalytics(context.TODO(), courseId, creatorId, false)
+	assert.Nil(t, err, "[GetScoreAnalytics] Average score of every assignment")
+	assert.Equal(t, []*models.AssignmentScoreStat{
+		{MaterialID: quizId, MaterialName: "Quiz 1", Submissions: 3, AverageScore: 73.33},
+		{MaterialID: quizId2, MaterialName: "Quiz 2"},
+	}, got, "[GetScoreAnalytics] Average score of every assignment")
+}
+
+func TestCourseService_GetCompletionAnalytics(t *testing.T) {
+	const day = 24 * 60 * 60
+
+	tests := []struct {
+		name      string
+		durations []int64
+		want      *models.CompletionTime
+	}{
+		{
+			name: "[GetCompletionAnalytics] Nobody completed the course",
+			want: &models.CompletionTime{},
+		},
+		{
+			name:      "[GetCompletionAnalytics] Median of an odd number of learners",
+			durations: []int64{day, 3 * day, 10 * day},
+			want:      &models.CompletionTime{Completed: 3, MedianDays: 3},
+		},
+		{
+			name:      "[GetCompletionAnalytics] Median of an even number of learners",
+			durations: []int64{day, 2 * day, 4 * day, 10 * day},
+			want:      &models.CompletionTime{Completed: 4, MedianDays: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCompletionDurations", mock.Anything, mock.Anything, courseId).
+				Return(tt.durations, nil)
+
+			got, err := svc.GetCompletionAnalytics(context.TODO(), courseId, creatorId, false)
+			assert.Nil(t, err, tt.name)
+			assert.Equal(t, tt.want, got, tt.name)
+		})
+	}
+}
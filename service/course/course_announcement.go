@@ -0,0 +1,201 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+// checkAnnouncementAccess lets admins, the staff of the course and learners
+// who are enrolled to or have completed the course read its announcements.
+func (serv *courseService) checkAnnouncementAccess(ctx context.Context, course *db.Course, userId string, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+
+	staff, err := serv.hasCourseRole(ctx, course, userId, courseStaffRoles)
+	if err != nil {
+		return err
+	}
+
+	if staff {
+		return nil
+	}
+
+	access, err := serv.hasCourseAccess(ctx, userId, course.ID)
+	if err != nil {
+		return err
+	}
+
+	if !access {
+		return er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	}
+
+	return nil
+}
+
+func (serv *courseService) GetCourseAnnouncements(ctx context.Context, meta *pagination.Meta, courseId string, userId string, isAdmin bool) ([]*models.CourseAnnouncement, uint64, error) {
+	announcements := []*models.CourseAnnouncement{}
+
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return announcements, 0, err
+	}
+
+	err = serv.checkAnnouncementAccess(ctx, course, userId, isAdmin)
+	if err != nil {
+		return announcements, 0, err
+	}
+
+	db_announcements, count, err := serv.courseRepository.GetCourseAnnouncements(ctx, serv.db, meta, courseId, userId)
+	if err != nil {
+		return announcements, count, err
+	}
+
+	for _, announcement := range db_announcements {
+		announcements = append(announcements, &models.CourseAnnouncement{
+			ID:        announcement.ID,
+			CourseID:  announcement.CourseID,
+			AuthorID:  announcement.AuthorID,
+			Author:    announcement.Author,
+			Title:     announcement.Title,
+			Content:   announcement.Content,
+			Read:      announcement.Read,
+			CreatedAt: announcement.CreatedAt,
+			UpdatedAt: announcement.UpdatedAt,
+		})
+	}
+
+	return announcements, count, nil
+}
+
+func (serv *courseService) CreateCourseAnnouncement(ctx context.Context, courseId string, input *models.CourseAnnouncementInput, userId string) (*models.CourseCreationResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	err = serv.courseRepository.InsertCourseAnnouncement(ctx, serv.db, &db.CourseAnnouncement{
+		ID:       id,
+		CourseID: courseId,
+		AuthorID: userId,
+		Title:    input.Title,
+		Content:  input.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Announcement Created Succesfully",
+		Id:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCourseAnnouncement(ctx context.Context, announcementId string, input *models.CourseAnnouncementInput, userId string) (*models.CourseUpdateResponse, error) {
+	announcement, err := serv.courseRepository.GetCourseAnnouncement(ctx, serv.db, announcementId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.authorizeCourse(ctx, announcement.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	announcement.Title = input.Title
+	announcement.Content = input.Content
+	err = serv.courseRepository.UpdateCourseAnnouncement(ctx, serv.db, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Announcement Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteCourseAnnouncement(ctx context.Context, announcementId string, userId string) (*models.CourseUpdateResponse, error) {
+	announcement, err := serv.courseRepository.GetCourseAnnouncement(ctx, serv.db, announcementId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.authorizeCourse(ctx, announcement.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteCourseAnnouncement(ctx, serv.db, announcementId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Announcement Deleted Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) MarkAnnouncementRead(ctx context.Context, announcementId string, userId string, isAdmin bool) (*models.CourseUpdateResponse, error) {
+	announcement, err := serv.courseRepository.GetCourseAnnouncement(ctx, serv.db, announcementId)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, announcement.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.checkAnnouncementAccess(ctx, course, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.MarkAnnouncementRead(ctx, serv.db, announcement, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Announcement Marked As Read Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) GetUnreadAnnouncements(ctx context.Context, userId string) ([]*models.UnreadAnnouncement, error) {
+	unread := []*models.UnreadAnnouncement{}
+
+	db_unread, err := serv.courseRepository.GetUnreadAnnouncements(ctx, serv.db, userId)
+	if err != nil {
+		return unread, err
+	}
+
+	for _, item := range db_unread {
+		unread = append(unread, &models.UnreadAnnouncement{
+			CourseID:   item.CourseID,
+			CourseName: item.CourseName,
+			Unread:     item.Unread,
+		})
+	}
+
+	return unread, nil
+}
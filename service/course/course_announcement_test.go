@@ -0,0 +1,220 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_CreateCourseAnnouncement(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  string
+		role    string
+		wantErr error
+	}{
+		{
+			name:    "[CreateCourseAnnouncement] Creator posts an announcement",
+			userId:  creatorId,
+			wantErr: nil,
+		},
+		{
+			name:    "[CreateCourseAnnouncement] Editor posts an announcement",
+			userId:  userId,
+			role:    models.CourseRoleEditor,
+			wantErr: nil,
+		},
+		{
+			name:    "[CreateCourseAnnouncement] Learners are not allowed to post announcements",
+			userId:  userId,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return(tt.role, nil)
+
+			var inserted *db_models.CourseAnnouncement
+			repoMock.
+				On("InsertCourseAnnouncement", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.CourseAnnouncement)
+				}).
+				Return(nil)
+
+			input := &models.CourseAnnouncementInput{Title: "Schedule change", Content: "The quiz moves to Friday"}
+			got, err := svc.CreateCourseAnnouncement(context.TODO(), courseId, input, tt.userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Announcement Created Succesfully", got.Message, tt.name)
+			assert.Equal(t, &db_models.CourseAnnouncement{
+				ID:       got.Id,
+				CourseID: courseId,
+				AuthorID: tt.userId,
+				Title:    input.Title,
+				Content:  input.Content,
+			}, inserted, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetCourseAnnouncements(t *testing.T) {
+	announcementId := uuid.New().String()
+
+	tests := []struct {
+		name     string
+		enrolled bool
+		want     []*models.CourseAnnouncement
+		wantErr  error
+	}{
+		{
+			name:     "[GetCourseAnnouncements] Enrolled learner reads announcements",
+			enrolled: true,
+			want: []*models.CourseAnnouncement{
+				{ID: announcementId, CourseID: courseId, AuthorID: creatorId, Title: "Welcome", Read: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "[GetCourseAnnouncements] User is not enrolled to the course",
+			enrolled: false,
+			want:     []*models.CourseAnnouncement{},
+			wantErr:  er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+				Return("", nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(tt.enrolled, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetCourseAnnouncements", mock.Anything, mock.Anything, mock.Anything, courseId, userId).
+				Return([]*db_models.CourseAnnouncement{
+					{ID: announcementId, CourseID: courseId, AuthorID: creatorId, Title: "Welcome", Read: true},
+				}, uint64(1), nil)
+
+			got, _, err := svc.GetCourseAnnouncements(context.TODO(), &pagination.Meta{Page: 1, Limit: 10}, courseId, userId, false)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Equal(t, tt.want, got, tt.name)
+		})
+	}
+}
+
+func TestCourseService_MarkAnnouncementRead(t *testing.T) {
+	announcementId := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "[MarkAnnouncementRead] Success to mark announcement as read",
+			wantErr: nil,
+		},
+		{
+			name:    "[MarkAnnouncementRead] Announcement not found",
+			err:     er.NewError(fmt.Errorf("%s", "Announcement not found"), http.StatusNotFound, nil),
+			wantErr: er.NewError(fmt.Errorf("%s", "Announcement not found"), http.StatusNotFound, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			announcement := &db_models.CourseAnnouncement{ID: announcementId, CourseID: courseId}
+			if tt.err != nil {
+				announcement = nil
+			}
+
+			repoMock.
+				On("GetCourseAnnouncement", mock.Anything, mock.Anything, announcementId).
+				Return(announcement, tt.err)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+				Return("", nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(true, nil)
+
+			repoMock.
+				On("MarkAnnouncementRead", mock.Anything, mock.Anything, announcement, userId).
+				Return(nil)
+
+			got, err := svc.MarkAnnouncementRead(context.TODO(), announcementId, userId, false)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "MarkAnnouncementRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Announcement Marked As Read Succesfully", got.Message, tt.name)
+			repoMock.AssertCalled(t, "MarkAnnouncementRead", mock.Anything, mock.Anything, announcement, userId)
+		})
+	}
+}
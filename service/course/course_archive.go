@@ -0,0 +1,590 @@
+package course
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+const (
+	archiveManifest = "manifest.json"
+	archiveImageDir = "images/"
+	imageDirectory  = "static/image/"
+
+	// maxArchiveSize bounds an uploaded archive as well as everything it
+	// unpacks to, maxArchiveFileSize bounds a single entry.
+	maxArchiveSize     = 64 << 20
+	maxArchiveFileSize = 16 << 20
+)
+
+// imageTypes are the image formats accepted from archives, keyed by extension
+// with the content type their data must sniff as.
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+var errArchiveTooLarge = er.NewError(fmt.Errorf("%s", "Archive is too large"), http.StatusBadRequest, nil)
+
+// imagePattern matches references to images uploaded through UploadImage,
+// together with the host they were served from.
+var imagePattern = regexp.MustCompile(`(?:https?://[^\s"'()<>]*/)?static/image/([A-Za-z0-9._-]+)`)
+
+// ExportCourse packs a course, its linked assignments with their problems and
+// the uploaded images it refers to into a zip archive.
+func (serv *courseService) ExportCourse(ctx context.Context, courseId string, userId string, isAdmin bool) ([]byte, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin {
+		allowed, err := serv.hasCourseRole(ctx, course, userId, courseEditorRoles)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to export this course"), http.StatusForbidden, nil)
+		}
+	}
+
+	materials, err := serv.courseRepository.GetMaterialsByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &models.CourseArchive{
+		Version: models.CourseArchiveVersion,
+		Course: models.CourseCreation{
+			Course: models.Course{
+				CourseName:  course.CourseName,
+				Description: course.Description,
+				Thumbnail:   course.Thumbnail,
+				Sequential:  course.Sequential,
+				MinScore:    course.MinScore,
+			},
+			Sections: []*models.SectionCreation{},
+		},
+		Assignments: []*models.ArchivedAssignment{},
+		Problems:    []*models.ArchivedProblem{},
+		Images:      []string{},
+	}
+
+	texts := []string{course.Thumbnail}
+	sections := map[string]*models.SectionCreation{}
+	exportedProblems := map[string]bool{}
+
+	for _, material := range materials {
+		if material.Type == "section" {
+			section := &models.SectionCreation{
+				ID:               material.ID,
+				Name:             material.Name,
+				ReleaseAt:        formatReleaseDate(material.ReleaseAt),
+				ReleaseAfterDays: material.ReleaseAfterDays,
+				Subsections:      []*models.MaterialCreation{},
+			}
+			sections[material.ID] = section
+			archive.Course.Sections = append(archive.Course.Sections, section)
+			continue
+		}
+
+		section, ok := sections[material.SectionID]
+		if !ok {
+			continue
+		}
+
+		exported := &models.MaterialCreation{
+			ID:          material.ID,
+			Name:        material.Name,
+			Type:        material.Type,
+			Content:     material.Content,
+			ContentText: material.ContentText,
+		}
+		if material.Metadata != nil {
+			exported.Metadata = models.DecodeMaterialMetadata(material.Metadata)
+		}
+
+		section.Subsections = append(section.Subsections, exported)
+		texts = append(texts, material.Content, material.ContentText)
+
+		if material.Type == "assignment" {
+			err = serv.exportAssignment(ctx, archive, material.ID, exportedProblems)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, text := range texts {
+		for _, match := range imagePattern.FindAllStringSubmatch(text, -1) {
+			name := match[1]
+			if _, ok := files[name]; ok {
+				continue
+			}
+
+			data, err := ioutil.ReadFile(imageDirectory + name)
+			if err != nil {
+				continue
+			}
+
+			files[name] = data
+			archive.Images = append(archive.Images, name)
+		}
+	}
+
+	return writeCourseArchive(archive, files)
+}
+
+func (serv *courseService) exportAssignment(ctx context.Context, archive *models.CourseArchive, id string, exportedProblems map[string]bool) error {
+	assignment, err := serv.assignmentRepository.GetAssignmentById(ctx, serv.db, id)
+	if err != nil {
+		return err
+	}
+
+	problemIds, err := serv.assignmentRepository.GetAssignmentProblemIds(ctx, serv.db, id)
+	if err != nil {
+		return err
+	}
+
+	archive.Assignments = append(archive.Assignments, &models.ArchivedAssignment{
+		ID:         assignment.ID,
+		Title:      assignment.Title,
+		Duration:   assignment.Duration,
+		Topic:      assignment.Topic,
+		Difficulty: assignment.Difficulty,
+		Problems:   problemIds,
+	})
+
+	for _, problemId := range problemIds {
+		if exportedProblems[problemId] {
+			continue
+		}
+		exportedProblems[problemId] = true
+
+		problem, err := serv.problemRepository.GetCandidateById(ctx, serv.db, problemId)
+		if err != nil {
+			return err
+		}
+
+		archive.Problems = append(archive.Problems, &models.ArchivedProblem{
+			ID:         problem.ID,
+			Title:      problem.Title,
+			Type:       problem.Type,
+			Topic:      problem.Topic,
+			Difficulty: problem.Difficulty,
+			Detail:     problem.Detail,
+		})
+	}
+
+	return nil
+}
+
+// ImportCourse recreates a course exported by ExportCourse as a draft owned
+// by userId. Courses, materials, assignments, problems and images all get new
+// ids, references between them are remapped.
+func (serv *courseService) ImportCourse(ctx context.Context, data []byte, userId string, baseURL string) (*models.CourseCreationResponse, error) {
+	archive, files, err := readCourseArchive(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if archive.Version < 1 || archive.Version > models.CourseArchiveVersion {
+		return nil, er.NewError(fmt.Errorf("%s", "Unsupported course archive version"), http.StatusBadRequest, nil)
+	}
+
+	errs := validateCourseArchive(archive, files)
+	if len(errs) > 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid course archive"), http.StatusBadRequest, &errs)
+	}
+
+	images := map[string]string{}
+	for _, name := range archive.Images {
+		ext, _ := imageExtension(name, files[name])
+		images[name] = "course-" + uuid.New().String() + ext
+	}
+
+	rewriteImages := func(text string) string {
+		return imagePattern.ReplaceAllStringFunc(text, func(ref string) string {
+			name, ok := images[imagePattern.FindStringSubmatch(ref)[1]]
+			if !ok {
+				return ref
+			}
+			return baseURL + imageDirectory + name
+		})
+	}
+
+	problemIds := map[string]string{}
+	for _, problem := range archive.Problems {
+		problemIds[problem.ID] = uuid.New().String()
+	}
+
+	assignmentIds := map[string]string{}
+	for _, assignment := range archive.Assignments {
+		assignmentIds[assignment.ID] = uuid.New().String()
+	}
+
+	course := &archive.Course
+	course.Course.ID = uuid.New().String()
+	course.Course.Thumbnail = rewriteImages(course.Course.Thumbnail)
+
+	for _, sect := range course.Sections {
+		sect.ReleaseAt, sect.ReleaseAfterDays, err = parseReleaseRule(sect.ReleaseAt, sect.ReleaseAfterDays)
+		if err != nil {
+			return nil, err
+		}
+
+		sect.ID = uuid.New().String()
+		for _, mat := range sect.Subsections {
+			if mat.Type == "assignment" {
+				mat.ID = assignmentIds[mat.ID]
+				mat.Content = mat.ID
+			} else {
+				mat.ID = uuid.New().String()
+			}
+			mat.Content = rewriteImages(mat.Content)
+			mat.ContentText = rewriteImages(mat.ContentText)
+		}
+	}
+
+	input := &db.CourseImport{
+		Course: db.Course{
+			ID:          course.Course.ID,
+			CourseName:  course.Course.CourseName,
+			Description: course.Course.Description,
+			Thumbnail:   course.Course.Thumbnail,
+			Creator:     userId,
+			Status:      models.CourseStatusDraft,
+			Sequential:  course.Course.Sequential,
+			MinScore:    course.Course.MinScore,
+		},
+	}
+
+	for _, problem := range archive.Problems {
+		input.Problems = append(input.Problems, db.ProblemCandidate{
+			ID:         problemIds[problem.ID],
+			Creator:    userId,
+			Title:      problem.Title,
+			Type:       problem.Type,
+			Topic:      problem.Topic,
+			Difficulty: problem.Difficulty,
+			Status:     "requested",
+			Detail:     problem.Detail,
+		})
+	}
+
+	for _, assignment := range archive.Assignments {
+		id := assignmentIds[assignment.ID]
+		creation := db.AssignmentCreation{
+			Desc: db.Assignment{
+				ID:         id,
+				Creator:    userId,
+				Title:      assignment.Title,
+				Duration:   assignment.Duration,
+				Topic:      assignment.Topic,
+				Difficulty: assignment.Difficulty,
+			},
+		}
+		for _, problemId := range assignment.Problems {
+			creation.Problems = append(creation.Problems, db.AssignmentProblem{
+				AssignmentID: id,
+				ProblemID:    problemIds[problemId],
+			})
+		}
+
+		input.Assignments = append(input.Assignments, creation)
+	}
+
+	for sectPosition, sect := range course.Sections {
+		input.Materials = append(input.Materials, db.Material{
+			ID:               sect.ID,
+			CourseID:         course.Course.ID,
+			Name:             sect.Name,
+			Type:             "section",
+			Position:         sectPosition,
+			ReleaseAt:        sect.ReleaseAt,
+			ReleaseAfterDays: sect.ReleaseAfterDays,
+		})
+		for matPosition, mat := range sect.Subsections {
+			input.Materials = append(input.Materials, db.Material{
+				ID:          mat.ID,
+				CourseID:    course.Course.ID,
+				Name:        mat.Name,
+				Type:        mat.Type,
+				SectionID:   sect.ID,
+				Content:     mat.Content,
+				ContentText: mat.ContentText,
+				Metadata:    models.EncodeMaterialMetadata(mat.Metadata),
+				Position:    matPosition,
+			})
+		}
+	}
+
+	err = serv.courseRepository.ImportCourse(ctx, serv.db, input)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, newName := range images {
+		err = ioutil.WriteFile(imageDirectory+newName, files[name], 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Course Imported Succesfully",
+		Id:      course.Course.ID,
+	}
+
+	return resp, nil
+}
+
+// validateCourseArchive reports every problem of the manifest at once. Fields
+// are the json paths inside manifest.json.
+func validateCourseArchive(archive *models.CourseArchive, files map[string][]byte) []er.ErrorStruct {
+	errs := []er.ErrorStruct{}
+
+	if strings.TrimSpace(archive.Course.Course.CourseName) == "" {
+		errs = append(errs, er.ErrorStruct{Field: "course.data.course_name", Reason: "Course name is required"})
+	}
+
+	problems := map[string]bool{}
+	for idx, problem := range archive.Problems {
+		field := fmt.Sprintf("problems[%d]", idx)
+
+		if problem.ID == "" || problems[problem.ID] {
+			errs = append(errs, er.ErrorStruct{Field: field + ".id", Reason: "Problem id must be unique"})
+		}
+		problems[problem.ID] = true
+
+		if !json.Valid([]byte(problem.Detail)) {
+			errs = append(errs, er.ErrorStruct{Field: field + ".detail", Reason: "Invalid problem detail"})
+		}
+	}
+
+	assignments := map[string]bool{}
+	for idx, assignment := range archive.Assignments {
+		field := fmt.Sprintf("assignments[%d]", idx)
+
+		if assignment.ID == "" || assignments[assignment.ID] {
+			errs = append(errs, er.ErrorStruct{Field: field + ".id", Reason: "Assignment id must be unique"})
+		}
+		assignments[assignment.ID] = true
+
+		if assignment.Title == "" {
+			errs = append(errs, er.ErrorStruct{Field: field + ".title", Reason: "Assignment title is required"})
+		}
+
+		for problemIdx, problemId := range assignment.Problems {
+			if !problems[problemId] {
+				errs = append(errs, er.ErrorStruct{Field: fmt.Sprintf("%s.problems[%d]", field, problemIdx), Reason: "Problem not found in archive"})
+			}
+		}
+	}
+
+	linked := map[string]bool{}
+	for idx, sect := range archive.Course.Sections {
+		field := fmt.Sprintf("course.sections[%d]", idx)
+
+		if sect.Name == "" {
+			errs = append(errs, er.ErrorStruct{Field: field + ".sectionName", Reason: "Section name is required"})
+		}
+
+		if sect.ReleaseAfterDays != nil && *sect.ReleaseAfterDays < 0 {
+			errs = append(errs, er.ErrorStruct{Field: field + ".releaseAfterDays", Reason: "Release after days must not be negative"})
+		}
+
+		_, _, err := parseReleaseRule(sect.ReleaseAt, sect.ReleaseAfterDays)
+		if err != nil {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: err.Error()})
+		}
+
+		for matIdx, mat := range sect.Subsections {
+			matField := fmt.Sprintf("%s.subSections[%d]", field, matIdx)
+
+			if mat.Name == "" {
+				errs = append(errs, er.ErrorStruct{Field: matField + ".materialName", Reason: "Material name is required"})
+			}
+
+			// Assignments of the archive are linked by id, they are created
+			// together with the course.
+			content := mat.Content
+			if mat.Type == models.MaterialTypeAssignment {
+				content = mat.ID
+			}
+			errs = append(errs, checkMaterial(matField, mat.Type, content, mat.Metadata)...)
+
+			if mat.Type == "assignment" {
+				switch {
+				case !assignments[mat.ID]:
+					errs = append(errs, er.ErrorStruct{Field: matField + ".materialID", Reason: "Assignment not found in archive"})
+				case linked[mat.ID]:
+					errs = append(errs, er.ErrorStruct{Field: matField + ".materialID", Reason: "Assignment is already linked to another material"})
+				}
+				linked[mat.ID] = true
+			}
+		}
+	}
+
+	for idx, name := range archive.Images {
+		field := fmt.Sprintf("images[%d]", idx)
+
+		data, ok := files[name]
+		if !ok {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "Image not found in archive"})
+			continue
+		}
+
+		if _, ok := imageExtension(name, data); !ok {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "Only png, jpg, gif and webp images are allowed"})
+		}
+	}
+
+	return errs
+}
+
+func readCourseArchive(data []byte) (*models.CourseArchive, map[string][]byte, error) {
+	invalid := er.NewError(fmt.Errorf("%s", "Invalid course archive"), http.StatusBadRequest, nil)
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, invalid
+	}
+
+	var archive *models.CourseArchive
+	files := map[string][]byte{}
+	total := 0
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if file.Name != archiveManifest && !strings.HasPrefix(file.Name, archiveImageDir) {
+			continue
+		}
+
+		content, err := readArchiveFile(file)
+		if err == errArchiveTooLarge {
+			return nil, nil, err
+		}
+		if err != nil {
+			return nil, nil, invalid
+		}
+
+		total += len(content)
+		if total > maxArchiveSize {
+			return nil, nil, errArchiveTooLarge
+		}
+
+		if file.Name == archiveManifest {
+			archive = new(models.CourseArchive)
+			err = json.Unmarshal(content, archive)
+			if err != nil {
+				return nil, nil, invalid
+			}
+			continue
+		}
+
+		files[strings.TrimPrefix(file.Name, archiveImageDir)] = content
+	}
+
+	if archive == nil {
+		return nil, nil, invalid
+	}
+
+	return archive, files, nil
+}
+
+// readArchiveFile reads a zip entry, refusing entries which unpack to more
+// than maxArchiveFileSize whatever size their header claims.
+func readArchiveFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxArchiveFileSize {
+		return nil, errArchiveTooLarge
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(io.LimitReader(reader, maxArchiveFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxArchiveFileSize {
+		return nil, errArchiveTooLarge
+	}
+
+	return content, nil
+}
+
+// imageExtension returns the extension an archived image is stored under, or
+// false when data is not one of imageTypes.
+func imageExtension(name string, data []byte) (string, bool) {
+	ext := strings.ToLower(path.Ext(name))
+
+	contentType, ok := imageTypes[ext]
+	if !ok || http.DetectContentType(data) != contentType {
+		return "", false
+	}
+
+	return ext, true
+}
+
+func writeCourseArchive(archive *models.CourseArchive, files map[string][]byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+
+	manifest, err := writer.Create(archiveManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(manifest)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range archive.Images {
+		image, err := writer.Create(archiveImageDir + name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = image.Write(files[name])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,380 @@
+package course_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+// useImageDirectory runs the test inside a temporary directory holding
+// static/image, where uploaded images live.
+func useImageDirectory(t *testing.T) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = os.MkdirAll(dir+"/static/image", 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return dir
+}
+
+func buildCourseArchive(t *testing.T, manifest interface{}, images map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+
+	file, err := writer.Create("manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.NewEncoder(file).Encode(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range images {
+		file, err := writer.Create("images/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte(content))
+	}
+
+	err = writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func readCourseArchive(t *testing.T, data []byte) (*models.CourseArchive, map[string]string) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := new(models.CourseArchive)
+	files := map[string]string{}
+	for _, file := range reader.File {
+		src, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := ioutil.ReadAll(src)
+		src.Close()
+
+		if file.Name == "manifest.json" {
+			err = json.Unmarshal(content, archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		files[file.Name] = string(content)
+	}
+
+	return archive, files
+}
+
+func TestCourseService_ExportCourse(t *testing.T) {
+	problemId := uuid.New().String()
+	releaseAt := "2022-05-01 07:00:00"
+
+	tests := []struct {
+		name    string
+		userId  string
+		role    string
+		isAdmin bool
+		wantErr error
+	}{
+		{
+			name:    "[ExportCourse] Success to export course",
+			userId:  creatorId,
+			wantErr: nil,
+		},
+		{
+			name:    "[ExportCourse] Success to export course as editor",
+			userId:  userId,
+			role:    models.CourseRoleEditor,
+			wantErr: nil,
+		},
+		{
+			name:    "[ExportCourse] Not allowed to export course of other creator",
+			userId:  userId,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to export this course"), http.StatusForbidden, nil),
+		},
+		{
+			name:    "[ExportCourse] Not allowed to export course as teaching assistant",
+			userId:  userId,
+			role:    models.CourseRoleTeachingAssistant,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to export this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useImageDirectory(t)
+			ioutil.WriteFile(dir+"/static/image/course-1-thumb.png", []byte("thumbnail"), 0644)
+
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			assignmentMock := new(mocks.AssignmentRepository)
+			problemMock := new(mocks.ProblemRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+			svc.InjectAssignmentRepository(assignmentMock)
+			svc.InjectProblemRepository(problemMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{
+					ID:         courseId,
+					CourseName: "Introduction to Programming",
+					Thumbnail:  "http://localhost:8080/static/image/course-1-thumb.png",
+					Creator:    creatorId,
+					Status:     models.CourseStatusPublished,
+					Sequential: true,
+					MinScore:   60,
+				}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return(tt.role, nil)
+
+			repoMock.
+				On("GetMaterialsByCourseID", mock.Anything, mock.Anything, courseId).
+				Return([]*db_models.Material{
+					{ID: syllabusId, CourseID: courseId, Name: "Week 1", Type: "section", ReleaseAt: &releaseAt},
+					{ID: syllabusId2, CourseID: courseId, Name: "Variables", Type: "text", SectionID: syllabusId, Content: `<img src="http://localhost:8080/static/image/course-1-thumb.png">`, ContentText: "Variables"},
+					{ID: materialId, CourseID: courseId, Name: "Quiz 1", Type: "assignment", SectionID: syllabusId},
+				}, nil)
+
+			assignmentMock.
+				On("GetAssignmentById", mock.Anything, mock.Anything, materialId).
+				Return(&db_models.Assignment{ID: materialId, Creator: creatorId, Title: "Quiz 1", Duration: 600}, nil)
+
+			assignmentMock.
+				On("GetAssignmentProblemIds", mock.Anything, mock.Anything, materialId).
+				Return([]string{problemId}, nil)
+
+			problemMock.
+				On("GetCandidateById", mock.Anything, mock.Anything, problemId).
+				Return(&db_models.ProblemCandidate{ID: problemId, Title: "Sum", Type: "isian", Status: "accepted", Detail: `{"question":"1+1"}`}, nil)
+
+			got, err := svc.ExportCourse(context.TODO(), courseId, tt.userId, tt.isAdmin)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				return
+			}
+
+			archive, files := readCourseArchive(t, got)
+			assert.Equal(t, models.CourseArchiveVersion, archive.Version, tt.name)
+			assert.Equal(t, "Introduction to Programming", archive.Course.Course.CourseName, tt.name)
+			assert.True(t, archive.Course.Course.Sequential, tt.name)
+			assert.Equal(t, 60, archive.Course.Course.MinScore, tt.name)
+			assert.Len(t, archive.Course.Sections, 1, tt.name)
+			assert.Equal(t, "2022-05-01T07:00:00Z", *archive.Course.Sections[0].ReleaseAt, tt.name)
+			assert.Len(t, archive.Course.Sections[0].Subsections, 2, tt.name)
+			assert.Equal(t, []*models.ArchivedAssignment{
+				{ID: materialId, Title: "Quiz 1", Duration: 600, Problems: []string{problemId}},
+			}, archive.Assignments, tt.name)
+			assert.Equal(t, []*models.ArchivedProblem{
+				{ID: problemId, Title: "Sum", Type: "isian", Detail: `{"question":"1+1"}`},
+			}, archive.Problems, tt.name)
+			assert.Equal(t, []string{"course-1-thumb.png"}, archive.Images, tt.name)
+			assert.Equal(t, map[string]string{"images/course-1-thumb.png": "thumbnail"}, files, tt.name)
+		})
+	}
+}
+
+func TestCourseService_ImportCourse(t *testing.T) {
+	problemId := uuid.New().String()
+	releaseAt := "2022-05-01T07:00:00Z"
+
+	validArchive := func() *models.CourseArchive {
+		return &models.CourseArchive{
+			Version: models.CourseArchiveVersion,
+			Course: models.CourseCreation{
+				Course: models.Course{
+					CourseName: "Introduction to Programming",
+					Thumbnail:  "http://old-host/static/image/thumb.png",
+					Sequential: true,
+					MinScore:   60,
+				},
+				Sections: []*models.SectionCreation{
+					{
+						ID:        syllabusId,
+						Name:      "Week 1",
+						ReleaseAt: &releaseAt,
+						Subsections: []*models.MaterialCreation{
+							{ID: syllabusId2, Name: "Variables", Type: "text", Content: `<img src="http://old-host/static/image/thumb.png">`, ContentText: "Variables"},
+							{ID: materialId, Name: "Quiz 1", Type: "assignment"},
+						},
+					},
+				},
+			},
+			Assignments: []*models.ArchivedAssignment{
+				{ID: materialId, Title: "Quiz 1", Duration: 600, Problems: []string{problemId}},
+			},
+			Problems: []*models.ArchivedProblem{
+				{ID: problemId, Title: "Sum", Type: "isian", Detail: `{"question":"1+1"}`},
+			},
+			Images: []string{"thumb.png"},
+		}
+	}
+
+	unsupported := validArchive()
+	unsupported.Version = models.CourseArchiveVersion + 1
+
+	invalid := validArchive()
+	invalid.Course.Sections[0].Name = ""
+	invalid.Course.Sections[0].Subsections[0].Type = "video"
+	invalid.Assignments[0].Problems = []string{"unknown"}
+	invalid.Images = []string{"thumb.png", "missing.png", "page.html"}
+
+	// thumbnail starts with the png signature so it sniffs as image/png.
+	thumbnail := "\x89PNG\r\n\x1a\nthumbnail"
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "[ImportCourse] Success to import course",
+			data:    buildCourseArchive(t, validArchive(), map[string]string{"thumb.png": thumbnail}),
+			wantErr: nil,
+		},
+		{
+			name:    "[ImportCourse] Not a course archive",
+			data:    []byte("not a zip file"),
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid course archive"), http.StatusBadRequest, nil),
+		},
+		{
+			name:    "[ImportCourse] Unsupported archive version",
+			data:    buildCourseArchive(t, unsupported, map[string]string{"thumb.png": thumbnail}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Unsupported course archive version"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ImportCourse] Invalid archive content",
+			data: buildCourseArchive(t, invalid, map[string]string{"thumb.png": thumbnail, "page.html": "<script>alert(1)</script>"}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid course archive"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "assignments[0].problems[0]", Reason: "Problem not found in archive"},
+				{Field: "course.sections[0].sectionName", Reason: "Section name is required"},
+				{Field: "course.sections[0].subSections[0].materialContent", Reason: "Content must be the URL of the video"},
+				{Field: "images[1]", Reason: "Image not found in archive"},
+				{Field: "images[2]", Reason: "Only png, jpg, gif and webp images are allowed"},
+			}),
+		},
+		{
+			name: "[ImportCourse] Image is not what its extension claims",
+			data: buildCourseArchive(t, validArchive(), map[string]string{"thumb.png": "<svg onload=alert(1)>"}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid course archive"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "images[0]", Reason: "Only png, jpg, gif and webp images are allowed"},
+			}),
+		},
+		{
+			name:    "[ImportCourse] Archive entry is too large",
+			data:    buildCourseArchive(t, validArchive(), map[string]string{"thumb.png": thumbnail + strings.Repeat("\x00", 16<<20)}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Archive is too large"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useImageDirectory(t)
+
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			var imported *db_models.CourseImport
+			repoMock.
+				On("ImportCourse", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					imported = args.Get(2).(*db_models.CourseImport)
+				}).
+				Return(nil)
+
+			got, err := svc.ImportCourse(context.TODO(), tt.data, userId, "http://new-host/")
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "ImportCourse", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			created := imported.Course
+			assert.Equal(t, "Course Imported Succesfully", got.Message, tt.name)
+			assert.Equal(t, created.ID, got.Id, tt.name)
+			assert.Equal(t, userId, created.Creator, tt.name)
+			assert.Equal(t, models.CourseStatusDraft, created.Status, tt.name)
+			assert.True(t, created.Sequential, tt.name)
+			assert.Equal(t, 60, created.MinScore, tt.name)
+
+			assert.Len(t, imported.Problems, 1, tt.name)
+			problem := imported.Problems[0]
+			assert.NotEqual(t, problemId, problem.ID, tt.name)
+			assert.Equal(t, userId, problem.Creator, tt.name)
+			assert.Equal(t, "requested", problem.Status, tt.name)
+			assert.Equal(t, `{"question":"1+1"}`, problem.Detail, tt.name)
+
+			assert.Len(t, imported.Assignments, 1, tt.name)
+			assignment := imported.Assignments[0]
+			assert.NotEqual(t, materialId, assignment.Desc.ID, tt.name)
+			assert.Equal(t, []db_models.AssignmentProblem{{AssignmentID: assignment.Desc.ID, ProblemID: problem.ID}}, assignment.Problems, tt.name)
+
+			assert.Len(t, imported.Materials, 3, tt.name)
+			section, text, quiz := imported.Materials[0], imported.Materials[1], imported.Materials[2]
+			assert.NotEqual(t, syllabusId, section.ID, tt.name)
+			assert.Equal(t, "section", section.Type, tt.name)
+			assert.Equal(t, "2022-05-01 07:00:00", *section.ReleaseAt, tt.name)
+			assert.NotEqual(t, syllabusId2, text.ID, tt.name)
+			assert.Equal(t, section.ID, text.SectionID, tt.name)
+			assert.Equal(t, assignment.Desc.ID, quiz.ID, tt.name)
+			assert.Equal(t, assignment.Desc.ID, quiz.Content, tt.name)
+
+			assert.True(t, strings.HasPrefix(created.Thumbnail, "http://new-host/static/image/course-"), tt.name)
+			assert.True(t, strings.HasSuffix(created.Thumbnail, ".png"), tt.name)
+			assert.Equal(t, `<img src="`+created.Thumbnail+`">`, text.Content, tt.name)
+
+			image, err := ioutil.ReadFile(dir + "/" + strings.TrimPrefix(created.Thumbnail, "http://new-host/"))
+			assert.Nil(t, err, tt.name)
+			assert.Equal(t, thumbnail, string(image), tt.name)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package course
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+const (
+	joinCodeLength   = 8
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// generateJoinCode returns a random code leaving out characters which are
+// easily mistaken for one another.
+func generateJoinCode() (string, error) {
+	code := make([]byte, joinCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(joinCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = joinCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// parseCohortDates converts the dates of the cohort from RFC3339 into
+// releaseDateLayout and makes sure the cohort ends after it starts.
+func parseCohortDates(input *models.CohortInput) (string, string, error) {
+	start, err := time.Parse(time.RFC3339, input.StartDate)
+	if err != nil {
+		errs := []er.ErrorStruct{{Field: "start_date", Reason: "Start date must be in RFC3339 format"}}
+		return "", "", er.NewError(fmt.Errorf("%s", "Invalid cohort date"), http.StatusBadRequest, &errs)
+	}
+
+	end, err := time.Parse(time.RFC3339, input.EndDate)
+	if err != nil {
+		errs := []er.ErrorStruct{{Field: "end_date", Reason: "End date must be in RFC3339 format"}}
+		return "", "", er.NewError(fmt.Errorf("%s", "Invalid cohort date"), http.StatusBadRequest, &errs)
+	}
+
+	if !end.After(start) {
+		return "", "", er.NewError(fmt.Errorf("%s", "The cohort must end after it starts"), http.StatusBadRequest, nil)
+	}
+
+	return start.UTC().Format(releaseDateLayout), end.UTC().Format(releaseDateLayout), nil
+}
+
+// getInstructorIds looks up the instructors of a cohort by their email.
+func (serv *courseService) getInstructorIds(ctx context.Context, emails []string) ([]string, error) {
+	ids := []string{}
+	seen := map[string]bool{}
+
+	for _, email := range emails {
+		user, err := serv.userRepository.GetUserByEmail(ctx, serv.db, email)
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			return nil, er.NewError(fmt.Errorf("%s", "User not found"), http.StatusNotFound, &[]er.ErrorStruct{
+				{Field: "instructors", Reason: fmt.Sprintf("%s is not registered", email)},
+			})
+		}
+
+		if seen[user.ID] {
+			continue
+		}
+
+		seen[user.ID] = true
+		ids = append(ids, user.ID)
+	}
+
+	return ids, nil
+}
+
+func (serv *courseService) GetCourseCohorts(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.Cohort, error) {
+	if !isAdmin {
+		_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	db_cohorts, err := serv.courseRepository.GetCohortsByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := []*models.Cohort{}
+	for _, cohort := range db_cohorts {
+		db_instructors, err := serv.courseRepository.GetCohortInstructors(ctx, serv.db, cohort.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		instructors := []*models.CohortUser{}
+		for _, instructor := range db_instructors {
+			instructors = append(instructors, &models.CohortUser{
+				UserID:   instructor.UserID,
+				Fullname: instructor.Fullname,
+				Email:    instructor.Email,
+			})
+		}
+
+		cohorts = append(cohorts, &models.Cohort{
+			ID:          cohort.ID,
+			CourseID:    cohort.CourseID,
+			Name:        cohort.Name,
+			JoinCode:    cohort.JoinCode,
+			StartDate:   *formatReleaseDate(&cohort.StartDate),
+			EndDate:     *formatReleaseDate(&cohort.EndDate),
+			Instructors: instructors,
+		})
+	}
+
+	return cohorts, nil
+}
+
+func (serv *courseService) CreateCohort(ctx context.Context, courseId string, input *models.CohortInput, userId string) (*models.CohortResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseCohortDates(input)
+	if err != nil {
+		return nil, err
+	}
+
+	instructors, err := serv.getInstructorIds(ctx, input.Instructors)
+	if err != nil {
+		return nil, err
+	}
+
+	joinCode, err := generateJoinCode()
+	if err != nil {
+		return nil, err
+	}
+
+	cohort := &db.Cohort{
+		ID:        uuid.New().String(),
+		CourseID:  courseId,
+		Name:      input.Name,
+		JoinCode:  joinCode,
+		StartDate: start,
+		EndDate:   end,
+	}
+
+	err = serv.courseRepository.InsertCohort(ctx, serv.db, cohort, instructors)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CohortResponse{
+		Status:   "Success",
+		Message:  "Cohort Created Succesfully",
+		ID:       cohort.ID,
+		JoinCode: cohort.JoinCode,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCohort(ctx context.Context, cohortId string, input *models.CohortInput, userId string) (*models.CohortResponse, error) {
+	cohort, err := serv.courseRepository.GetCohortById(ctx, serv.db, cohortId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.authorizeCourse(ctx, cohort.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseCohortDates(input)
+	if err != nil {
+		return nil, err
+	}
+
+	instructors, err := serv.getInstructorIds(ctx, input.Instructors)
+	if err != nil {
+		return nil, err
+	}
+
+	cohort.Name = input.Name
+	cohort.StartDate = start
+	cohort.EndDate = end
+	err = serv.courseRepository.UpdateCohort(ctx, serv.db, cohort, instructors)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CohortResponse{
+		Status:   "Success",
+		Message:  "Cohort Updated Succesfully",
+		ID:       cohort.ID,
+		JoinCode: cohort.JoinCode,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteCohort(ctx context.Context, cohortId string, userId string) (*models.CourseUpdateResponse, error) {
+	cohort, err := serv.courseRepository.GetCohortById(ctx, serv.db, cohortId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.authorizeCourse(ctx, cohort.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteCohort(ctx, serv.db, cohortId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Cohort Deleted Succesfully",
+	}
+
+	return resp, nil
+}
+
+// GetCohortRoster lists the members of the cohort with their progress on the
+// course, leaving out members who have left the course. It is open to the
+// instructors of the cohort, the editors of the course and admins.
+func (serv *courseService) GetCohortRoster(ctx context.Context, cohortId string, userId string, isAdmin bool) ([]*models.CohortMember, error) {
+	cohort, err := serv.courseRepository.GetCohortById(ctx, serv.db, cohortId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin {
+		instructor, err := serv.courseRepository.IsCohortInstructor(ctx, serv.db, cohortId, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !instructor {
+			_, err = serv.authorizeCourse(ctx, cohort.CourseID, userId, courseEditorRoles)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	db_members, err := serv.courseRepository.GetCohortMembers(ctx, serv.db, cohortId)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []*models.CohortMember{}
+	for _, member := range db_members {
+		access, err := serv.hasCourseAccess(ctx, member.UserID, cohort.CourseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !access {
+			continue
+		}
+
+		progress, err := serv.ComputeUserProgress(ctx, member.UserID, cohort.CourseID)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, &models.CohortMember{
+			UserID:     member.UserID,
+			Fullname:   member.Fullname,
+			Email:      member.Email,
+			Percentage: progress.Percentage,
+		})
+	}
+
+	return members, nil
+}
+
+// getJoinableCohort finds the cohort of the course with the given join code.
+// Cohorts which have ended can no longer be joined.
+func (serv *courseService) getJoinableCohort(ctx context.Context, courseId string, joinCode string) (*db.Cohort, error) {
+	cohort, err := serv.courseRepository.GetCohortByJoinCode(ctx, serv.db, joinCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if cohort.CourseID != courseId {
+		return nil, er.NewError(fmt.Errorf("%s", "The join code does not belong to the course"), http.StatusBadRequest, nil)
+	}
+
+	end, err := time.Parse(releaseDateLayout, cohort.EndDate)
+	if err == nil && time.Now().UTC().After(end) {
+		return nil, er.NewError(fmt.Errorf("%s", "The cohort has ended"), http.StatusBadRequest, nil)
+	}
+
+	return cohort, nil
+}
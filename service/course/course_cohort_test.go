@@ -0,0 +1,308 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_CreateCohort(t *testing.T) {
+	instructorId := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		input   *models.CohortInput
+		wantErr error
+	}{
+		{
+			name: "[CreateCohort] Success to create cohort",
+			input: &models.CohortInput{
+				Name:        "Class A",
+				StartDate:   "2022-08-01T00:00:00Z",
+				EndDate:     "2022-12-01T00:00:00Z",
+				Instructors: []string{"john@mail.com", "john@mail.com"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[CreateCohort] Invalid start date",
+			input: &models.CohortInput{
+				Name:      "Class A",
+				StartDate: "2022-08-01",
+				EndDate:   "2022-12-01T00:00:00Z",
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid cohort date"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "start_date", Reason: "Start date must be in RFC3339 format"},
+			}),
+		},
+		{
+			name: "[CreateCohort] Cohort ends before it starts",
+			input: &models.CohortInput{
+				Name:      "Class A",
+				StartDate: "2022-12-01T00:00:00Z",
+				EndDate:   "2022-08-01T00:00:00Z",
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "The cohort must end after it starts"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[CreateCohort] Instructor is not registered",
+			input: &models.CohortInput{
+				Name:        "Class A",
+				StartDate:   "2022-08-01T00:00:00Z",
+				EndDate:     "2022-12-01T00:00:00Z",
+				Instructors: []string{"nobody@mail.com"},
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "User not found"), http.StatusNotFound, &[]er.ErrorStruct{
+				{Field: "instructors", Reason: "nobody@mail.com is not registered"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			userMock.
+				On("GetUserByEmail", mock.Anything, mock.Anything, "john@mail.com").
+				Return(&db_models.User{ID: instructorId, Email: "john@mail.com"}, nil)
+
+			userMock.
+				On("GetUserByEmail", mock.Anything, mock.Anything, "nobody@mail.com").
+				Return(nil, nil)
+
+			var inserted *db_models.Cohort
+			var instructors []string
+			repoMock.
+				On("InsertCohort", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.Cohort)
+					instructors = args.Get(3).([]string)
+				}).
+				Return(nil)
+
+			got, err := svc.CreateCohort(context.TODO(), courseId, tt.input, creatorId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Cohort Created Succesfully", got.Message, tt.name)
+			assert.Len(t, got.JoinCode, 8, tt.name)
+			assert.Equal(t, &db_models.Cohort{
+				ID:        got.ID,
+				CourseID:  courseId,
+				Name:      "Class A",
+				JoinCode:  got.JoinCode,
+				StartDate: "2022-08-01 00:00:00",
+				EndDate:   "2022-12-01 00:00:00",
+			}, inserted, tt.name)
+			assert.Equal(t, []string{instructorId}, instructors, tt.name)
+		})
+	}
+}
+
+func TestCourseService_EnrollWithJoinCode(t *testing.T) {
+	cohortId := uuid.New().String()
+	future := time.Now().UTC().AddDate(0, 1, 0).Format("2006-01-02 15:04:05")
+	past := time.Now().UTC().AddDate(0, -1, 0).Format("2006-01-02 15:04:05")
+
+	tests := []struct {
+		name    string
+		cohort  *db_models.Cohort
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "[Enroll] Success to enroll with join code",
+			cohort:  &db_models.Cohort{ID: cohortId, CourseID: courseId, EndDate: future},
+			wantErr: nil,
+		},
+		{
+			name:    "[Enroll] Join code of another course",
+			cohort:  &db_models.Cohort{ID: cohortId, CourseID: courseId2, EndDate: future},
+			wantErr: er.NewError(fmt.Errorf("%s", "The join code does not belong to the course"), http.StatusBadRequest, nil),
+		},
+		{
+			name:    "[Enroll] Cohort has ended",
+			cohort:  &db_models.Cohort{ID: cohortId, CourseID: courseId, EndDate: past},
+			wantErr: er.NewError(fmt.Errorf("%s", "The cohort has ended"), http.StatusBadRequest, nil),
+		},
+		{
+			name:    "[Enroll] Unknown join code",
+			err:     er.NewError(fmt.Errorf("%s", "Cohort not found"), http.StatusNotFound, nil),
+			wantErr: er.NewError(fmt.Errorf("%s", "Cohort not found"), http.StatusNotFound, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Status: models.CourseStatusPublished}, nil)
+
+			repoMock.
+				On("GetCohortByJoinCode", mock.Anything, mock.Anything, "ABCD2345").
+				Return(tt.cohort, tt.err)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetMissingPrerequisites", mock.Anything, mock.Anything, userId, courseId).
+				Return([]*db_models.Course{}, nil)
+
+			repoMock.
+				On("HasEnrollmentHistory", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			var enrollment *models.EnrollInput
+			repoMock.
+				On("InsertEnrollment", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					enrollment = args.Get(2).(*models.EnrollInput)
+				}).
+				Return(nil)
+
+			got, err := svc.Enroll(context.TODO(), userId, courseId, "ABCD2345")
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, enrollment, tt.name)
+				return
+			}
+
+			assert.Equal(t, cohortId, enrollment.CohortID, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetCohortRoster(t *testing.T) {
+	cohortId := uuid.New().String()
+	instructorId := uuid.New().String()
+	leftId := uuid.New().String()
+
+	syllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Type: "section"},
+		{ID: syllabusId2, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+		{ID: syllabusId3, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+	}
+
+	tests := []struct {
+		name       string
+		userId     string
+		instructor bool
+		want       []*models.CohortMember
+		wantErr    error
+	}{
+		{
+			name:       "[GetCohortRoster] Instructor gets the roster",
+			userId:     instructorId,
+			instructor: true,
+			want: []*models.CohortMember{
+				{UserID: userId, Fullname: "John Doe", Email: "john@mail.com", Percentage: 50},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "[GetCohortRoster] Learners are not allowed to get the roster",
+			userId:     userId,
+			instructor: false,
+			wantErr:    er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCohortById", mock.Anything, mock.Anything, cohortId).
+				Return(&db_models.Cohort{ID: cohortId, CourseID: courseId}, nil)
+
+			repoMock.
+				On("IsCohortInstructor", mock.Anything, mock.Anything, cohortId, tt.userId).
+				Return(tt.instructor, nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return("", nil)
+
+			repoMock.
+				On("GetCohortMembers", mock.Anything, mock.Anything, cohortId).
+				Return([]*db_models.CohortUser{
+					{CohortID: cohortId, UserID: userId, Fullname: "John Doe", Email: "john@mail.com"},
+					{CohortID: cohortId, UserID: leftId, Fullname: "Jane Doe", Email: "jane@mail.com"},
+				}, nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(true, nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, leftId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, leftId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetUserProgress", mock.Anything, mock.Anything, userId, courseId).
+				Return([]*db_models.UserProgress{{MaterialID: syllabusId2}}, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, courseId).
+				Return(syllabus, nil)
+
+			got, err := svc.GetCohortRoster(context.TODO(), cohortId, tt.userId, false)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Equal(t, tt.want, got, tt.name)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+// validateCollaboratorRole rejects roles which can not be given through an
+// invitation. The owner role only belongs to the creator of the course.
+func validateCollaboratorRole(role string) error {
+	if role == models.CourseRoleEditor || role == models.CourseRoleTeachingAssistant {
+		return nil
+	}
+
+	errs := []er.ErrorStruct{
+		{
+			Field:  "role",
+			Reason: fmt.Sprintf("Role must be either %s or %s", models.CourseRoleEditor, models.CourseRoleTeachingAssistant),
+		},
+	}
+
+	return er.NewError(fmt.Errorf("%s", "Invalid collaborator role"), http.StatusBadRequest, &errs)
+}
+
+// GetCourseCollaborators lists the staff of the course, starting with its
+// creator as the owner. Pending invitations are included.
+func (serv *courseService) GetCourseCollaborators(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.CourseCollaborator, error) {
+	var (
+		course *db.Course
+		err    error
+	)
+
+	if isAdmin {
+		course, err = serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	} else {
+		course, err = serv.authorizeCourse(ctx, courseId, userId, courseStaffRoles)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &models.CourseCollaborator{
+		UserID: course.Creator,
+		Role:   models.CourseRoleOwner,
+		Status: models.CollaboratorStatusAccepted,
+	}
+
+	user, err := serv.userRepository.GetUserById(ctx, serv.db, course.Creator)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		owner.Fullname = user.Name
+		owner.Email = user.Email
+	}
+
+	collaborators := []*models.CourseCollaborator{owner}
+
+	db_collaborators, err := serv.courseRepository.GetCollaboratorsByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, collaborator := range db_collaborators {
+		collaborators = append(collaborators, &models.CourseCollaborator{
+			UserID:    collaborator.UserID,
+			Fullname:  collaborator.Fullname,
+			Email:     collaborator.Email,
+			Role:      collaborator.Role,
+			Status:    collaborator.Status,
+			InvitedBy: collaborator.InvitedBy,
+			CreatedAt: collaborator.CreatedAt,
+		})
+	}
+
+	return collaborators, nil
+}
+
+// InviteCollaborator invites the user registered with the given email to the
+// course. The invitation stays pending until the user accepts it.
+func (serv *courseService) InviteCollaborator(ctx context.Context, courseId string, input *models.CollaboratorInviteInput, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseOwnerRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateCollaboratorRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := serv.userRepository.GetUserByEmail(ctx, serv.db, input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, er.NewError(fmt.Errorf("%s", "User not found"), http.StatusNotFound, nil)
+	}
+
+	if user.ID == course.Creator {
+		return nil, er.NewError(fmt.Errorf("%s", "The user is already a collaborator of the course"), http.StatusBadRequest, nil)
+	}
+
+	_, err = serv.courseRepository.GetCollaborator(ctx, serv.db, courseId, user.ID)
+	if err == nil {
+		return nil, er.NewError(fmt.Errorf("%s", "The user is already a collaborator of the course"), http.StatusBadRequest, nil)
+	}
+	if e, ok := err.(er.Error); !ok || e.HTTPStatusCode() != http.StatusNotFound {
+		return nil, err
+	}
+
+	err = serv.courseRepository.InsertCollaborator(ctx, serv.db, &db.CourseCollaborator{
+		CourseID:  courseId,
+		UserID:    user.ID,
+		Role:      input.Role,
+		Status:    models.CollaboratorStatusPending,
+		InvitedBy: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Collaborator Invited Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) AcceptCollaboration(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error) {
+	collaborator, err := serv.courseRepository.GetCollaborator(ctx, serv.db, courseId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if collaborator.Status == models.CollaboratorStatusAccepted {
+		return nil, er.NewError(fmt.Errorf("%s", "The invitation has already been accepted"), http.StatusBadRequest, nil)
+	}
+
+	collaborator.Status = models.CollaboratorStatusAccepted
+
+	err = serv.courseRepository.UpdateCollaborator(ctx, serv.db, collaborator)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Invitation Accepted Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCollaboratorRole(ctx context.Context, courseId string, collaboratorId string, input *models.CollaboratorRoleInput, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseOwnerRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	if collaboratorId == course.Creator {
+		return nil, er.NewError(fmt.Errorf("%s", "The creator of the course cannot be changed"), http.StatusBadRequest, nil)
+	}
+
+	err = validateCollaboratorRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborator, err := serv.courseRepository.GetCollaborator(ctx, serv.db, courseId, collaboratorId)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborator.Role = input.Role
+
+	err = serv.courseRepository.UpdateCollaborator(ctx, serv.db, collaborator)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Collaborator Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+// RemoveCollaborator is used by the owner to remove a collaborator and by the
+// collaborator to decline an invitation or leave the course.
+func (serv *courseService) RemoveCollaborator(ctx context.Context, courseId string, collaboratorId string, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if collaboratorId == course.Creator {
+		return nil, er.NewError(fmt.Errorf("%s", "The creator of the course cannot be changed"), http.StatusBadRequest, nil)
+	}
+
+	if collaboratorId != userId && course.Creator != userId {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil)
+	}
+
+	_, err = serv.courseRepository.GetCollaborator(ctx, serv.db, courseId, collaboratorId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteCollaborator(ctx, serv.db, courseId, collaboratorId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Collaborator Removed Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) GetCollaborationInvitations(ctx context.Context, userId string) ([]*models.CollaboratorInvitation, error) {
+	invitations := []*models.CollaboratorInvitation{}
+
+	db_invitations, err := serv.courseRepository.GetCollaborationInvitations(ctx, serv.db, userId)
+	if err != nil {
+		return invitations, err
+	}
+
+	for _, invitation := range db_invitations {
+		invitations = append(invitations, &models.CollaboratorInvitation{
+			CourseID:   invitation.CourseID,
+			CourseName: invitation.CourseName,
+			Role:       invitation.Role,
+			InvitedBy:  invitation.InvitedBy,
+			CreatedAt:  invitation.CreatedAt,
+		})
+	}
+
+	return invitations, nil
+}
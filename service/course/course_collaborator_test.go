@@ -0,0 +1,294 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_InviteCollaborator(t *testing.T) {
+	collaboratorId := uuid.New().String()
+	notFound := er.NewError(fmt.Errorf("%s", "Collaborator not found"), http.StatusNotFound, nil)
+
+	type mockRepo struct {
+		role     string
+		user     *db_models.User
+		existing *db_models.CourseCollaborator
+		err      error
+	}
+
+	tests := []struct {
+		name    string
+		userId  string
+		input   *models.CollaboratorInviteInput
+		mock    mockRepo
+		wantErr error
+	}{
+		{
+			name:   "[InviteCollaborator] Success to invite collaborator",
+			userId: creatorId,
+			input:  &models.CollaboratorInviteInput{Email: "john@mail.com", Role: models.CourseRoleEditor},
+			mock: mockRepo{
+				user: &db_models.User{ID: collaboratorId, Email: "john@mail.com"},
+				err:  notFound,
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "[InviteCollaborator] Editors are not allowed to invite collaborators",
+			userId: userId,
+			input:  &models.CollaboratorInviteInput{Email: "john@mail.com", Role: models.CourseRoleEditor},
+			mock: mockRepo{
+				role: models.CourseRoleEditor,
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+		{
+			name:   "[InviteCollaborator] Owner role can not be given",
+			userId: creatorId,
+			input:  &models.CollaboratorInviteInput{Email: "john@mail.com", Role: models.CourseRoleOwner},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid collaborator role"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "role", Reason: "Role must be either editor or teaching_assistant"},
+			}),
+		},
+		{
+			name:    "[InviteCollaborator] User not found",
+			userId:  creatorId,
+			input:   &models.CollaboratorInviteInput{Email: "nobody@mail.com", Role: models.CourseRoleTeachingAssistant},
+			wantErr: er.NewError(fmt.Errorf("%s", "User not found"), http.StatusNotFound, nil),
+		},
+		{
+			name:   "[InviteCollaborator] User is already a collaborator",
+			userId: creatorId,
+			input:  &models.CollaboratorInviteInput{Email: "john@mail.com", Role: models.CourseRoleTeachingAssistant},
+			mock: mockRepo{
+				user:     &db_models.User{ID: collaboratorId, Email: "john@mail.com"},
+				existing: &db_models.CourseCollaborator{CourseID: courseId, UserID: collaboratorId},
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "The user is already a collaborator of the course"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return(tt.mock.role, nil)
+
+			userMock.
+				On("GetUserByEmail", mock.Anything, mock.Anything, tt.input.Email).
+				Return(tt.mock.user, nil)
+
+			repoMock.
+				On("GetCollaborator", mock.Anything, mock.Anything, courseId, collaboratorId).
+				Return(tt.mock.existing, tt.mock.err)
+
+			var inserted *db_models.CourseCollaborator
+			repoMock.
+				On("InsertCollaborator", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.CourseCollaborator)
+				}).
+				Return(nil)
+
+			got, err := svc.InviteCollaborator(context.TODO(), courseId, tt.input, tt.userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Collaborator Invited Succesfully", got.Message, tt.name)
+			assert.Equal(t, &db_models.CourseCollaborator{
+				CourseID:  courseId,
+				UserID:    collaboratorId,
+				Role:      tt.input.Role,
+				Status:    models.CollaboratorStatusPending,
+				InvitedBy: creatorId,
+			}, inserted, tt.name)
+		})
+	}
+}
+
+func TestCourseService_AcceptCollaboration(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		wantErr error
+	}{
+		{
+			name:    "[AcceptCollaboration] Success to accept invitation",
+			status:  models.CollaboratorStatusPending,
+			wantErr: nil,
+		},
+		{
+			name:    "[AcceptCollaboration] Invitation is already accepted",
+			status:  models.CollaboratorStatusAccepted,
+			wantErr: er.NewError(fmt.Errorf("%s", "The invitation has already been accepted"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCollaborator", mock.Anything, mock.Anything, courseId, userId).
+				Return(&db_models.CourseCollaborator{CourseID: courseId, UserID: userId, Role: models.CourseRoleEditor, Status: tt.status}, nil)
+
+			var updated *db_models.CourseCollaborator
+			repoMock.
+				On("UpdateCollaborator", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					updated = args.Get(2).(*db_models.CourseCollaborator)
+				}).
+				Return(nil)
+
+			got, err := svc.AcceptCollaboration(context.TODO(), courseId, userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Invitation Accepted Succesfully", got.Message, tt.name)
+			assert.Equal(t, models.CollaboratorStatusAccepted, updated.Status, tt.name)
+			assert.Equal(t, models.CourseRoleEditor, updated.Role, tt.name)
+		})
+	}
+}
+
+func TestCourseService_RemoveCollaborator(t *testing.T) {
+	otherId := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		collaboratorId string
+		userId         string
+		wantErr        error
+	}{
+		{
+			name:           "[RemoveCollaborator] Owner removes a collaborator",
+			collaboratorId: userId,
+			userId:         creatorId,
+			wantErr:        nil,
+		},
+		{
+			name:           "[RemoveCollaborator] Collaborator leaves the course",
+			collaboratorId: userId,
+			userId:         userId,
+			wantErr:        nil,
+		},
+		{
+			name:           "[RemoveCollaborator] Not allowed to remove other collaborators",
+			collaboratorId: userId,
+			userId:         otherId,
+			wantErr:        er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+		{
+			name:           "[RemoveCollaborator] Creator can not be removed",
+			collaboratorId: creatorId,
+			userId:         creatorId,
+			wantErr:        er.NewError(fmt.Errorf("%s", "The creator of the course cannot be changed"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaborator", mock.Anything, mock.Anything, courseId, tt.collaboratorId).
+				Return(&db_models.CourseCollaborator{CourseID: courseId, UserID: tt.collaboratorId}, nil)
+
+			repoMock.
+				On("DeleteCollaborator", mock.Anything, mock.Anything, courseId, tt.collaboratorId).
+				Return(nil)
+
+			got, err := svc.RemoveCollaborator(context.TODO(), courseId, tt.collaboratorId, tt.userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "DeleteCollaborator", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Collaborator Removed Succesfully", got.Message, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetCourseCollaborators(t *testing.T) {
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	userMock := new(mocks.UserRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+	svc.InjectUserRepository(userMock)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+	repoMock.
+		On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+		Return(models.CourseRoleTeachingAssistant, nil)
+
+	userMock.
+		On("GetUserById", mock.Anything, mock.Anything, creatorId).
+		Return(&db_models.User{ID: creatorId, Name: "Jane Doe", Email: "jane@mail.com"}, nil)
+
+	repoMock.
+		On("GetCollaboratorsByCourseID", mock.Anything, mock.Anything, courseId).
+		Return([]*db_models.CourseCollaborator{
+			{CourseID: courseId, UserID: userId, Fullname: "John Doe", Email: "john@mail.com", Role: models.CourseRoleTeachingAssistant, Status: models.CollaboratorStatusAccepted, InvitedBy: creatorId},
+		}, nil)
+
+	got, err := svc.GetCourseCollaborators(context.TODO(), courseId, userId, false)
+
+	assert.Nil(t, err, "[GetCourseCollaborators] Success to get course collaborators")
+	assert.Equal(t, []*models.CourseCollaborator{
+		{UserID: creatorId, Fullname: "Jane Doe", Email: "jane@mail.com", Role: models.CourseRoleOwner, Status: models.CollaboratorStatusAccepted},
+		{UserID: userId, Fullname: "John Doe", Email: "john@mail.com", Role: models.CourseRoleTeachingAssistant, Status: models.CollaboratorStatusAccepted, InvitedBy: creatorId},
+	}, got, "[GetCourseCollaborators] Success to get course collaborators")
+}
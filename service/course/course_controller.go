@@ -2,6 +2,8 @@ package course
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -89,6 +91,34 @@ func (ctl *CourseController) HandleGetOnProgressCoursePagination(c echo.Context)
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (ctl *CourseController) HandleGetResumePoints(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetResumePoints(ctx, &meta, userId)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (ctl *CourseController) HandleGetCoursePagination(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -97,7 +127,10 @@ func (ctl *CourseController) HandleGetCoursePagination(c echo.Context) error {
 	meta := pagination.Meta{}
 	meta.FromContext(c)
 
-	items, count, err := ctl.courseService.GetCoursePagination(ctx, &meta)
+	filter := models.CourseFilter{}
+	filter.FromContext(c)
+
+	items, count, err := ctl.courseService.GetCoursePagination(ctx, &meta, filter)
 	if err != nil {
 		return err
 	}
@@ -118,9 +151,11 @@ func (ctl *CourseController) HandleGetCoursePagination(c echo.Context) error {
 
 func (ctl *CourseController) HandleGetCourseSyllabus(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
 	courseId := c.Param("id")
 
-	resp, err := ctl.courseService.GetCourseSyllabus(ctx, courseId)
+	resp, err := ctl.courseService.GetCourseSyllabus(ctx, courseId, userId, isAdmin)
 	if err != nil {
 		return err
 	}
@@ -130,10 +165,12 @@ func (ctl *CourseController) HandleGetCourseSyllabus(c echo.Context) error {
 
 func (ctl *CourseController) HandleGetMaterial(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
 	courseId := c.Param("id")
 	sectionId := c.Param("sectId")
 
-	resp, err := ctl.courseService.GetCourseMaterial(ctx, courseId, sectionId)
+	resp, err := ctl.courseService.GetCourseMaterial(ctx, courseId, sectionId, userId, isAdmin)
 	if err != nil {
 		return err
 	}
@@ -145,11 +182,67 @@ func (ctl *CourseController) HandleEnroll(c echo.Context) error {
 	ctx := c.Request().Context()
 	userId := c.Get("userId").(string)
 	courseId := c.Param("id")
+	joinCode := c.QueryParam("code")
+
+	resp, err := ctl.courseService.Enroll(ctx, userId, courseId, joinCode)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleReEnroll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	joinCode := c.QueryParam("code")
+	keepProgress := c.QueryParam("keep_progress") == "true"
+
+	resp, err := ctl.courseService.ReEnroll(ctx, userId, courseId, joinCode, keepProgress)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUnenroll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	keepProgress := c.QueryParam("keep_progress") == "true"
+
+	resp, err := ctl.courseService.Unenroll(ctx, userId, courseId, keepProgress)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetEnrollmentHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+
+	var resp pagination.PaginationResponse
 
-	resp, err := ctl.courseService.Enroll(ctx, userId, courseId)
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetEnrollmentHistory(ctx, &meta, userId)
 	if err != nil {
 		return err
 	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -159,7 +252,16 @@ func (ctl *CourseController) HandleStoreProgress(c echo.Context) error {
 	materialId := c.Param("id")
 	userId := c.Get("userId").(string)
 
-	resp, err := ctl.courseService.StoreUserProgress(ctx, userId, materialId)
+	event := new(models.ProgressEvent)
+	if err := c.Bind(event); err != nil {
+		return err
+	}
+
+	if err := c.Validate(event); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.StoreUserProgress(ctx, userId, materialId, event)
 	if err != nil {
 		return err
 	}
@@ -296,6 +398,106 @@ func (ctl *CourseController) HandleCreateMaterial(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)	
 }
 
+func (ctl *CourseController) HandleCloneCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	input := new(models.CourseCloneInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.CloneCourse(ctx, courseId, input, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleExportCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	data, err := ctl.courseService.ExportCourse(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"course-%s.zip\"", courseId))
+	return c.Blob(http.StatusOK, "application/zip", data)
+}
+
+func (ctl *CourseController) HandleImportCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	url := "http://" + c.Request().Host + "/"
+
+	file, err := c.FormFile("archive")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Course archive is required")
+	}
+
+	if file.Size > maxArchiveSize {
+		return echo.NewHTTPError(http.StatusBadRequest, "Course archive is too large")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(src, maxArchiveSize))
+	if err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.ImportCourse(ctx, data, userId, url)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleImportContentPackage(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	url := "http://" + c.Request().Host + "/"
+
+	file, err := c.FormFile("package")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Content package is required")
+	}
+
+	if file.Size > maxArchiveSize {
+		return echo.NewHTTPError(http.StatusBadRequest, "Content package is too large")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(src, maxArchiveSize))
+	if err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.ImportContentPackage(ctx, data, userId, url)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (ctl *CourseController) HandleCourseByCreatorId(c echo.Context) error {
 	ctx := c.Request().Context()
 	userId  := c.Param("userID")
@@ -318,4 +520,899 @@ func (ctl *CourseController) HandleContributedCourse(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, resp)
-}
\ No newline at end of file
+}
+
+func (ctl *CourseController) HandleUpdateDescription(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseDescriptionInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	update := &models.CourseDescriptionUpdate{
+		CourseName:  &input.CourseName,
+		Description: &input.Description,
+		Thumbnail:   &input.Thumbnail,
+	}
+
+	resp, err := ctl.courseService.UpdateCourseDesc(ctx, courseId, update, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandlePatchDescription(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseDescriptionUpdate)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.UpdateCourseDesc(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.DeleteCourse(ctx, courseId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateSection(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	sectionId := c.Param("id")
+
+	input := new(models.CourseSectionUpdateInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateCourseSection(ctx, sectionId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteSection(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	sectionId := c.Param("id")
+	cascade := c.QueryParam("cascade") == "true"
+
+	resp, err := ctl.courseService.DeleteCourseSection(ctx, sectionId, cascade, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateMaterial(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	materialId := c.Param("id")
+
+	input := new(models.CourseMaterialUpdateInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	if input.Metadata == nil {
+		input.Metadata = new(models.MaterialMetadata)
+	}
+
+	update := &models.CourseMaterialUpdate{
+		Name:        &input.Name,
+		Type:        &input.Type,
+		Content:     &input.Content,
+		ContentText: &input.ContentText,
+		Metadata:    input.Metadata,
+	}
+
+	resp, err := ctl.courseService.UpdateCourseMaterial(ctx, materialId, update, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandlePatchMaterial(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	materialId := c.Param("id")
+
+	input := new(models.CourseMaterialUpdate)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.UpdateCourseMaterial(ctx, materialId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteMaterial(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	materialId := c.Param("id")
+
+	resp, err := ctl.courseService.DeleteCourseMaterial(ctx, materialId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleReorderSyllabus(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.SyllabusOrderInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.ReorderSyllabus(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCourseReviewList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetCourseReviewList(ctx, &meta)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleSubmitCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.SubmitCourseForReview(ctx, courseId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandlePublishCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.PublishCourse(ctx, courseId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleRejectCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.RejectCourse(ctx, courseId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleArchiveCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.ArchiveCourse(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetTopicList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	resp, err := ctl.courseService.GetTopicList(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleCreateTopic(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	input := new(models.TopicInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.CreateTopic(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateTopic(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	input := new(models.TopicInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateTopic(ctx, id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteTopic(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	resp, err := ctl.courseService.DeleteTopic(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleSetCourseTopics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseTopicInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.SetCourseTopics(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCoursePrerequisites(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetCoursePrerequisites(ctx, courseId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleSetCoursePrerequisites(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CoursePrerequisiteInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.SetCoursePrerequisites(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateCourseAccess(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseAccessInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateCourseAccess(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCourseCollaborators(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetCourseCollaborators(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleInviteCollaborator(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CollaboratorInviteInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.InviteCollaborator(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleAcceptCollaboration(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.AcceptCollaboration(ctx, courseId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateCollaborator(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	collaboratorId := c.Param("userId")
+
+	input := new(models.CollaboratorRoleInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateCollaboratorRole(ctx, courseId, collaboratorId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleRemoveCollaborator(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	collaboratorId := c.Param("userId")
+
+	resp, err := ctl.courseService.RemoveCollaborator(ctx, courseId, collaboratorId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCollaborationInvitations(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+
+	resp, err := ctl.courseService.GetCollaborationInvitations(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCourseReviews(c echo.Context) error {
+	ctx := c.Request().Context()
+	courseId := c.Param("id")
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetCourseReviews(ctx, &meta, courseId)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleReviewCourse(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseReviewInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.ReviewCourse(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleModerateReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	courseId := c.Param("id")
+	reviewerId := c.Param("userId")
+
+	input := new(models.ReviewModerationInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.courseService.ModerateCourseReview(ctx, courseId, reviewerId, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCourseAnnouncements(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetCourseAnnouncements(ctx, &meta, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleCreateAnnouncement(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CourseAnnouncementInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.CreateCourseAnnouncement(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateAnnouncement(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	announcementId := c.Param("id")
+
+	input := new(models.CourseAnnouncementInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateCourseAnnouncement(ctx, announcementId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteAnnouncement(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	announcementId := c.Param("id")
+
+	resp, err := ctl.courseService.DeleteCourseAnnouncement(ctx, announcementId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleMarkAnnouncementRead(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	announcementId := c.Param("id")
+
+	resp, err := ctl.courseService.MarkAnnouncementRead(ctx, announcementId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetUnreadAnnouncements(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+
+	resp, err := ctl.courseService.GetUnreadAnnouncements(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCourseCohorts(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetCourseCohorts(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleCreateCohort(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.CohortInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.CreateCohort(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateCohort(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	cohortId := c.Param("id")
+
+	input := new(models.CohortInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateCohort(ctx, cohortId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDeleteCohort(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	cohortId := c.Param("id")
+
+	resp, err := ctl.courseService.DeleteCohort(ctx, cohortId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCohortRoster(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	cohortId := c.Param("id")
+
+	resp, err := ctl.courseService.GetCohortRoster(ctx, cohortId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleUpdateEnrollmentSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+
+	input := new(models.EnrollmentSettingsInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.courseService.UpdateEnrollmentSettings(ctx, courseId, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetEnrollmentRequests(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	status := c.QueryParam("status")
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.courseService.GetEnrollmentRequests(ctx, &meta, courseId, status, userId)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleApproveEnrollmentRequest(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	requesterId := c.Param("userId")
+
+	resp, err := ctl.courseService.ApproveEnrollmentRequest(ctx, courseId, requesterId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleDenyEnrollmentRequest(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	courseId := c.Param("id")
+	requesterId := c.Param("userId")
+
+	resp, err := ctl.courseService.DenyEnrollmentRequest(ctx, courseId, requesterId, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetEnrollmentAnalytics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetEnrollmentAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetFunnelAnalytics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetFunnelAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetScoreAnalytics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetScoreAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *CourseController) HandleGetCompletionAnalytics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	courseId := c.Param("id")
+
+	resp, err := ctl.courseService.GetCompletionAnalytics(ctx, courseId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,363 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+var enrollmentModes = []string{
+	models.EnrollmentModeOpen,
+	models.EnrollmentModeCode,
+	models.EnrollmentModeCapped,
+	models.EnrollmentModeApproval,
+}
+
+func validateEnrollmentSettings(input *models.EnrollmentSettingsInput) error {
+	valid := false
+	for _, mode := range enrollmentModes {
+		if input.Mode == mode {
+			valid = true
+		}
+	}
+
+	var errs []er.ErrorStruct
+	switch {
+	case !valid:
+		errs = append(errs, er.ErrorStruct{Field: "mode", Reason: "Mode must be one of open, code, capped or approval"})
+	case input.Mode == models.EnrollmentModeCode && input.EnrollmentKey == "":
+		errs = append(errs, er.ErrorStruct{Field: "enrollment_key", Reason: "Enrollment key is required for code protected courses"})
+	case input.Mode == models.EnrollmentModeCapped && input.Capacity <= 0:
+		errs = append(errs, er.ErrorStruct{Field: "capacity", Reason: "Capacity must be greater than 0"})
+	}
+
+	if len(errs) > 0 {
+		return er.NewError(fmt.Errorf("%s", "Invalid enrollment settings"), http.StatusBadRequest, &errs)
+	}
+
+	return nil
+}
+
+// UpdateEnrollmentSettings changes how learners enroll to the course. Raising
+// the capacity of a capped course lets waitlisted learners in right away.
+func (serv *courseService) UpdateEnrollmentSettings(ctx context.Context, courseId string, input *models.EnrollmentSettingsInput, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateEnrollmentSettings(input)
+	if err != nil {
+		return nil, err
+	}
+
+	course.EnrollmentMode = input.Mode
+	course.EnrollmentKey = ""
+	course.Capacity = 0
+	switch input.Mode {
+	case models.EnrollmentModeCode:
+		course.EnrollmentKey = input.EnrollmentKey
+	case models.EnrollmentModeCapped:
+		course.Capacity = input.Capacity
+	}
+
+	err = serv.courseRepository.UpdateEnrollmentSettings(ctx, serv.db, course)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.promoteWaitlist(ctx, course)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Enrollment Settings Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+// resolveJoinCode checks the join code given on enrollment and returns the
+// cohort it belongs to. Code protected courses accept either their
+// enrollment key or the join code of one of their cohorts.
+func (serv *courseService) resolveJoinCode(ctx context.Context, course *db.Course, joinCode string) (string, error) {
+	protected := course.EnrollmentMode == models.EnrollmentModeCode
+	invalidCode := er.NewError(fmt.Errorf("%s", "A valid join code is required to enroll to the course"), http.StatusForbidden, nil)
+
+	if joinCode == "" {
+		if protected {
+			return "", invalidCode
+		}
+		return "", nil
+	}
+
+	if protected && joinCode == course.EnrollmentKey {
+		return "", nil
+	}
+
+	cohort, err := serv.getJoinableCohort(ctx, course.ID, joinCode)
+	if err != nil {
+		if e, ok := err.(er.Error); ok && e.HTTPStatusCode() == http.StatusNotFound && protected {
+			return "", invalidCode
+		}
+		return "", err
+	}
+
+	return cohort.ID, nil
+}
+
+// checkEnrollmentRequest stops learners from queueing twice for the same
+// course. Requests left over from an earlier enrollment mode are ignored.
+func (serv *courseService) checkEnrollmentRequest(ctx context.Context, course *db.Course, userId string) error {
+	if course.EnrollmentMode != models.EnrollmentModeApproval && course.EnrollmentMode != models.EnrollmentModeCapped {
+		return nil
+	}
+
+	request, err := serv.courseRepository.GetEnrollmentRequest(ctx, serv.db, course.ID, userId)
+	if err != nil {
+		if e, ok := err.(er.Error); ok && e.HTTPStatusCode() == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	switch {
+	case course.EnrollmentMode == models.EnrollmentModeApproval && request.Status == models.EnrollmentRequestPending:
+		return er.NewError(fmt.Errorf("%s", "Your enrollment request is already waiting for approval"), http.StatusBadRequest, nil)
+	case course.EnrollmentMode == models.EnrollmentModeApproval && request.Status == models.EnrollmentRequestDenied:
+		return er.NewError(fmt.Errorf("%s", "Your enrollment request has been denied"), http.StatusBadRequest, nil)
+	case course.EnrollmentMode == models.EnrollmentModeCapped && request.Status == models.EnrollmentRequestWaitlisted:
+		return er.NewError(fmt.Errorf("%s", "You are already on the waitlist of the course"), http.StatusBadRequest, nil)
+	}
+
+	return nil
+}
+
+// isCourseFull tells whether InsertEnrollment was refused because every seat
+// of a capped course is taken.
+func isCourseFull(err error) bool {
+	e, ok := err.(er.Error)
+	return ok && e.HTTPStatusCode() == http.StatusConflict
+}
+
+// courseCapacity is the number of seats of a capped course, 0 when the course
+// takes any number of learners.
+func courseCapacity(course *db.Course) int {
+	if course.EnrollmentMode != models.EnrollmentModeCapped {
+		return 0
+	}
+
+	return course.Capacity
+}
+
+// admitEnrollment enrolls the learner, or queues them when the course needs
+// approval or every seat of a capped course is taken. Seats are counted while
+// the enrollment is stored so concurrent enrollments can not overbook.
+func (serv *courseService) admitEnrollment(ctx context.Context, course *db.Course, values *models.EnrollInput, message string) (*models.EnrollResponse, error) {
+	if course.EnrollmentMode == models.EnrollmentModeApproval {
+		return serv.queueEnrollment(ctx, values, models.EnrollmentRequestPending)
+	}
+
+	values.Capacity = courseCapacity(course)
+
+	err := serv.courseRepository.InsertEnrollment(ctx, serv.db, values)
+	if isCourseFull(err) {
+		return serv.queueEnrollment(ctx, values, models.EnrollmentRequestWaitlisted)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.EnrollResponse{
+		Status:  "Success",
+		Message: message,
+	}
+
+	return out, nil
+}
+
+// queueEnrollment puts the learner on the approval queue or the waitlist of
+// the course instead of enrolling them.
+func (serv *courseService) queueEnrollment(ctx context.Context, values *models.EnrollInput, status string) (*models.EnrollResponse, error) {
+	err := serv.courseRepository.UpsertEnrollmentRequest(ctx, serv.db, &db.EnrollmentRequest{
+		CourseID:     values.CourseID,
+		UserID:       values.UserID,
+		CohortID:     values.CohortID,
+		Action:       values.Action,
+		KeepProgress: values.KeepProgress,
+		Status:       status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Your enrollment request is waiting for approval."
+	if status == models.EnrollmentRequestWaitlisted {
+		message = "The course is full, you have been added to the waitlist."
+	}
+
+	out := &models.EnrollResponse{
+		Status:  "Success",
+		Message: message,
+	}
+
+	return out, nil
+}
+
+// promoteWaitlist enrolls waitlisted learners of a capped course, oldest
+// first, until every seat is taken. Learners who enroll meanwhile may take
+// the last seats, the rest of the waitlist then keeps its place.
+func (serv *courseService) promoteWaitlist(ctx context.Context, course *db.Course) error {
+	if course.EnrollmentMode != models.EnrollmentModeCapped {
+		return nil
+	}
+
+	enrolled, err := serv.courseRepository.CountEnrolledUsers(ctx, serv.db, course.ID)
+	if err != nil {
+		return err
+	}
+
+	seats := course.Capacity - enrolled
+	if seats <= 0 {
+		return nil
+	}
+
+	meta := &pagination.Meta{Page: 1, Limit: seats}
+	requests, _, err := serv.courseRepository.GetEnrollmentRequests(ctx, serv.db, meta, course.ID, models.EnrollmentRequestWaitlisted)
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		err = serv.enrollFromRequest(ctx, request, course.Capacity)
+		if isCourseFull(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enrollFromRequest enrolls the learner behind an approved or promoted
+// request and removes the request. Capacity is passed on to InsertEnrollment.
+func (serv *courseService) enrollFromRequest(ctx context.Context, request *db.EnrollmentRequest, capacity int) error {
+	enrolled, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, request.UserID, request.CourseID)
+	if err != nil {
+		return err
+	}
+
+	if !enrolled {
+		err = serv.courseRepository.InsertEnrollment(ctx, serv.db, &models.EnrollInput{
+			UserID:       request.UserID,
+			CourseID:     request.CourseID,
+			Action:       request.Action,
+			KeepProgress: request.KeepProgress,
+			CohortID:     request.CohortID,
+			Capacity:     capacity,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return serv.courseRepository.DeleteEnrollmentRequest(ctx, serv.db, request.CourseID, request.UserID)
+}
+
+func (serv *courseService) GetEnrollmentRequests(ctx context.Context, meta *pagination.Meta, courseId string, status string, userId string) ([]*models.EnrollmentRequest, uint64, error) {
+	requests := []*models.EnrollmentRequest{}
+
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseStaffRoles)
+	if err != nil {
+		return requests, 0, err
+	}
+
+	if status == "" {
+		status = models.EnrollmentRequestPending
+	}
+
+	db_requests, count, err := serv.courseRepository.GetEnrollmentRequests(ctx, serv.db, meta, courseId, status)
+	if err != nil {
+		return requests, count, err
+	}
+
+	for _, request := range db_requests {
+		requests = append(requests, &models.EnrollmentRequest{
+			UserID:    request.UserID,
+			Fullname:  request.Fullname,
+			Email:     request.Email,
+			CohortID:  request.CohortID,
+			Status:    request.Status,
+			CreatedAt: request.CreatedAt,
+		})
+	}
+
+	return requests, count, nil
+}
+
+// getPendingRequest loads a request awaiting approval on behalf of the staff
+// of the course.
+func (serv *courseService) getPendingRequest(ctx context.Context, courseId string, requesterId string, userId string) (*db.EnrollmentRequest, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseStaffRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := serv.courseRepository.GetEnrollmentRequest(ctx, serv.db, courseId, requesterId)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.EnrollmentRequestPending {
+		return nil, er.NewError(fmt.Errorf("%s", "The enrollment request is not waiting for approval"), http.StatusBadRequest, nil)
+	}
+
+	return request, nil
+}
+
+func (serv *courseService) ApproveEnrollmentRequest(ctx context.Context, courseId string, requesterId string, userId string) (*models.CourseUpdateResponse, error) {
+	request, err := serv.getPendingRequest(ctx, courseId, requesterId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.enrollFromRequest(ctx, request, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Enrollment Request Approved Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DenyEnrollmentRequest(ctx context.Context, courseId string, requesterId string, userId string) (*models.CourseUpdateResponse, error) {
+	_, err := serv.getPendingRequest(ctx, courseId, requesterId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.SetEnrollmentRequestStatus(ctx, serv.db, courseId, requesterId, models.EnrollmentRequestDenied)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Enrollment Request Denied Succesfully",
+	}
+
+	return resp, nil
+}
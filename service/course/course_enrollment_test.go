@@ -0,0 +1,418 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_EnrollWithEnrollmentMode(t *testing.T) {
+	notFound := er.NewError(fmt.Errorf("%s", "Enrollment request not found"), http.StatusNotFound, nil)
+	full := er.NewError(fmt.Errorf("%s", "The course is full"), http.StatusConflict, nil)
+
+	type mockRepo struct {
+		request    *db_models.EnrollmentRequest
+		requestErr error
+		insertErr  error
+	}
+
+	tests := []struct {
+		name        string
+		course      *db_models.Course
+		joinCode    string
+		mock        mockRepo
+		wantMessage string
+		wantQueued  string
+		wantErr     error
+	}{
+		{
+			name:        "[Enroll] Enroll to an open course",
+			course:      &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeOpen},
+			wantMessage: "You have successfully enrolled to the course.",
+		},
+		{
+			name:     "[Enroll] Code protected course without a join code",
+			course:   &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCode, EnrollmentKey: "SECRET"},
+			joinCode: "",
+			wantErr:  er.NewError(fmt.Errorf("%s", "A valid join code is required to enroll to the course"), http.StatusForbidden, nil),
+		},
+		{
+			name:        "[Enroll] Code protected course with the enrollment key",
+			course:      &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCode, EnrollmentKey: "SECRET"},
+			joinCode:    "SECRET",
+			wantMessage: "You have successfully enrolled to the course.",
+		},
+		{
+			name:     "[Enroll] Code protected course with a wrong key",
+			course:   &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCode, EnrollmentKey: "SECRET"},
+			joinCode: "WRONG",
+			wantErr:  er.NewError(fmt.Errorf("%s", "A valid join code is required to enroll to the course"), http.StatusForbidden, nil),
+		},
+		{
+			name:        "[Enroll] Approval course queues the learner",
+			course:      &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeApproval},
+			mock:        mockRepo{requestErr: notFound},
+			wantMessage: "Your enrollment request is waiting for approval.",
+			wantQueued:  models.EnrollmentRequestPending,
+		},
+		{
+			name:   "[Enroll] Approval course with a pending request",
+			course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeApproval},
+			mock: mockRepo{
+				request: &db_models.EnrollmentRequest{CourseID: courseId, UserID: userId, Status: models.EnrollmentRequestPending},
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "Your enrollment request is already waiting for approval"), http.StatusBadRequest, nil),
+		},
+		{
+			name:   "[Enroll] Approval course with a denied request",
+			course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeApproval},
+			mock: mockRepo{
+				request: &db_models.EnrollmentRequest{CourseID: courseId, UserID: userId, Status: models.EnrollmentRequestDenied},
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "Your enrollment request has been denied"), http.StatusBadRequest, nil),
+		},
+		{
+			name:        "[Enroll] Capped course with a free seat",
+			course:      &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCapped, Capacity: 2},
+			mock:        mockRepo{requestErr: notFound},
+			wantMessage: "You have successfully enrolled to the course.",
+		},
+		{
+			name:        "[Enroll] Full capped course puts the learner on the waitlist",
+			course:      &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCapped, Capacity: 2},
+			mock:        mockRepo{requestErr: notFound, insertErr: full},
+			wantMessage: "The course is full, you have been added to the waitlist.",
+			wantQueued:  models.EnrollmentRequestWaitlisted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(tt.course, nil)
+
+			repoMock.
+				On("GetCohortByJoinCode", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, er.NewError(fmt.Errorf("%s", "Cohort not found"), http.StatusNotFound, nil))
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetMissingPrerequisites", mock.Anything, mock.Anything, userId, courseId).
+				Return([]*db_models.Course{}, nil)
+
+			repoMock.
+				On("HasEnrollmentHistory", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetEnrollmentRequest", mock.Anything, mock.Anything, courseId, userId).
+				Return(tt.mock.request, tt.mock.requestErr)
+
+			var queued *db_models.EnrollmentRequest
+			repoMock.
+				On("UpsertEnrollmentRequest", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					queued = args.Get(2).(*db_models.EnrollmentRequest)
+				}).
+				Return(nil)
+
+			repoMock.
+				On("InsertEnrollment", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.insertErr)
+
+			got, err := svc.Enroll(context.TODO(), userId, courseId, tt.joinCode)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "InsertEnrollment", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, tt.wantMessage, got.Message, tt.name)
+			if tt.wantQueued != "" {
+				assert.Equal(t, &db_models.EnrollmentRequest{CourseID: courseId, UserID: userId, Action: models.EnrollmentActionEnroll, KeepProgress: true, Status: tt.wantQueued}, queued, tt.name)
+				return
+			}
+
+			assert.Nil(t, queued, tt.name)
+			repoMock.AssertCalled(t, "InsertEnrollment", mock.Anything, mock.Anything, &models.EnrollInput{
+				UserID:       userId,
+				CourseID:     courseId,
+				Action:       models.EnrollmentActionEnroll,
+				KeepProgress: true,
+				Capacity:     tt.course.Capacity,
+			})
+		})
+	}
+}
+
+func TestCourseService_UnenrollPromotesWaitlist(t *testing.T) {
+	waitlistedId := uuid.New().String()
+	cohortId := uuid.New().String()
+
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+
+	repoMock.
+		On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+		Return(true, nil)
+
+	repoMock.
+		On("DeleteEnrollment", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, EnrollmentMode: models.EnrollmentModeCapped, Capacity: 2}, nil)
+
+	repoMock.
+		On("CountEnrolledUsers", mock.Anything, mock.Anything, courseId).
+		Return(1, nil)
+
+	repoMock.
+		On("GetEnrollmentRequests", mock.Anything, mock.Anything, &pagination.Meta{Page: 1, Limit: 1}, courseId, models.EnrollmentRequestWaitlisted).
+		Return([]*db_models.EnrollmentRequest{
+			{CourseID: courseId, UserID: waitlistedId, CohortID: cohortId, Action: models.EnrollmentActionReEnroll, KeepProgress: false, Status: models.EnrollmentRequestWaitlisted},
+		}, uint64(3), nil)
+
+	repoMock.
+		On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, waitlistedId, courseId).
+		Return(false, nil)
+
+	repoMock.
+		On("InsertEnrollment", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	repoMock.
+		On("DeleteEnrollmentRequest", mock.Anything, mock.Anything, courseId, waitlistedId).
+		Return(nil)
+
+	got, err := svc.Unenroll(context.TODO(), userId, courseId, true)
+
+	assert.Nil(t, err, "[Unenroll] Waitlisted learner takes the free seat")
+	assert.Equal(t, "You have successfully unenrolled from the course.", got.Message, "[Unenroll] Waitlisted learner takes the free seat")
+	repoMock.AssertCalled(t, "InsertEnrollment", mock.Anything, mock.Anything, &models.EnrollInput{
+		UserID:       waitlistedId,
+		CourseID:     courseId,
+		Action:       models.EnrollmentActionReEnroll,
+		KeepProgress: false,
+		CohortID:     cohortId,
+		Capacity:     2,
+	})
+	repoMock.AssertCalled(t, "DeleteEnrollmentRequest", mock.Anything, mock.Anything, courseId, waitlistedId)
+}
+
+func TestCourseService_UpdateEnrollmentSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *models.EnrollmentSettingsInput
+		want    *db_models.Course
+		wantErr error
+	}{
+		{
+			name:  "[UpdateEnrollmentSettings] Protect the course with a key",
+			input: &models.EnrollmentSettingsInput{Mode: models.EnrollmentModeCode, EnrollmentKey: "SECRET", Capacity: 10},
+			want:  &db_models.Course{ID: courseId, Creator: creatorId, EnrollmentMode: models.EnrollmentModeCode, EnrollmentKey: "SECRET"},
+		},
+		{
+			name:  "[UpdateEnrollmentSettings] Invalid mode",
+			input: &models.EnrollmentSettingsInput{Mode: "invite"},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid enrollment settings"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "mode", Reason: "Mode must be one of open, code, capped or approval"},
+			}),
+		},
+		{
+			name:  "[UpdateEnrollmentSettings] Code mode without a key",
+			input: &models.EnrollmentSettingsInput{Mode: models.EnrollmentModeCode},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid enrollment settings"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "enrollment_key", Reason: "Enrollment key is required for code protected courses"},
+			}),
+		},
+		{
+			name:  "[UpdateEnrollmentSettings] Capped mode without seats",
+			input: &models.EnrollmentSettingsInput{Mode: models.EnrollmentModeCapped},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid enrollment settings"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "capacity", Reason: "Capacity must be greater than 0"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			var updated *db_models.Course
+			repoMock.
+				On("UpdateEnrollmentSettings", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					updated = args.Get(2).(*db_models.Course)
+				}).
+				Return(nil)
+
+			got, err := svc.UpdateEnrollmentSettings(context.TODO(), courseId, tt.input, creatorId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, updated, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Enrollment Settings Updated Succesfully", got.Message, tt.name)
+			assert.Equal(t, tt.want, updated, tt.name)
+		})
+	}
+}
+
+func TestCourseService_ApproveEnrollmentRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  string
+		role    string
+		status  string
+		wantErr error
+	}{
+		{
+			name:    "[ApproveEnrollmentRequest] Teaching assistant approves a request",
+			userId:  creatorId,
+			status:  models.EnrollmentRequestPending,
+			wantErr: nil,
+		},
+		{
+			name:    "[ApproveEnrollmentRequest] Learners are not allowed to approve requests",
+			userId:  userId,
+			status:  models.EnrollmentRequestPending,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+		{
+			name:    "[ApproveEnrollmentRequest] Waitlisted requests can not be approved",
+			userId:  creatorId,
+			status:  models.EnrollmentRequestWaitlisted,
+			wantErr: er.NewError(fmt.Errorf("%s", "The enrollment request is not waiting for approval"), http.StatusBadRequest, nil),
+		},
+	}
+
+	requesterId := uuid.New().String()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId, EnrollmentMode: models.EnrollmentModeApproval}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return(tt.role, nil)
+
+			repoMock.
+				On("GetEnrollmentRequest", mock.Anything, mock.Anything, courseId, requesterId).
+				Return(&db_models.EnrollmentRequest{CourseID: courseId, UserID: requesterId, Action: models.EnrollmentActionEnroll, KeepProgress: true, Status: tt.status}, nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, requesterId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("InsertEnrollment", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			repoMock.
+				On("DeleteEnrollmentRequest", mock.Anything, mock.Anything, courseId, requesterId).
+				Return(nil)
+
+			got, err := svc.ApproveEnrollmentRequest(context.TODO(), courseId, requesterId, tt.userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "InsertEnrollment", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Enrollment Request Approved Succesfully", got.Message, tt.name)
+			repoMock.AssertCalled(t, "InsertEnrollment", mock.Anything, mock.Anything, &models.EnrollInput{
+				UserID:       requesterId,
+				CourseID:     courseId,
+				Action:       models.EnrollmentActionEnroll,
+				KeepProgress: true,
+			})
+			repoMock.AssertCalled(t, "DeleteEnrollmentRequest", mock.Anything, mock.Anything, courseId, requesterId)
+		})
+	}
+}
+
+func TestCourseService_DenyEnrollmentRequest(t *testing.T) {
+	requesterId := uuid.New().String()
+
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, Creator: creatorId, EnrollmentMode: models.EnrollmentModeApproval}, nil)
+
+	repoMock.
+		On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+		Return(models.CourseRoleTeachingAssistant, nil)
+
+	repoMock.
+		On("GetEnrollmentRequest", mock.Anything, mock.Anything, courseId, requesterId).
+		Return(&db_models.EnrollmentRequest{CourseID: courseId, UserID: requesterId, Status: models.EnrollmentRequestPending}, nil)
+
+	repoMock.
+		On("SetEnrollmentRequestStatus", mock.Anything, mock.Anything, courseId, requesterId, models.EnrollmentRequestDenied).
+		Return(nil)
+
+	got, err := svc.DenyEnrollmentRequest(context.TODO(), courseId, requesterId, userId)
+
+	assert.Nil(t, err, "[DenyEnrollmentRequest] Teaching assistant denies a request")
+	assert.Equal(t, "Enrollment Request Denied Succesfully", got.Message, "[DenyEnrollmentRequest] Teaching assistant denies a request")
+	repoMock.AssertCalled(t, "SetEnrollmentRequestStatus", mock.Anything, mock.Anything, courseId, requesterId, models.EnrollmentRequestDenied)
+}
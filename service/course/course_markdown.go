@@ -0,0 +1,199 @@
+package course
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	markdown_html "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+// markdownRenderer lets raw HTML through so authors may keep using the safe
+// subset of it, everything it renders goes through markdownPolicy.
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM, mathExtension),
+	goldmark.WithRendererOptions(markdown_html.WithUnsafe()),
+)
+
+var markdownPolicy = newMarkdownPolicy()
+
+// newMarkdownPolicy allows user generated content along with the classes
+// used to highlight code blocks and to find math for KaTeX on the client.
+func newMarkdownPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w#+-]+$`)).OnElements("code")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^math (inline|display)$`)).OnElements("span")
+
+	return policy
+}
+
+func renderMarkdown(source string) string {
+	var out bytes.Buffer
+
+	err := markdownRenderer.Convert([]byte(source), &out)
+	if err != nil {
+		return sanitizeHTML(html.EscapeString(source))
+	}
+
+	return sanitizeHTML(out.String())
+}
+
+func sanitizeHTML(source string) string {
+	return markdownPolicy.Sanitize(source)
+}
+
+// markdownSource is the markdown of a material: the content of markdown
+// materials and the accompanying text of every other material.
+func markdownSource(material *db.Material) string {
+	if material.Type == models.MaterialTypeMarkdown {
+		return material.Content
+	}
+
+	return material.ContentText
+}
+
+// renderMaterial caches the rendered markdown of the material, it has to be
+// called whenever the content of the material changes.
+func renderMaterial(material *db.Material) {
+	rendered := renderMarkdown(markdownSource(material))
+	material.ContentHTML = &rendered
+}
+
+// materialHTML falls back to rendering the material when nothing has been
+// cached yet, such as for imported courses.
+func materialHTML(material *db.Material) string {
+	if material.ContentHTML != nil {
+		return *material.ContentHTML
+	}
+
+	return renderMarkdown(markdownSource(material))
+}
+
+// mathNode holds TeX written between $ for inline math or $$ for display
+// math. It is rendered as its escaped source, ready for KaTeX.
+type mathNode struct {
+	ast.BaseInline
+	display bool
+}
+
+var kindMath = ast.NewNodeKind("Math")
+
+func (n *mathNode) Kind() ast.NodeKind {
+	return kindMath
+}
+
+func (n *mathNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+type mathParser struct{}
+
+func (p *mathParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse follows the pandoc rules so prices are left alone: inline math can
+// not start or end with a space and the closing $ can not precede a digit.
+// Display math may span several lines of a paragraph.
+func (p *mathParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if len(line) > 1 && line[1] == '$' {
+		return p.parseDisplay(block, segment)
+	}
+
+	for i := 1; i < len(line); i++ {
+		if line[i] != '$' || line[i-1] == '\\' {
+			continue
+		}
+
+		if i == 1 || util.IsSpace(line[1]) || util.IsSpace(line[i-1]) {
+			return nil
+		}
+		if i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9' {
+			return nil
+		}
+
+		node := &mathNode{}
+		node.AppendChild(node, ast.NewRawTextSegment(text.NewSegment(segment.Start+1, segment.Start+i)))
+		block.Advance(i + 1)
+		return node
+	}
+
+	return nil
+}
+
+func (p *mathParser) parseDisplay(block text.Reader, start text.Segment) ast.Node {
+	block.Advance(2)
+	l, pos := block.Position()
+	node := &mathNode{display: true}
+
+	for {
+		line, segment := block.PeekLine()
+		if line == nil {
+			block.SetPosition(l, pos)
+			return ast.NewTextSegment(start.WithStop(start.Start + 2))
+		}
+
+		for i := 0; i+1 < len(line); i++ {
+			if line[i] == '$' && line[i+1] == '$' {
+				if i > 0 {
+					node.AppendChild(node, ast.NewRawTextSegment(segment.WithStop(segment.Start+i)))
+				}
+				block.Advance(i + 2)
+				return node
+			}
+		}
+
+		node.AppendChild(node, ast.NewRawTextSegment(segment))
+		block.AdvanceLine()
+	}
+}
+
+type mathRenderer struct{}
+
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMath, r.renderMath)
+}
+
+func (r *mathRenderer) renderMath(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	class := "math inline"
+	if n.(*mathNode).display {
+		class = "math display"
+	}
+
+	_, _ = w.WriteString(`<span class="` + class + `">`)
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		segment := child.(*ast.Text).Segment
+		_, _ = w.WriteString(html.EscapeString(string(segment.Value(source))))
+	}
+	_, _ = w.WriteString("</span>")
+
+	return ast.WalkSkipChildren, nil
+}
+
+type mathMarkdownExtension struct{}
+
+var mathExtension = &mathMarkdownExtension{}
+
+func (e *mathMarkdownExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(
+		util.Prioritized(&mathParser{}, 500),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&mathRenderer{}, 500),
+	))
+}
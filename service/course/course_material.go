@@ -0,0 +1,242 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/config"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+// materialType validates the content and metadata of a material and builds
+// the typed payload returned to the learners. Threshold is the percentage a
+// learner has to reach for the material to be completed, negative when only
+// a submission completes it.
+type materialType struct {
+	validate  func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct
+	payload   func(material *db.Material, metadata *models.MaterialMetadata) interface{}
+	threshold int
+}
+
+var materialTypes = map[string]*materialType{
+	models.MaterialTypeText: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			return requireContent(content, "Content is required for text materials")
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			return &models.TextPayload{HTML: sanitizeHTML(material.Content)}
+		},
+		threshold: 80,
+	},
+	models.MaterialTypeMarkdown: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			return requireContent(content, "Content is required for markdown materials")
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			return &models.MarkdownPayload{Markdown: material.Content, HTML: materialHTML(material)}
+		},
+		threshold: 80,
+	},
+	models.MaterialTypeVideo: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			errs := requireURL(content, "Content must be the URL of the video")
+			if metadata.Duration < 0 {
+				errs = append(errs, er.ErrorStruct{Field: "materialMetadata.duration", Reason: "Duration of the video can not be negative"})
+			}
+			return errs
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			return &models.VideoPayload{URL: material.Content, Duration: metadata.Duration}
+		},
+		threshold: 90,
+	},
+	models.MaterialTypeAssignment: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			return requireContent(content, "Content must be the ID of the assignment")
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			return &models.AssignmentPayload{AssignmentID: material.ID}
+		},
+		threshold: -1,
+	},
+	models.MaterialTypeFile: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			errs := requireURL(content, "Content must be the URL of the file")
+			if strings.TrimSpace(metadata.FileName) == "" {
+				errs = append(errs, er.ErrorStruct{Field: "materialMetadata.fileName", Reason: "File name is required"})
+			}
+			if metadata.FileSize < 0 {
+				errs = append(errs, er.ErrorStruct{Field: "materialMetadata.fileSize", Reason: "File size can not be negative"})
+			}
+			return errs
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			return &models.FilePayload{URL: material.Content, FileName: metadata.FileName, FileSize: metadata.FileSize}
+		},
+		threshold: 0,
+	},
+	models.MaterialTypeLink: {
+		validate: func(content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+			return requireURL(content, "Content must be an http or https URL")
+		},
+		payload: func(material *db.Material, metadata *models.MaterialMetadata) interface{} {
+			title := metadata.Title
+			if title == "" {
+				title = material.Name
+			}
+			return &models.LinkPayload{URL: material.Content, Title: title}
+		},
+		threshold: 0,
+	},
+}
+
+func requireContent(content string, reason string) []er.ErrorStruct {
+	if strings.TrimSpace(content) == "" {
+		return []er.ErrorStruct{{Field: "materialContent", Reason: reason}}
+	}
+
+	return nil
+}
+
+func requireURL(content string, reason string) []er.ErrorStruct {
+	parsed, err := url.Parse(content)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return []er.ErrorStruct{{Field: "materialContent", Reason: reason}}
+	}
+
+	return nil
+}
+
+func materialTypeNames() string {
+	var names []string
+	for name := range materialTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// validateMaterial checks the content of a material against its type.
+// Assignment materials share their id with the assignment they embed, so the
+// referenced assignment has to exist.
+func (serv *courseService) validateMaterial(ctx context.Context, materialType string, content string, metadata *models.MaterialMetadata) error {
+	errs, err := serv.materialErrors(ctx, "", materialType, content, metadata)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &errs)
+	}
+
+	return nil
+}
+
+// validateCourseMaterials checks every material of a new course, reporting
+// the failures of all of them at once.
+func (serv *courseService) validateCourseMaterials(ctx context.Context, sections []*models.SectionCreation) error {
+	var errs []er.ErrorStruct
+	for sectIdx, sect := range sections {
+		for matIdx, mat := range sect.Subsections {
+			field := fmt.Sprintf("sections[%d].subSections[%d]", sectIdx, matIdx)
+
+			matErrs, err := serv.materialErrors(ctx, field, mat.Type, mat.Content, mat.Metadata)
+			if err != nil {
+				return err
+			}
+			errs = append(errs, matErrs...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &errs)
+	}
+
+	return nil
+}
+
+// materialErrors validates a material and looks up the assignment it embeds.
+func (serv *courseService) materialErrors(ctx context.Context, field string, materialType string, content string, metadata *models.MaterialMetadata) ([]er.ErrorStruct, error) {
+	errs := checkMaterial(field, materialType, content, metadata)
+	if len(errs) == 0 && materialType == models.MaterialTypeAssignment {
+		exists, err := serv.courseRepository.IsAssignmentExists(ctx, serv.db, content)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			errs = append(errs, er.ErrorStruct{Field: materialField(field, "materialContent"), Reason: "Assignment does not exist"})
+		}
+	}
+
+	return errs, nil
+}
+
+// checkMaterial validates a material against the registry without touching
+// the database. The fields are prefixed with field when it is not empty.
+func checkMaterial(field string, materialType string, content string, metadata *models.MaterialMetadata) []er.ErrorStruct {
+	if metadata == nil {
+		metadata = new(models.MaterialMetadata)
+	}
+
+	registered, ok := materialTypes[materialType]
+	if !ok {
+		return []er.ErrorStruct{
+			{Field: materialField(field, "materialType"), Reason: fmt.Sprintf("Material type must be one of %s", materialTypeNames())},
+		}
+	}
+
+	errs := registered.validate(content, metadata)
+	for idx := range errs {
+		errs[idx].Field = materialField(field, errs[idx].Field)
+	}
+
+	return errs
+}
+
+func materialField(prefix string, field string) string {
+	if prefix == "" {
+		return field
+	}
+
+	return prefix + "." + field
+}
+
+// completionThreshold returns the percentage needed to complete a material
+// of the given type. The defaults of the registry can be overridden with
+// PROGRESS_THRESHOLDS, e.g. "video:95,markdown:60", except for the types
+// completed by a submission.
+func completionThreshold(materialType string) int {
+	registered, ok := materialTypes[materialType]
+	if !ok {
+		return 100
+	}
+
+	if registered.threshold < 0 {
+		return registered.threshold
+	}
+
+	if threshold, ok := config.GetConfig().ProgressThresholds[materialType]; ok {
+		return threshold
+	}
+
+	return registered.threshold
+}
+
+// materialPayload returns nil for materials whose type is not registered,
+// such as the ones written before the registry existed.
+func materialPayload(material *db.Material) interface{} {
+	registered, ok := materialTypes[material.Type]
+	if !ok {
+		return nil
+	}
+
+	return registered.payload(material, models.DecodeMaterialMetadata(material.Metadata))
+}
@@ -0,0 +1,326 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_CreateTypedCourseMaterial(t *testing.T) {
+	assignmentId := uuid.New().String()
+	usedAssignmentId := uuid.New().String()
+	metadata := `{"fileName":"slides.pdf","fileSize":2048}`
+	rendered := ""
+
+	invalid := func(errs ...er.ErrorStruct) error {
+		return er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &errs)
+	}
+
+	tests := []struct {
+		name    string
+		input   *models.CourseMaterialInput
+		want    *db_models.Material
+		wantErr error
+	}{
+		{
+			name:  "[CreateCourseMaterial] Unknown material type",
+			input: &models.CourseMaterialInput{Name: "Lesson", Type: "slides", SectionID: syllabusId},
+			wantErr: invalid(er.ErrorStruct{
+				Field:  "materialType",
+				Reason: "Material type must be one of assignment, file, link, markdown, text, video",
+			}),
+		},
+		{
+			name:    "[CreateCourseMaterial] Sections are not materials",
+			input:   &models.CourseMaterialInput{Name: "Lesson", Type: "section", SectionID: syllabusId},
+			wantErr: invalid(er.ErrorStruct{Field: "materialType", Reason: "Material type must be one of assignment, file, link, markdown, text, video"}),
+		},
+		{
+			name:  "[CreateCourseMaterial] Video needs a URL and a duration which is not negative",
+			input: &models.CourseMaterialInput{Name: "Lecture", Type: "video", Content: "lecture.mp4", Metadata: &models.MaterialMetadata{Duration: -1}, SectionID: syllabusId},
+			wantErr: invalid(
+				er.ErrorStruct{Field: "materialContent", Reason: "Content must be the URL of the video"},
+				er.ErrorStruct{Field: "materialMetadata.duration", Reason: "Duration of the video can not be negative"},
+			),
+		},
+		{
+			name:    "[CreateCourseMaterial] File needs a name",
+			input:   &models.CourseMaterialInput{Name: "Slides", Type: "file", Content: "https://example.com/static/slides.pdf", SectionID: syllabusId},
+			wantErr: invalid(er.ErrorStruct{Field: "materialMetadata.fileName", Reason: "File name is required"}),
+		},
+		{
+			name: "[CreateCourseMaterial] File with its metadata",
+			input: &models.CourseMaterialInput{
+				Name:      "Slides",
+				Type:      "file",
+				Content:   "https://example.com/static/slides.pdf",
+				Metadata:  &models.MaterialMetadata{FileName: "slides.pdf", FileSize: 2048},
+				SectionID: syllabusId,
+			},
+			want: &db_models.Material{
+				CourseID:    courseId,
+				Name:        "Slides",
+				Type:        "file",
+				SectionID:   syllabusId,
+				Content:     "https://example.com/static/slides.pdf",
+				Metadata:    &metadata,
+				ContentHTML: &rendered,
+			},
+		},
+		{
+			name:    "[CreateCourseMaterial] Link must be a web URL",
+			input:   &models.CourseMaterialInput{Name: "Docs", Type: "link", Content: "javascript:alert(1)", SectionID: syllabusId},
+			wantErr: invalid(er.ErrorStruct{Field: "materialContent", Reason: "Content must be an http or https URL"}),
+		},
+		{
+			name:    "[CreateCourseMaterial] Assignment does not exist",
+			input:   &models.CourseMaterialInput{Name: "Quiz", Type: "assignment", Content: uuid.New().String(), SectionID: syllabusId},
+			wantErr: invalid(er.ErrorStruct{Field: "materialContent", Reason: "Assignment does not exist"}),
+		},
+		{
+			name:  "[CreateCourseMaterial] Assignment material takes the id of the assignment",
+			input: &models.CourseMaterialInput{Name: "Quiz", Type: "assignment", Content: assignmentId, SectionID: syllabusId},
+			want: &db_models.Material{
+				ID:          assignmentId,
+				CourseID:    courseId,
+				Name:        "Quiz",
+				Type:        "assignment",
+				SectionID:   syllabusId,
+				Content:     assignmentId,
+				ContentHTML: &rendered,
+			},
+		},
+		{
+			name:    "[CreateCourseMaterial] Assignment is already part of a course",
+			input:   &models.CourseMaterialInput{Name: "Quiz", Type: "assignment", Content: usedAssignmentId, SectionID: syllabusId},
+			wantErr: er.NewError(fmt.Errorf("%s", "The assignment is already part of a course"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseIDByMaterialID", mock.Anything, mock.Anything, syllabusId).
+				Return(courseId, nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, syllabusId).
+				Return(&db_models.Material{ID: syllabusId, CourseID: courseId, Type: "section"}, nil)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, usedAssignmentId).
+				Return(&db_models.Material{ID: usedAssignmentId, CourseID: courseId2, Type: "assignment"}, nil)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, er.NewError(fmt.Errorf("%s", "Material not found"), http.StatusNotFound, nil))
+
+			repoMock.
+				On("IsAssignmentExists", mock.Anything, mock.Anything, assignmentId).
+				Return(true, nil)
+
+			repoMock.
+				On("IsAssignmentExists", mock.Anything, mock.Anything, usedAssignmentId).
+				Return(true, nil)
+
+			repoMock.
+				On("IsAssignmentExists", mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+
+			repoMock.
+				On("GetNextMaterialPosition", mock.Anything, mock.Anything, courseId, syllabusId).
+				Return(0, nil)
+
+			var inserted *db_models.Material
+			repoMock.
+				On("InsertCourseMaterial", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.Material)
+				}).
+				Return(nil)
+
+			got, err := svc.CreateCourseMaterial(context.TODO(), tt.input, creatorId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			if tt.want.ID == "" {
+				tt.want.ID = inserted.ID
+			}
+			assert.Equal(t, tt.want, inserted, tt.name)
+			assert.Equal(t, inserted.ID, got.Id, tt.name)
+		})
+	}
+}
+
+func TestCourseService_UpdateAssignmentMaterial(t *testing.T) {
+	otherAssignmentId := uuid.New().String()
+
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+
+	repoMock.
+		On("GetMaterialByID", mock.Anything, mock.Anything, materialId).
+		Return(&db_models.Material{ID: materialId, CourseID: courseId, Type: "assignment", SectionID: syllabusId, Content: materialId}, nil)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+	got, err := svc.UpdateCourseMaterial(context.TODO(), materialId, &models.CourseMaterialUpdate{Content: &otherAssignmentId}, creatorId)
+
+	assert.Nil(t, got, "[UpdateCourseMaterial] Assignment of a material can not be changed")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "The assignment of a material can not be changed"), http.StatusBadRequest, nil), err, "[UpdateCourseMaterial] Assignment of a material can not be changed")
+	repoMock.AssertNotCalled(t, "UpdateCourseMaterial", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCourseService_RenderMaterialMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "[CreateCourseMaterial] Render markdown",
+			content: "# Sorting\n\nUse *merge sort*.",
+			want:    "<h1>Sorting</h1>\n<p>Use <em>merge sort</em>.</p>\n",
+		},
+		{
+			name:    "[CreateCourseMaterial] Remove scripts and event handlers",
+			content: "<script>alert(1)</script>\n\nHello <b onclick=\"steal()\">world</b> [link](javascript:alert(1))",
+			want:    "\n<p>Hello <b>world</b> link</p>\n",
+		},
+		{
+			name:    "[CreateCourseMaterial] Keep the language of code blocks",
+			content: "```go\nfmt.Println(i)\n```",
+			want:    "<pre><code class=\"language-go\">fmt.Println(i)\n</code></pre>\n",
+		},
+		{
+			name:    "[CreateCourseMaterial] Mark math for KaTeX",
+			content: "Euler $e^{i\\pi} + 1 = 0$\n$$\n\\sum_{i=1}^n i < n^2\n$$",
+			want:    "<p>Euler <span class=\"math inline\">e^{i\\pi} + 1 = 0</span>\n<span class=\"math display\">\n\\sum_{i=1}^n i &lt; n^2\n</span></p>\n",
+		},
+		{
+			name:    "[CreateCourseMaterial] Prices are not math",
+			content: "It costs $5 and $10",
+			want:    "<p>It costs $5 and $10</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseIDByMaterialID", mock.Anything, mock.Anything, syllabusId).
+				Return(courseId, nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, syllabusId).
+				Return(&db_models.Material{ID: syllabusId, CourseID: courseId, Type: "section"}, nil)
+
+			repoMock.
+				On("GetNextMaterialPosition", mock.Anything, mock.Anything, courseId, syllabusId).
+				Return(0, nil)
+
+			var inserted *db_models.Material
+			repoMock.
+				On("InsertCourseMaterial", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.Material)
+				}).
+				Return(nil)
+
+			_, err := svc.CreateCourseMaterial(context.TODO(), &models.CourseMaterialInput{
+				Name:      "Lesson",
+				Type:      "markdown",
+				Content:   tt.content,
+				SectionID: syllabusId,
+			}, creatorId)
+
+			assert.Nil(t, err, tt.name)
+			assert.Equal(t, tt.want, *inserted.ContentHTML, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetRenderedCourseMaterial(t *testing.T) {
+	cached := "<p>Cached</p>\n"
+
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	repoMock := new(mocks.CourseRepository)
+	svc := course.NewService(sqlxDB)
+	svc.InjectCourseRepository(repoMock)
+
+	repoMock.
+		On("GetCourseById", mock.Anything, mock.Anything, courseId).
+		Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+	repoMock.
+		On("GetCourseMaterialByCourseIDAndSectionID", mock.Anything, mock.Anything, courseId, syllabusId).
+		Return([]*db_models.Material{
+			{ID: materialId, CourseID: courseId, Type: "markdown", SectionID: syllabusId, Content: "Cached", ContentHTML: &cached},
+			{ID: syllabusId2, CourseID: courseId, Type: "text", SectionID: syllabusId, Content: `<p onmouseover="steal()">Imported</p>`, ContentText: "**Imported**"},
+		}, nil)
+
+	got, err := svc.GetCourseMaterial(context.TODO(), courseId, syllabusId, creatorId, false)
+
+	assert.Nil(t, err, "[GetCourseMaterial] Return raw and rendered content")
+	assert.Equal(t, &models.SectionContentResponse{
+		ID: syllabusId,
+		Subsections: []*models.MaterialContent{
+			{
+				ID:          materialId,
+				Type:        "markdown",
+				Content:     "Cached",
+				ContentHTML: cached,
+				Payload:     &models.MarkdownPayload{Markdown: "Cached", HTML: cached},
+			},
+			{
+				ID:          syllabusId2,
+				Type:        "text",
+				Content:     `<p onmouseover="steal()">Imported</p>`,
+				ContentText: "**Imported**",
+				ContentHTML: "<p><strong>Imported</strong></p>\n",
+				Payload:     &models.TextPayload{HTML: "<p>Imported</p>"},
+			},
+		},
+	}, got, "[GetCourseMaterial] Return raw and rendered content")
+}
@@ -0,0 +1,535 @@
+package course
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+)
+
+const (
+	packageManifest = "imsmanifest.xml"
+	// ccFileBase is how Common Cartridge pages refer to the files shipped in
+	// web_resources.
+	ccFileBase = "$IMS-CC-FILEBASE$"
+)
+
+var (
+	packageLinkPattern   = regexp.MustCompile(`(?i)(src|href)\s*=\s*("[^"]*"|'[^']*')`)
+	packageBodyPattern   = regexp.MustCompile(`(?is)<body[^>]*>(.*)</body>`)
+	packageScriptPattern = regexp.MustCompile(`(?is)<script[^>]*>.*?</script>|<style[^>]*>.*?</style>`)
+	packageTagPattern    = regexp.MustCompile(`<[^>]*>`)
+
+	packageVideoExtensions = map[string]bool{".mp4": true, ".webm": true, ".ogg": true, ".ogv": true}
+	packageVideoHosts      = []string{"youtube.com", "youtu.be", "vimeo.com"}
+
+	// packageAssetTypes are the files of a package which are stored besides
+	// images, keyed by extension with the content type they must sniff as.
+	// Anything else could be served as a page from static/image.
+	packageAssetTypes = map[string]string{
+		".mp4":  "video/mp4",
+		".webm": "video/webm",
+		".ogg":  "application/ogg",
+		".ogv":  "application/ogg",
+		".pdf":  "application/pdf",
+	}
+)
+
+type imsManifest struct {
+	Metadata struct {
+		Schema      string `xml:"schema"`
+		Title       string `xml:"lom>general>title>string"`
+		LangTitle   string `xml:"lom>general>title>langstring"`
+		Description string `xml:"lom>general>description>string"`
+	} `xml:"metadata"`
+	Organizations struct {
+		Default      string             `xml:"default,attr"`
+		Organization []*imsOrganization `xml:"organization"`
+	} `xml:"organizations"`
+	Resources []*imsResource `xml:"resources>resource"`
+}
+
+type imsOrganization struct {
+	Identifier string     `xml:"identifier,attr"`
+	Title      string     `xml:"title"`
+	Items      []*imsItem `xml:"item"`
+}
+
+type imsItem struct {
+	Identifier    string     `xml:"identifier,attr"`
+	IdentifierRef string     `xml:"identifierref,attr"`
+	Title         string     `xml:"title"`
+	Items         []*imsItem `xml:"item"`
+}
+
+type imsResource struct {
+	Identifier string `xml:"identifier,attr"`
+	Type       string `xml:"type,attr"`
+	Href       string `xml:"href,attr"`
+	Files      []struct {
+		Href string `xml:"href,attr"`
+	} `xml:"file"`
+}
+
+type imsWebLink struct {
+	Title string `xml:"title"`
+	URL   struct {
+		Href string `xml:"href,attr"`
+	} `xml:"url"`
+}
+
+// contentPackage maps an IMS content package onto models.CourseCreation.
+// Assets get their url the first time they are referenced and are kept in
+// staged until the course is created.
+type contentPackage struct {
+	manifest    *imsManifest
+	files       map[string]*zip.File
+	resources   map[string]*imsResource
+	assets      map[string]string
+	staged      map[string][]byte
+	baseURL     string
+	unsupported []*models.UnsupportedPackageItem
+	// size is how much has been unpacked so far, bounded by maxArchiveSize.
+	size int
+}
+
+// ImportContentPackage creates a draft course owned by userId from an IMS
+// Common Cartridge or SCORM 1.2 zip. The top level items of the organization
+// become sections and the items below them, flattened, become materials.
+// Items which cannot be mapped are reported back instead of failing the import.
+func (serv *courseService) ImportContentPackage(ctx context.Context, data []byte, userId string, baseURL string) (*models.ContentPackageImportResponse, error) {
+	pkg, err := openContentPackage(data, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := pkg.courseCreation()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(course.Sections) == 0 {
+		errs := []er.ErrorStruct{}
+		for _, item := range pkg.unsupported {
+			errs = append(errs, er.ErrorStruct{Field: item.Identifier, Reason: item.Reason})
+		}
+		return nil, er.NewError(fmt.Errorf("%s", "The package has no supported content"), http.StatusBadRequest, &errs)
+	}
+
+	course.Course.Creator = userId
+
+	_, err = serv.CreateNewCourse(ctx, course)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, content := range pkg.staged {
+		err = ioutil.WriteFile(imageDirectory+name, content, 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &models.ContentPackageImportResponse{
+		Status:      "Success",
+		Message:     "Course Imported Succesfully",
+		Id:          course.Course.ID,
+		Format:      pkg.format(),
+		Unsupported: pkg.unsupported,
+	}
+
+	return resp, nil
+}
+
+func openContentPackage(data []byte, baseURL string) (*contentPackage, error) {
+	invalid := er.NewError(fmt.Errorf("%s", "Invalid content package"), http.StatusBadRequest, nil)
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, invalid
+	}
+
+	pkg := &contentPackage{
+		files:       map[string]*zip.File{},
+		resources:   map[string]*imsResource{},
+		assets:      map[string]string{},
+		staged:      map[string][]byte{},
+		baseURL:     baseURL,
+		unsupported: []*models.UnsupportedPackageItem{},
+	}
+
+	for _, file := range reader.File {
+		pkg.files[path.Clean(file.Name)] = file
+	}
+
+	content, err := pkg.read(packageManifest)
+	if err == errArchiveTooLarge {
+		return nil, err
+	}
+	if err != nil {
+		return nil, invalid
+	}
+
+	pkg.manifest = new(imsManifest)
+	err = xml.Unmarshal(content, pkg.manifest)
+	if err != nil {
+		return nil, invalid
+	}
+
+	for _, resource := range pkg.manifest.Resources {
+		pkg.resources[resource.Identifier] = resource
+	}
+
+	return pkg, nil
+}
+
+func (pkg *contentPackage) format() string {
+	if strings.Contains(pkg.manifest.Metadata.Schema, "Common Cartridge") {
+		return "imscc"
+	}
+	return "scorm"
+}
+
+func (pkg *contentPackage) courseCreation() (*models.CourseCreation, error) {
+	organizations := pkg.manifest.Organizations.Organization
+	if len(organizations) == 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "The package has no organization"), http.StatusBadRequest, nil)
+	}
+
+	organization := organizations[0]
+	for _, org := range organizations {
+		if org.Identifier == pkg.manifest.Organizations.Default {
+			organization = org
+		}
+	}
+
+	metadata := pkg.manifest.Metadata
+	course := &models.CourseCreation{
+		Course: models.Course{
+			CourseName:  firstNonEmpty(metadata.Title, metadata.LangTitle, organization.Title, "Imported Course"),
+			Description: strings.TrimSpace(metadata.Description),
+		},
+		Sections: []*models.SectionCreation{},
+	}
+
+	// Common Cartridge wraps the whole tree in a single untitled root item.
+	items := organization.Items
+	if len(items) == 1 && items[0].IdentifierRef == "" && strings.TrimSpace(items[0].Title) == "" {
+		items = items[0].Items
+	}
+
+	for _, item := range items {
+		section := &models.SectionCreation{
+			Name:        pkg.itemTitle(item),
+			Subsections: []*models.MaterialCreation{},
+		}
+
+		err := pkg.collectMaterials(section, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(section.Subsections) > 0 {
+			course.Sections = append(course.Sections, section)
+		}
+	}
+
+	return course, nil
+}
+
+func (pkg *contentPackage) collectMaterials(section *models.SectionCreation, item *imsItem) error {
+	if item.IdentifierRef != "" {
+		material, err := pkg.material(item)
+		if err != nil {
+			return err
+		}
+
+		if material != nil {
+			section.Subsections = append(section.Subsections, material)
+		}
+	}
+
+	for _, child := range item.Items {
+		err := pkg.collectMaterials(section, child)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// material maps an item to a material, or reports it as unsupported and
+// returns nil.
+func (pkg *contentPackage) material(item *imsItem) (*models.MaterialCreation, error) {
+	title := pkg.itemTitle(item)
+
+	resource, ok := pkg.resources[item.IdentifierRef]
+	if !ok {
+		pkg.reportUnsupported(item, "", "Resource not found in the package")
+		return nil, nil
+	}
+
+	href := resource.Href
+	if href == "" && len(resource.Files) > 0 {
+		href = resource.Files[0].Href
+	}
+	href = path.Clean(href)
+
+	if _, ok := pkg.files[href]; !ok {
+		pkg.reportUnsupported(item, resource.Type, "Resource file not found in the package")
+		return nil, nil
+	}
+
+	switch {
+	case strings.HasPrefix(resource.Type, "imswl_"):
+		return pkg.webLinkMaterial(item, resource, href, title)
+	case resource.Type != "webcontent":
+		pkg.reportUnsupported(item, resource.Type, "Unsupported resource type")
+		return nil, nil
+	}
+
+	ext := strings.ToLower(path.Ext(href))
+	if ext == ".html" || ext == ".htm" {
+		content, err := pkg.read(href)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := pkg.rewriteLinks(string(content), path.Dir(href))
+		if err != nil {
+			return nil, err
+		}
+
+		if match := packageBodyPattern.FindStringSubmatch(page); match != nil {
+			page = match[1]
+		}
+		page = strings.TrimSpace(sanitizeHTML(page))
+
+		return &models.MaterialCreation{
+			Name:        title,
+			Type:        "text",
+			Content:     page,
+			ContentText: htmlToText(page),
+		}, nil
+	}
+
+	assetURL, ok, err := pkg.storeAsset(href)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		pkg.reportUnsupported(item, resource.Type, "Unsupported file type")
+		return nil, nil
+	}
+
+	if packageVideoExtensions[ext] {
+		return &models.MaterialCreation{
+			Name:        title,
+			Type:        "video",
+			Content:     assetURL,
+			ContentText: title,
+		}, nil
+	}
+
+	return &models.MaterialCreation{
+		Name:        title,
+		Type:        "text",
+		Content:     fmt.Sprintf(`<a href="%s">%s</a>`, assetURL, html.EscapeString(title)),
+		ContentText: title,
+	}, nil
+}
+
+func (pkg *contentPackage) webLinkMaterial(item *imsItem, resource *imsResource, href string, title string) (*models.MaterialCreation, error) {
+	content, err := pkg.read(href)
+	if err != nil {
+		return nil, err
+	}
+
+	link := new(imsWebLink)
+	err = xml.Unmarshal(content, link)
+	if err != nil || len(requireURL(link.URL.Href, "")) > 0 {
+		pkg.reportUnsupported(item, resource.Type, "Invalid web link")
+		return nil, nil
+	}
+
+	for _, host := range packageVideoHosts {
+		if strings.Contains(link.URL.Href, host) {
+			return &models.MaterialCreation{
+				Name:        title,
+				Type:        "video",
+				Content:     link.URL.Href,
+				ContentText: title,
+			}, nil
+		}
+	}
+
+	return &models.MaterialCreation{
+		Name:        title,
+		Type:        "text",
+		Content:     fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link.URL.Href), html.EscapeString(firstNonEmpty(link.Title, title))),
+		ContentText: firstNonEmpty(link.Title, title),
+	}, nil
+}
+
+// rewriteLinks points src and href attributes which refer to files of the
+// package to their stored copies. Links to other pages are left untouched.
+func (pkg *contentPackage) rewriteLinks(page string, dir string) (string, error) {
+	var err error
+
+	page = packageLinkPattern.ReplaceAllStringFunc(page, func(attr string) string {
+		if err != nil {
+			return attr
+		}
+
+		match := packageLinkPattern.FindStringSubmatch(attr)
+		quote := match[2][:1]
+		ref := match[2][1 : len(match[2])-1]
+
+		file, ok := pkg.resolve(ref, dir)
+		if !ok {
+			return attr
+		}
+
+		ext := strings.ToLower(path.Ext(file))
+		if ext == ".html" || ext == ".htm" {
+			return attr
+		}
+
+		assetURL, ok, storeErr := pkg.storeAsset(file)
+		if storeErr != nil || !ok {
+			err = storeErr
+			return attr
+		}
+
+		return match[1] + "=" + quote + assetURL + quote
+	})
+
+	return page, err
+}
+
+func (pkg *contentPackage) resolve(ref string, dir string) (string, bool) {
+	if idx := strings.IndexAny(ref, "?#"); idx >= 0 {
+		ref = ref[:idx]
+	}
+
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+
+	if ref == "" || (strings.Contains(ref, ":") && !strings.HasPrefix(ref, ccFileBase)) {
+		return "", false
+	}
+
+	candidates := []string{path.Join(dir, ref)}
+	if strings.HasPrefix(ref, ccFileBase) {
+		ref = strings.TrimPrefix(strings.TrimPrefix(ref, ccFileBase), "/")
+		candidates = []string{path.Join("web_resources", ref), path.Join(dir, ref), path.Clean(ref)}
+	}
+
+	for _, candidate := range candidates {
+		if _, ok := pkg.files[candidate]; ok {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// storeAsset stages an image, video or pdf of the package and returns the url
+// it will be served from. It returns false for any other file.
+func (pkg *contentPackage) storeAsset(name string) (string, bool, error) {
+	if assetURL, ok := pkg.assets[name]; ok {
+		return assetURL, true, nil
+	}
+
+	content, err := pkg.read(name)
+	if err != nil {
+		return "", false, err
+	}
+
+	ext, ok := imageExtension(name, content)
+	if !ok {
+		ext = strings.ToLower(path.Ext(name))
+		contentType, known := packageAssetTypes[ext]
+		if !known || http.DetectContentType(content) != contentType {
+			return "", false, nil
+		}
+	}
+
+	stagedName := "course-" + uuid.New().String() + ext
+	pkg.staged[stagedName] = content
+
+	assetURL := pkg.baseURL + imageDirectory + stagedName
+	pkg.assets[name] = assetURL
+	return assetURL, true, nil
+}
+
+func (pkg *contentPackage) read(name string) ([]byte, error) {
+	file, ok := pkg.files[name]
+	if !ok {
+		return nil, er.NewError(fmt.Errorf("%s", "File not found in the package"), http.StatusBadRequest, nil)
+	}
+
+	content, err := readArchiveFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg.size += len(content)
+	if pkg.size > maxArchiveSize {
+		return nil, errArchiveTooLarge
+	}
+
+	return content, nil
+}
+
+func (pkg *contentPackage) itemTitle(item *imsItem) string {
+	title := strings.TrimSpace(item.Title)
+	if title != "" {
+		return title
+	}
+
+	if resource, ok := pkg.resources[item.IdentifierRef]; ok && resource.Href != "" {
+		return path.Base(resource.Href)
+	}
+
+	return item.Identifier
+}
+
+func (pkg *contentPackage) reportUnsupported(item *imsItem, resourceType string, reason string) {
+	pkg.unsupported = append(pkg.unsupported, &models.UnsupportedPackageItem{
+		Identifier: item.Identifier,
+		Title:      pkg.itemTitle(item),
+		Type:       resourceType,
+		Reason:     reason,
+	})
+}
+
+func htmlToText(page string) string {
+	page = packageScriptPattern.ReplaceAllString(page, " ")
+	page = packageTagPattern.ReplaceAllString(page, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(page)), " ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,320 @@
+package course_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+const commonCartridgeManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest identifier="CC1" xmlns="http://www.imsglobal.org/xsd/imsccv1p1/imscp_v1p1" xmlns:lomimscc="http://ltsc.ieee.org/xsd/imsccv1p1/LOM/manifest">
+  <metadata>
+    <schema>IMS Common Cartridge</schema>
+    <schemaversion>1.1.0</schemaversion>
+    <lomimscc:lom>
+      <lomimscc:general>
+        <lomimscc:title><lomimscc:string>Web Programming</lomimscc:string></lomimscc:title>
+        <lomimscc:description><lomimscc:string>Build websites from scratch</lomimscc:string></lomimscc:description>
+      </lomimscc:general>
+    </lomimscc:lom>
+  </metadata>
+  <organizations>
+    <organization identifier="ORG" structure="rooted-hierarchy">
+      <item identifier="LearningModules">
+        <item identifier="M1">
+          <title>Week 1</title>
+          <item identifier="I1" identifierref="R1"><title>Introduction</title></item>
+          <item identifier="I2" identifierref="R2"><title>Lecture Video</title></item>
+          <item identifier="I3" identifierref="R3"><title>Quiz 1</title></item>
+        </item>
+        <item identifier="M2">
+          <title>Week 2</title>
+          <item identifier="F1">
+            <title>Readings</title>
+            <item identifier="I4" identifierref="R4"><title>Reference</title></item>
+          </item>
+        </item>
+        <item identifier="M3">
+          <title>Week 3</title>
+          <item identifier="I5" identifierref="R5"><title>Discussion</title></item>
+        </item>
+      </item>
+    </organization>
+  </organizations>
+  <resources>
+    <resource identifier="R1" type="webcontent" href="wiki_content/intro.html">
+      <file href="wiki_content/intro.html"/>
+    </resource>
+    <resource identifier="R2" type="webcontent" href="web_resources/lecture.mp4">
+      <file href="web_resources/lecture.mp4"/>
+    </resource>
+    <resource identifier="R3" type="imsqti_xmlv1p2/imscc_xmlv1p1/assessment">
+      <file href="quiz.xml"/>
+    </resource>
+    <resource identifier="R4" type="imswl_xmlv1p1">
+      <file href="weblink.xml"/>
+    </resource>
+    <resource identifier="R5" type="imsdt_xmlv1p1">
+      <file href="topic.xml"/>
+    </resource>
+  </resources>
+</manifest>`
+
+const scormManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest identifier="SCORM1" xmlns="http://www.imsproject.org/xsd/imscp_rootv1p1p2" xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_rootv1p2">
+  <metadata>
+    <schema>ADL SCORM</schema>
+    <schemaversion>1.2</schemaversion>
+  </metadata>
+  <organizations default="ORG">
+    <organization identifier="ORG">
+      <title>Golang Basics</title>
+      <item identifier="I1" identifierref="SCO1"><title>Lesson 1</title></item>
+      <item identifier="I2" identifierref="SCO2"><title>Lesson 2</title></item>
+    </organization>
+  </organizations>
+  <resources>
+    <resource identifier="SCO1" type="webcontent" adlcp:scormtype="sco" href="lesson1/index.html">
+      <file href="lesson1/index.html"/>
+      <file href="lesson1/slides.pdf"/>
+    </resource>
+  </resources>
+</manifest>`
+
+func buildContentPackage(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte(content))
+	}
+
+	err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCourseService_ImportContentPackage(t *testing.T) {
+	storedAsset := `http://new-host/static/image/course-[0-9a-f-]+`
+
+	// Assets start with the signature of their format so they sniff as the
+	// content type their extension claims.
+	diagram := "\x89PNG\r\n\x1a\ndiagram"
+	lecture := "\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"
+	slides := "%PDF-1.4 slides"
+
+	tests := []struct {
+		name            string
+		data            []byte
+		wantFormat      string
+		wantCourse      *models.CourseCreation
+		wantContent     map[string]string
+		wantUnsupported []*models.UnsupportedPackageItem
+		insertErr       error
+		wantErr         error
+	}{
+		{
+			name: "[ImportContentPackage] Success to import common cartridge",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml":                  commonCartridgeManifest,
+				"wiki_content/intro.html":          `<html><head><style>h1 { color: red; }</style></head><body><h1 onclick="steal()">Welcome</h1><script>steal()</script><p>See the <a href="other.html">syllabus</a> or <a href="javascript:steal()">this</a>.</p><img src="$IMS-CC-FILEBASE$/images/diagram.png"><img src="$IMS-CC-FILEBASE$/images/logo.svg"></body></html>`,
+				"web_resources/images/diagram.png": diagram,
+				"web_resources/images/logo.svg":    `<svg xmlns="http://www.w3.org/2000/svg" onload="steal()"/>`,
+				"web_resources/lecture.mp4":        lecture,
+				"quiz.xml":                         "<questestinterop/>",
+				"weblink.xml":                      `<webLink><title>MDN Web Docs</title><url href="https://developer.mozilla.org"/></webLink>`,
+				"topic.xml":                        "<topic/>",
+			}),
+			wantFormat: "imscc",
+			wantCourse: &models.CourseCreation{
+				Course: models.Course{
+					CourseName:  "Web Programming",
+					Description: "Build websites from scratch",
+				},
+				Sections: []*models.SectionCreation{
+					{
+						Name: "Week 1",
+						Subsections: []*models.MaterialCreation{
+							{Name: "Introduction", Type: "text", ContentText: "Welcome See the syllabus or this."},
+							{Name: "Lecture Video", Type: "video", ContentText: "Lecture Video"},
+						},
+					},
+					{
+						Name: "Week 2",
+						Subsections: []*models.MaterialCreation{
+							{Name: "Reference", Type: "text", Content: `<a href="https://developer.mozilla.org">MDN Web Docs</a>`, ContentText: "MDN Web Docs"},
+						},
+					},
+				},
+			},
+			wantContent: map[string]string{
+				"Introduction":  `^<h1>Welcome</h1><p>See the <a href="other.html" rel="nofollow">syllabus</a> or this.</p><img src="` + storedAsset + `\.png"><img src="\$IMS-CC-FILEBASE\$/images/logo\.svg">$`,
+				"Lecture Video": `^` + storedAsset + `\.mp4$`,
+			},
+			wantUnsupported: []*models.UnsupportedPackageItem{
+				{Identifier: "I3", Title: "Quiz 1", Type: "imsqti_xmlv1p2/imscc_xmlv1p1/assessment", Reason: "Unsupported resource type"},
+				{Identifier: "I5", Title: "Discussion", Type: "imsdt_xmlv1p1", Reason: "Unsupported resource type"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ImportContentPackage] Success to import scorm package",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml":    scormManifest,
+				"lesson1/index.html": `<p>Download the <a href='slides.pdf?v=1'>slides</a></p>`,
+				"lesson1/slides.pdf": slides,
+			}),
+			wantFormat: "scorm",
+			wantCourse: &models.CourseCreation{
+				Course: models.Course{
+					CourseName: "Golang Basics",
+				},
+				Sections: []*models.SectionCreation{
+					{
+						Name: "Lesson 1",
+						Subsections: []*models.MaterialCreation{
+							{Name: "Lesson 1", Type: "text", ContentText: "Download the slides"},
+						},
+					},
+				},
+			},
+			wantContent: map[string]string{
+				"Lesson 1": `^<p>Download the <a href="` + storedAsset + `\.pdf" rel="nofollow">slides</a></p>$`,
+			},
+			wantUnsupported: []*models.UnsupportedPackageItem{
+				{Identifier: "I2", Title: "Lesson 2", Type: "", Reason: "Resource not found in the package"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ImportContentPackage] Assets are not stored when the course is not created",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml":    scormManifest,
+				"lesson1/index.html": `<p>Download the <a href='slides.pdf?v=1'>slides</a></p>`,
+				"lesson1/slides.pdf": slides,
+			}),
+			insertErr: errors.New("insert failed"),
+			wantErr:   errors.New("insert failed"),
+		},
+		{
+			name: "[ImportContentPackage] Assets are not stored when a material is invalid",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml":    scormManifest,
+				"lesson1/index.html": `<script src="slides.pdf"></script>`,
+				"lesson1/slides.pdf": slides,
+			}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "sections[0].subSections[0].materialContent", Reason: "Content is required for text materials"},
+			}),
+		},
+		{
+			name:    "[ImportContentPackage] Not a content package",
+			data:    buildContentPackage(t, map[string]string{"index.html": "<p>Hello</p>"}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid content package"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ImportContentPackage] Package is too large",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml":    scormManifest,
+				"lesson1/index.html": "<p>" + strings.Repeat(" ", 16<<20) + "</p>",
+			}),
+			wantErr: er.NewError(fmt.Errorf("%s", "Archive is too large"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ImportContentPackage] No supported content",
+			data: buildContentPackage(t, map[string]string{
+				"imsmanifest.xml": scormManifest,
+			}),
+			wantErr: er.NewError(fmt.Errorf("%s", "The package has no supported content"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "I1", Reason: "Resource file not found in the package"},
+				{Field: "I2", Reason: "Resource not found in the package"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useImageDirectory(t)
+
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			var created *models.CourseCreation
+			repoMock.
+				On("InsertCourse", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					created = args.Get(2).(*models.CourseCreation)
+				}).
+				Return(tt.insertErr)
+
+			got, err := svc.ImportContentPackage(context.TODO(), tt.data, userId, "http://new-host/")
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+
+				stored, _ := ioutil.ReadDir(dir + "/static/image")
+				assert.Empty(t, stored, tt.name)
+				return
+			}
+
+			assert.Equal(t, created.Course.ID, got.Id, tt.name)
+			assert.Equal(t, tt.wantFormat, got.Format, tt.name)
+			assert.Equal(t, tt.wantUnsupported, got.Unsupported, tt.name)
+			assert.Equal(t, userId, created.Course.Creator, tt.name)
+			assert.Equal(t, models.CourseStatusDraft, created.Course.Status, tt.name)
+			assert.Equal(t, tt.wantCourse.Course.CourseName, created.Course.CourseName, tt.name)
+			assert.Equal(t, tt.wantCourse.Course.Description, created.Course.Description, tt.name)
+
+			assert.Len(t, created.Sections, len(tt.wantCourse.Sections), tt.name)
+			for idx, section := range created.Sections {
+				assert.Equal(t, tt.wantCourse.Sections[idx].Name, section.Name, tt.name)
+				assert.Len(t, section.Subsections, len(tt.wantCourse.Sections[idx].Subsections), tt.name)
+
+				for matIdx, material := range section.Subsections {
+					want := tt.wantCourse.Sections[idx].Subsections[matIdx]
+					assert.Equal(t, want.Name, material.Name, tt.name)
+					assert.Equal(t, want.Type, material.Type, tt.name)
+					assert.Equal(t, want.ContentText, material.ContentText, tt.name)
+
+					if pattern, ok := tt.wantContent[material.Name]; ok {
+						assert.Regexp(t, regexp.MustCompile(pattern), material.Content, tt.name)
+					} else {
+						assert.Equal(t, want.Content, material.Content, tt.name)
+					}
+				}
+			}
+
+			stored, _ := ioutil.ReadDir(dir + "/static/image")
+			assert.Len(t, stored, len(tt.wantContent), tt.name)
+			for _, file := range stored {
+				assert.True(t, strings.HasPrefix(file.Name(), "course-"), tt.name)
+			}
+		})
+	}
+}
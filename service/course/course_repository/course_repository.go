@@ -3,7 +3,9 @@ package course_repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 
 	sq "github.com/Masterminds/squirrel"
@@ -16,6 +18,13 @@ import (
 
 type courseRepository struct{}
 
+var COURSE_STATUS [4]string = [4]string{
+	models.CourseStatusDraft,
+	models.CourseStatusInReview,
+	models.CourseStatusPublished,
+	models.CourseStatusArchived,
+}
+
 func NewRepository() CourseRepository {
 	return &courseRepository{}
 }
@@ -31,6 +40,12 @@ func (repo *courseRepository) querySelectCourse() sq.SelectBuilder {
 		"description",
 		"thumbnail",
 		"creator",
+		"status",
+		"sequential",
+		"min_score",
+		"enrollment_mode",
+		"enrollment_key",
+		"capacity",
 	).From(repo.GetTableName())
 
 	return builder
@@ -43,6 +58,9 @@ func (repo *courseRepository) querySelectCourseSyllabus() sq.SelectBuilder {
 		"name",
 		"type",
 		"section_id",
+		"position",
+		"release_at",
+		"release_after_days",
 	).From("course_material")
 
 	return builder
@@ -57,6 +75,11 @@ func (repo *courseRepository) querySelectCourseMaterial() sq.SelectBuilder {
 		"section_id",
 		"content",
 		"content_text",
+		"metadata",
+		"content_html",
+		"position",
+		"release_at",
+		"release_after_days",
 	).From("course_material")
 
 	return builder
@@ -88,6 +111,7 @@ func (repo *courseRepository) queryInsertCourseData() sq.InsertBuilder {
 		"description",
 		"thumbnail",
 		"creator",
+		"status",
 	)
 
 	return builder
@@ -102,6 +126,11 @@ func (repo *courseRepository) queryInsertCourseMaterial() sq.InsertBuilder {
 		"section_id",
 		"content",
 		"content_text",
+		"metadata",
+		"content_html",
+		"position",
+		"release_at",
+		"release_after_days",
 	)
 
 	return builder
@@ -118,7 +147,7 @@ func (repo *courseRepository) GetCourseById(ctx context.Context, db *sqlx.DB, id
 	err = db.GetContext(ctx, out, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, err
+			return nil, er.NewError(fmt.Errorf("%s", "Course not found"), http.StatusNotFound, nil)
 		}
 		return nil, err
 	}
@@ -206,11 +235,49 @@ func (repo *courseRepository) GetOnProgressCourseByUserID(ctx context.Context, d
 	return courses, count, nil
 }
 
-func (repo *courseRepository) GetCourseList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta) ([]*db_models.Course, uint64, error) {
+func (repo *courseRepository) GetCourseList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, filter models.CourseFilter) ([]*db_models.Course, uint64, error) {
+	return repo.GetCourseListByStatus(ctx, db, meta, models.CourseStatusPublished, filter)
+}
+
+// filterCourse narrows a course query down by topic, creator and a free
+// text match on the course name and description.
+func (repo *courseRepository) filterCourse(builder sq.SelectBuilder, filter models.CourseFilter) sq.SelectBuilder {
+	if len(filter.Topic) > 0 {
+		builder = builder.Where("id IN (SELECT course_id FROM course_topic WHERE topic_id = ?)", filter.Topic)
+	}
+
+	if len(filter.Creator) > 0 {
+		builder = builder.Where(sq.Eq{"creator": filter.Creator})
+	}
+
+	if len(filter.Query) > 0 {
+		text := "%" + filter.Query + "%"
+		builder = builder.Where(sq.Or{
+			sq.Like{"course_name": text},
+			sq.Like{"description": text},
+		})
+	}
+
+	return builder
+}
+
+// sortCourse orders a course query as requested by the filter. Courses are
+// left in storage order unless sorting by rating, where courses without any
+// visible review come last.
+func (repo *courseRepository) sortCourse(builder sq.SelectBuilder, filter models.CourseFilter) sq.SelectBuilder {
+	if filter.Sort == models.CourseSortRating {
+		builder = builder.OrderBy("(SELECT COALESCE(AVG(rating), 0) FROM course_review WHERE course_review.course_id = Course.id AND hidden = false) DESC")
+	}
+
+	return builder
+}
+
+func (repo *courseRepository) GetCourseListByStatus(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, status string, filter models.CourseFilter) ([]*db_models.Course, uint64, error) {
 	var courses []*db_models.Course
 	var count uint64
 
-	selectQuery, args, err := repo.querySelectCourse().ToSql()
+	selectQuery, args, err := repo.sortCourse(repo.filterCourse(repo.querySelectCourse(), filter), filter).
+		Where(sq.Eq{"status": status}).ToSql()
 	if err != nil {
 		return courses, count, err
 	}
@@ -225,7 +292,8 @@ func (repo *courseRepository) GetCourseList(ctx context.Context, db *sqlx.DB, me
 		return courses, count, err
 	}
 
-	countQuery, args, err := repo.queryCountCourse().ToSql()
+	countQuery, args, err := repo.filterCourse(repo.queryCountCourse(), filter).
+		Where(sq.Eq{"status": status}).ToSql()
 	if err != nil {
 		return courses, count, err
 	}
@@ -246,7 +314,8 @@ func (repo *courseRepository) GetCourseSyllabusByCourseID(ctx context.Context, d
 	var syllabus []*db_models.Syllabus
 
 	query, args, err := repo.querySelectCourseSyllabus().
-		Where(sq.Eq{"course_id": courseId}).ToSql()
+		Where(sq.Eq{"course_id": courseId}).
+		OrderBy("position", "_id").ToSql()
 	if err != nil {
 		return syllabus, err
 	}
@@ -268,6 +337,7 @@ func (repo *courseRepository) GetCourseMaterialByCourseIDAndSectionID(ctx contex
 	query, args, err := repo.querySelectCourseMaterial().
 		Where(sq.Eq{"course_id": courseId}).
 		Where(sq.Eq{"section_id": sectionId}).
+		OrderBy("position", "_id").
 		ToSql()
 	if err != nil {
 		return material, err
@@ -308,251 +378,2526 @@ func (repo *courseRepository) IsUserEnrolledToCourse(ctx context.Context, db *sq
 	return false, nil
 }
 
-func (repo *courseRepository) InsertEnrollment(ctx context.Context, db *sqlx.DB, values *models.EnrollInput) error {
-	query, args, err := sq.Insert("on_progress_course").
-		Columns("user_id", "course_id").
-		Values(values.UserID, values.CourseID).ToSql()
-
+func (repo *courseRepository) insertEnrollmentHistory(ctx context.Context, tx *sqlx.Tx, values *models.EnrollInput) error {
+	query, args, err := sq.Insert("enrollment_history").
+		Columns("user_id", "course_id", "action", "keep_progress").
+		Values(values.UserID, values.CourseID, values.Action, values.KeepProgress).ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return err
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (repo *courseRepository) deleteUserProgress(ctx context.Context, tx *sqlx.Tx, userId, courseId string) error {
+	for _, table := range []string{"user_progress", "material_progress"} {
+		query, args, err := sq.Delete(table).
+			Where(sq.Eq{"user_id": userId}).
+			Where(sq.Eq{"course_id": courseId}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (repo *courseRepository) GetCourseIDByMaterialID(ctx context.Context, db *sqlx.DB, materialID string) (string, error) {
-	out := new(db_models.Syllabus)
-	query, args, err := repo.querySelectCourseSyllabus().Where(sq.Eq{"id": materialID}).ToSql()
+// reserveSeat locks the course row until the transaction ends, so concurrent
+// enrollments to a capped course count the seats one after another.
+func (repo *courseRepository) reserveSeat(ctx context.Context, tx *sqlx.Tx, courseId string, capacity int) error {
+	var id string
+
+	query, args, err := sq.Select("id").
+		From(repo.GetTableName()).
+		Where(sq.Eq{"id": courseId}).
+		Suffix("FOR UPDATE").ToSql()
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	err = db.GetContext(ctx, out, query, args...)
+	err = tx.GetContext(ctx, &id, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return "", er.NewError(fmt.Errorf("%s", "Material is not in course syllabus"), http.StatusBadRequest, nil)
+			return er.NewError(fmt.Errorf("%s", "Course not found"), http.StatusNotFound, nil)
 		}
-		return "", err
+		return err
 	}
 
-	return out.CourseID, nil
-}
+	var enrolled int
 
-func (repo *courseRepository) StoreUserProgress(ctx context.Context, db *sqlx.DB, materialID, courseID, userID string, score int) error {
-	query, args, err := sq.Insert("user_progress").
-		Columns("user_id", "course_id", "material_id", "score").
-		Values(userID, courseID, materialID, score).ToSql()
+	query, args, err = sq.Select("COUNT(*)").
+		From("on_progress_course").
+		Where(sq.Eq{"course_id": courseId}).ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = db.ExecContext(ctx, query, args...)
+	err = tx.GetContext(ctx, &enrolled, query, args...)
 	if err != nil {
 		return err
 	}
 
+	if enrolled >= capacity {
+		return er.NewError(fmt.Errorf("%s", "The course is full"), http.StatusConflict, nil)
+	}
+
 	return nil
 }
 
-func (repo *courseRepository) GetUserProgress(ctx context.Context, db *sqlx.DB, userID, courseID string) ([]*db_models.UserProgress, error) {
-	var userProgress []*db_models.UserProgress
+// InsertEnrollment enrolls the user and records it in the enrollment
+// history. Previous progress on the course is dropped unless KeepProgress is
+// set. With a Capacity the enrollment only goes through while a seat is free.
+func (repo *courseRepository) InsertEnrollment(ctx context.Context, db *sqlx.DB, values *models.EnrollInput) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if values.Capacity > 0 {
+		err = repo.reserveSeat(ctx, tx, values.CourseID, values.Capacity)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !values.KeepProgress {
+		err = repo.deleteUserProgress(ctx, tx, values.UserID, values.CourseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Insert("on_progress_course").
+		Columns("user_id", "course_id").
+		Values(values.UserID, values.CourseID).ToSql()
 
-	query, args, err := repo.querySelectUserProgress().Where(sq.Eq{"user_id": userID, "course_id": courseID}).ToSql()
 	if err != nil {
-		return userProgress, err
+		return err
 	}
 
-	err = db.SelectContext(ctx, &userProgress, query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return userProgress, nil
+		return err
+	}
+
+	err = repo.insertEnrollmentHistory(ctx, tx, values)
+	if err != nil {
+		return err
+	}
+
+	if values.CohortID != "" {
+		query, args, err = sq.Insert("cohort_member").
+			Columns("cohort_id", "course_id", "user_id").
+			Values(values.CohortID, values.CourseID, values.UserID).
+			Suffix("ON DUPLICATE KEY UPDATE cohort_id = VALUES(cohort_id), joined_at = CURRENT_TIMESTAMP").
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
 		}
-		return userProgress, err
 	}
 
-	return userProgress, nil
+	return tx.Commit()
 }
 
-func (repo *courseRepository) CheckIsProgressLogged(ctx context.Context, db *sqlx.DB, userID, materialID string) (bool, error) {
-	var userProgress []*db_models.UserProgress
+// DeleteEnrollment unenrolls the user and records it in the enrollment
+// history. The user's progress on the course is dropped unless KeepProgress
+// is set.
+func (repo *courseRepository) DeleteEnrollment(ctx context.Context, db *sqlx.DB, values *models.EnrollInput) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	query, args, err := repo.querySelectUserProgress().Where(sq.Eq{"user_id": userID, "material_id": materialID}).ToSql()
+	query, args, err := sq.Delete("on_progress_course").
+		Where(sq.Eq{"user_id": values.UserID}).
+		Where(sq.Eq{"course_id": values.CourseID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if !values.KeepProgress {
+		err = repo.deleteUserProgress(ctx, tx, values.UserID, values.CourseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = repo.insertEnrollmentHistory(ctx, tx, values)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) HasEnrollmentHistory(ctx context.Context, db *sqlx.DB, userId, courseId string) (bool, error) {
+	var count uint64
+
+	query, args, err := sq.Select("count(*)").
+		From("enrollment_history").
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).ToSql()
 	if err != nil {
 		return false, err
 	}
 
-	err = db.SelectContext(ctx, &userProgress, query, args...)
+	err = db.GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (repo *courseRepository) GetEnrollmentHistoryByUserID(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, userId string) ([]*db_models.EnrollmentHistory, uint64, error) {
+	var history []*db_models.EnrollmentHistory
+	var count uint64
+
+	selectQuery, args, err := sq.Select(
+		"enrollment_history.user_id",
+		"enrollment_history.course_id",
+		"COALESCE(Course.course_name, '') AS course_name",
+		"enrollment_history.action",
+		"enrollment_history.keep_progress",
+		"enrollment_history.created_at",
+	).From("enrollment_history").
+		LeftJoin("Course ON Course.id = enrollment_history.course_id").
+		Where(sq.Eq{"enrollment_history.user_id": userId}).
+		OrderBy("enrollment_history._id DESC").ToSql()
+	if err != nil {
+		return history, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &history, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return false, nil
+			return history, count, nil
 		}
-		return false, err
+
+		return history, count, err
 	}
 
-	if len(userProgress) == 0 {
-		return false, nil
+	countQuery, args, err := sq.Select("count(*)").
+		From("enrollment_history").
+		Where(sq.Eq{"user_id": userId}).ToSql()
+	if err != nil {
+		return history, count, err
 	}
 
-	return true, nil
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		return history, count, err
+	}
+
+	return history, count, nil
 }
 
-func (repo *courseRepository) InsertCourseData(ctx context.Context, db *sqlx.DB, values *db_models.Course) error {
-	query, args, err := repo.queryInsertCourseData().Values(
-		values.ID,
-		values.CourseName,
-		values.Description,
-		values.Thumbnail,
-		values.Creator,
-	).ToSql()
+func (repo *courseRepository) IsCourseCompleted(ctx context.Context, db *sqlx.DB, userId string, courseId string) (bool, error) {
+	var count uint64
+
+	countQuery, args, err := sq.Select("count(*)").
+		From("solved_course").
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).ToSql()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	_, err = db.ExecContext(ctx, query, args...)
+	err = db.GetContext(ctx, &count, countQuery, args...)
 	if err != nil {
-		fmt.Println(err)
-		return err
+		return false, err
 	}
 
-	return nil
+	return count > 0, nil
 }
 
-func (repo *courseRepository) InsertCourseMaterialMany(ctx context.Context, db *sqlx.DB, values *[]db_models.Material) error {
-	queryBuilder := repo.queryInsertCourseMaterial()
+// CompleteCourse moves an enrollment from on_progress_course to solved_course.
+// The final score is the average of the user's best score on every material.
+// Nothing happens when the user is no longer in on_progress_course.
+func (repo *courseRepository) CompleteCourse(ctx context.Context, db *sqlx.DB, userId string, courseId string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	for _, material := range *values {
-		queryBuilder = queryBuilder.Values(
-			material.ID,
-			material.CourseID,
-			material.Name,
-			material.Type,
-			material.SectionID,
-			material.Content,
-			material.ContentText,
-		)
+	var count uint64
+	query, args, err := sq.Select("count(*)").
+		From("on_progress_course").
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return err
 	}
 
-	query, args, err := queryBuilder.ToSql()
+	err = tx.GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return err
 	}
 
-	_, err = db.ExecContext(ctx, query, args...)
+	if count == 0 {
+		return nil
+	}
+
+	var average float64
+	query, args, err = sq.Select("COALESCE(AVG(best.score), 0)").
+		FromSelect(sq.Select("MAX(score) AS score").
+			From("user_progress").
+			Where(sq.Eq{"user_id": userId}).
+			Where(sq.Eq{"course_id": courseId}).
+			GroupBy("material_id"), "best").ToSql()
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
-	return nil
-}
+	err = tx.GetContext(ctx, &average, query, args...)
+	if err != nil {
+		return err
+	}
 
-func (repo *courseRepository) InsertCourse(ctx context.Context, db *sqlx.DB, input *models.CourseCreation) error {
-	courseData := &db_models.Course{
-		ID:          input.Course.ID,
-		CourseName:  input.Course.CourseName,
-		Description: input.Course.Description,
-		Thumbnail:   input.Course.Thumbnail,
-		Creator:     input.Course.Creator,
+	query, args, err = sq.Insert("solved_course").
+		Columns("user_id", "course_id", "start_date", "finish_date", "final_score").
+		Select(sq.Select("user_id", "course_id", "start_date", "NOW()").
+			Column("?", int(math.Round(average))).
+			From("on_progress_course").
+			Where(sq.Eq{"user_id": userId}).
+			Where(sq.Eq{"course_id": courseId})).ToSql()
+	if err != nil {
+		return err
 	}
-	err := repo.InsertCourseData(ctx, db, courseData)
+
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
 
-	materialData := []db_models.Material{}
+	query, args, err = sq.Delete("on_progress_course").
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return err
+	}
 
-	for _, sect := range input.Sections {
-		materialData = append(materialData, db_models.Material{
-			ID:          sect.ID,
-			CourseID:    courseData.ID,
-			Name:        sect.Name,
-			Type:        "section",
-			SectionID:   "",
-			Content:     "",
-			ContentText: "",
-		})
-		for _, mat := range sect.Subsections {
-			materialData = append(materialData, db_models.Material{
-				ID:          mat.ID,
-				CourseID:    courseData.ID,
-				Name:        mat.Name,
-				Type:        mat.Type,
-				SectionID:   sect.ID,
-				Content:     mat.Content,
-				ContentText: mat.ContentText,
-			})
-		}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
 	}
-	err = repo.InsertCourseMaterialMany(ctx, db, &materialData)
+
+	err = repo.insertEnrollmentHistory(ctx, tx, &models.EnrollInput{
+		UserID:       userId,
+		CourseID:     courseId,
+		Action:       models.EnrollmentActionComplete,
+		KeepProgress: true,
+	})
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (repo *courseRepository) GetMaterialByID(ctx context.Context, db *sqlx.DB, id string) (*db_models.Material, error) {
-	out := new(db_models.Material)
-	query, args, err := repo.querySelectCourseMaterial().Where(sq.Eq{"id": id}).ToSql()
-
+func (repo *courseRepository) GetCourseIDByMaterialID(ctx context.Context, db *sqlx.DB, materialID string) (string, error) {
+	out := new(db_models.Syllabus)
+	query, args, err := repo.querySelectCourseSyllabus().Where(sq.Eq{"id": materialID}).ToSql()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	err = db.GetContext(ctx, out, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, err
+			return "", er.NewError(fmt.Errorf("%s", "Material is not in course syllabus"), http.StatusBadRequest, nil)
 		}
-		return nil, err
+		return "", err
 	}
 
-	return out, nil
+	return out.CourseID, nil
 }
 
-func (repo *courseRepository) InsertCourseMaterial(ctx context.Context, db *sqlx.DB, material *db_models.Material) error {
-	query, args, err := repo.queryInsertCourseMaterial().Values(
-		material.ID,
-		material.CourseID,
-		material.Name,
-		material.Type,
-		material.SectionID,
-		material.Content,
-		material.ContentText,
-	).ToSql()
+// StoreUserProgress records the score of the user on a material. A material
+// has a single progress row per user which keeps the best score.
+func (repo *courseRepository) queryInsertUserProgress(materialID, courseID, userID string, score int) sq.InsertBuilder {
+	return sq.Insert("user_progress").
+		Columns("user_id", "course_id", "material_id", "score").
+		Values(userID, courseID, materialID, score).
+		Suffix("ON DUPLICATE KEY UPDATE score = GREATEST(score, VALUES(score))")
+}
+
+func (repo *courseRepository) StoreUserProgress(ctx context.Context, db *sqlx.DB, materialID, courseID, userID string, score int) error {
+	query, args, err := repo.queryInsertUserProgress(materialID, courseID, userID, score).ToSql()
 	if err != nil {
 		return err
 	}
 
 	_, err = db.ExecContext(ctx, query, args...)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
 	return nil
 }
 
-func (repo *courseRepository) GetCourseByCreatorID(ctx context.Context, db *sqlx.DB, creatorId string) ([]*db_models.Course, error) {
-	var courses []*db_models.Course
+func (repo *courseRepository) GetUserProgress(ctx context.Context, db *sqlx.DB, userID, courseID string) ([]*db_models.UserProgress, error) {
+	var userProgress []*db_models.UserProgress
 
-	query, args, err := repo.querySelectCourse().Where(sq.Eq{"creator": creatorId}).ToSql()
+	query, args, err := repo.querySelectUserProgress().Where(sq.Eq{"user_id": userID, "course_id": courseID}).ToSql()
 	if err != nil {
-		return nil, err
+		return userProgress, err
+	}
+
+	err = db.SelectContext(ctx, &userProgress, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return userProgress, nil
+		}
+		return userProgress, err
+	}
+
+	return userProgress, nil
+}
+
+func (repo *courseRepository) CheckIsProgressLogged(ctx context.Context, db *sqlx.DB, userID, materialID string) (bool, error) {
+	var userProgress []*db_models.UserProgress
+
+	query, args, err := repo.querySelectUserProgress().Where(sq.Eq{"user_id": userID, "material_id": materialID}).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = db.SelectContext(ctx, &userProgress, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(userProgress) == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (repo *courseRepository) querySelectMaterialProgress() sq.SelectBuilder {
+	return sq.Select(
+		"user_id",
+		"course_id",
+		"material_id",
+		"time_spent",
+		"percentage",
+		"last_position",
+		"completed",
+		"updated_at",
+	).From("material_progress")
+}
+
+func (repo *courseRepository) GetMaterialProgress(ctx context.Context, db *sqlx.DB, userID, materialID string) (*db_models.MaterialProgress, error) {
+	out := new(db_models.MaterialProgress)
+	query, args, err := repo.querySelectMaterialProgress().Where(sq.Eq{"user_id": userID, "material_id": materialID}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Material progress not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *courseRepository) GetMaterialProgressByCourseID(ctx context.Context, db *sqlx.DB, userID, courseID string) ([]*db_models.MaterialProgress, error) {
+	var out []*db_models.MaterialProgress
+
+	query, args, err := repo.querySelectMaterialProgress().
+		Where(sq.Eq{"user_id": userID, "course_id": courseID}).
+		OrderBy("updated_at DESC").ToSql()
+	if err != nil {
+		return out, err
+	}
+
+	err = db.SelectContext(ctx, &out, query, args...)
+	if err != nil {
+		return out, err
+	}
+
+	return out, nil
+}
+
+// UpsertMaterialProgress records a progress event. The time spent is added
+// to the stored one, the percentage only ever grows and a completed material
+// stays completed.
+func (repo *courseRepository) UpsertMaterialProgress(ctx context.Context, db *sqlx.DB, progress *db_models.MaterialProgress) error {
+	query, args, err := sq.Insert("material_progress").
+		Columns("user_id", "course_id", "material_id", "time_spent", "percentage", "last_position", "completed").
+		Values(progress.UserID, progress.CourseID, progress.MaterialID, progress.TimeSpent, progress.Percentage, progress.LastPosition, progress.Completed).
+		Suffix("ON DUPLICATE KEY UPDATE time_spent = time_spent + VALUES(time_spent), percentage = GREATEST(percentage, VALUES(percentage)), last_position = VALUES(last_position), completed = completed OR VALUES(completed), updated_at = CURRENT_TIMESTAMP").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CompleteMaterialProgress marks the progress of a material as completed and
+// logs the material in user_progress in one transaction. It reports whether
+// this call completed the material, so concurrent heartbeats complete a
+// material only once.
+func (repo *courseRepository) CompleteMaterialProgress(ctx context.Context, db *sqlx.DB, userID, courseID, materialID string) (bool, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Update("material_progress").
+		Set("completed", true).
+		Where(sq.Eq{"user_id": userID, "material_id": materialID, "completed": false}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	query, args, err = repo.queryInsertUserProgress(materialID, courseID, userID, 100).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *courseRepository) InsertCourseData(ctx context.Context, db *sqlx.DB, values *db_models.Course) error {
+	query, args, err := repo.queryInsertCourseData().Values(
+		values.ID,
+		values.CourseName,
+		values.Description,
+		values.Thumbnail,
+		values.Creator,
+		values.Status,
+	).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) InsertCourseMaterialMany(ctx context.Context, db *sqlx.DB, values *[]db_models.Material) error {
+	queryBuilder := repo.queryInsertCourseMaterial()
+
+	for _, material := range *values {
+		queryBuilder = queryBuilder.Values(
+			material.ID,
+			material.CourseID,
+			material.Name,
+			material.Type,
+			material.SectionID,
+			material.Content,
+			material.ContentText,
+			material.Metadata,
+			material.ContentHTML,
+			material.Position,
+			material.ReleaseAt,
+			material.ReleaseAfterDays,
+		)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) InsertCourse(ctx context.Context, db *sqlx.DB, input *models.CourseCreation) error {
+	courseData := &db_models.Course{
+		ID:          input.Course.ID,
+		CourseName:  input.Course.CourseName,
+		Description: input.Course.Description,
+		Thumbnail:   input.Course.Thumbnail,
+		Creator:     input.Course.Creator,
+		Status:      input.Course.Status,
+	}
+	err := repo.InsertCourseData(ctx, db, courseData)
+	if err != nil {
+		return err
+	}
+
+	materialData := []db_models.Material{}
+
+	for sectPosition, sect := range input.Sections {
+		materialData = append(materialData, db_models.Material{
+			ID:               sect.ID,
+			CourseID:         courseData.ID,
+			Name:             sect.Name,
+			Type:             "section",
+			SectionID:        "",
+			Content:          "",
+			ContentText:      "",
+			Position:         sectPosition,
+			ReleaseAt:        sect.ReleaseAt,
+			ReleaseAfterDays: sect.ReleaseAfterDays,
+		})
+		for matPosition, mat := range sect.Subsections {
+			materialData = append(materialData, db_models.Material{
+				ID:          mat.ID,
+				CourseID:    courseData.ID,
+				Name:        mat.Name,
+				Type:        mat.Type,
+				SectionID:   sect.ID,
+				Content:     mat.Content,
+				ContentText: mat.ContentText,
+				Metadata:    models.EncodeMaterialMetadata(mat.Metadata),
+				Position:    matPosition,
+			})
+		}
+	}
+	err = repo.InsertCourseMaterialMany(ctx, db, &materialData)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetMaterialsByCourseID returns every section and material of a course, with
+// sections first so their ids are known before their materials.
+func (repo *courseRepository) GetMaterialsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Material, error) {
+	var materials []*db_models.Material
+
+	query, args, err := repo.querySelectCourseMaterial().
+		Where(sq.Eq{"course_id": courseId}).
+		OrderBy("section_id", "position").
+		ToSql()
+	if err != nil {
+		return materials, err
+	}
+
+	err = db.SelectContext(ctx, &materials, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return materials, nil
+		}
+		return materials, err
+	}
+
+	return materials, nil
+}
+
+// CloneCourse stores a deep copy of a course: its data, syllabus, linked
+// assignments with their problems, topics and prerequisites.
+func (repo *courseRepository) CloneCourse(ctx context.Context, db *sqlx.DB, clone *db_models.CourseClone) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := repo.queryInsertCourseData().Columns("sequential", "min_score", "enrollment_mode", "enrollment_key", "capacity").Values(
+		clone.Course.ID,
+		clone.Course.CourseName,
+		clone.Course.Description,
+		clone.Course.Thumbnail,
+		clone.Course.Creator,
+		clone.Course.Status,
+		clone.Course.Sequential,
+		clone.Course.MinScore,
+		clone.Course.EnrollmentMode,
+		clone.Course.EnrollmentKey,
+		clone.Course.Capacity,
+	).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(clone.Materials) > 0 {
+		queryBuilder := repo.queryInsertCourseMaterial()
+		for _, material := range clone.Materials {
+			queryBuilder = queryBuilder.Values(
+				material.ID,
+				material.CourseID,
+				material.Name,
+				material.Type,
+				material.SectionID,
+				material.Content,
+				material.ContentText,
+				material.Metadata,
+				material.ContentHTML,
+				material.Position,
+				material.ReleaseAt,
+				material.ReleaseAfterDays,
+			)
+		}
+
+		query, args, err = queryBuilder.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, assignment := range clone.Assignments {
+		query, args, err = sq.Insert("assignment").
+			Columns("id", "creator", "title", "duration", "topic", "difficulty").
+			Select(sq.Select().
+				Column(sq.Expr("?", assignment.ID)).
+				Column(sq.Expr("?", clone.Course.Creator)).
+				Columns("title", "duration", "topic", "difficulty").
+				From("assignment").
+				Where(sq.Eq{"id": assignment.SourceID})).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range []string{"assignment_problem", "assignment_problems"} {
+			err = repo.copyRows(ctx, tx, table, "assignment_id", assignment.SourceID, assignment.ID, "problem_id")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = repo.copyRows(ctx, tx, "course_topic", "course_id", clone.SourceID, clone.Course.ID, "topic_id")
+	if err != nil {
+		return err
+	}
+
+	err = repo.copyRows(ctx, tx, "course_prerequisite", "course_id", clone.SourceID, clone.Course.ID, "prerequisite_id")
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// copyRows duplicates the rows of table whose key equals from, pointing the
+// copies to to.
+func (repo *courseRepository) copyRows(ctx context.Context, tx *sqlx.Tx, table, key, from, to string, columns ...string) error {
+	query, args, err := sq.Insert(table).
+		Columns(append([]string{key}, columns...)...).
+		Select(sq.Select().
+			Column(sq.Expr("?", to)).
+			Columns(columns...).
+			From(table).
+			Where(sq.Eq{key: from})).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ImportCourse stores an imported course with its materials and the problems
+// and assignments it links in a single transaction, so a failed import leaves
+// nothing behind.
+func (repo *courseRepository) ImportCourse(ctx context.Context, db *sqlx.DB, input *db_models.CourseImport) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(input.Problems) > 0 {
+		problems := sq.Insert("Candidate_Problem").Columns("id", "creator", "title", "type", "topic", "difficulty", "status")
+		details := sq.Insert("Detail_Problem").Columns("id", "detail")
+		for _, problem := range input.Problems {
+			problems = problems.Values(problem.ID, problem.Creator, problem.Title, problem.Type, problem.Topic, problem.Difficulty, problem.Status)
+			details = details.Values(problem.ID, problem.Detail)
+		}
+
+		for _, builder := range []sq.InsertBuilder{problems, details} {
+			err = repo.execTx(ctx, tx, builder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(input.Assignments) > 0 {
+		assignments := sq.Insert("assignment").Columns("id", "creator", "title", "duration", "topic", "difficulty")
+		links := sq.Insert("assignment_problem").Columns("assignment_id", "problem_id")
+		hasLinks := false
+		for _, assignment := range input.Assignments {
+			desc := assignment.Desc
+			assignments = assignments.Values(desc.ID, desc.Creator, desc.Title, desc.Duration, desc.Topic, desc.Difficulty)
+			for _, problem := range assignment.Problems {
+				links = links.Values(problem.AssignmentID, problem.ProblemID)
+				hasLinks = true
+			}
+		}
+
+		err = repo.execTx(ctx, tx, assignments)
+		if err != nil {
+			return err
+		}
+
+		if hasLinks {
+			err = repo.execTx(ctx, tx, links)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = repo.execTx(ctx, tx, repo.queryInsertCourseData().Columns("sequential", "min_score").Values(
+		input.Course.ID,
+		input.Course.CourseName,
+		input.Course.Description,
+		input.Course.Thumbnail,
+		input.Course.Creator,
+		input.Course.Status,
+		input.Course.Sequential,
+		input.Course.MinScore,
+	))
+	if err != nil {
+		return err
+	}
+
+	if len(input.Materials) > 0 {
+		queryBuilder := repo.queryInsertCourseMaterial()
+		for _, material := range input.Materials {
+			queryBuilder = queryBuilder.Values(
+				material.ID,
+				material.CourseID,
+				material.Name,
+				material.Type,
+				material.SectionID,
+				material.Content,
+				material.ContentText,
+				material.Metadata,
+				material.ContentHTML,
+				material.Position,
+				material.ReleaseAt,
+				material.ReleaseAfterDays,
+			)
+		}
+
+		err = repo.execTx(ctx, tx, queryBuilder)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// execTx runs the insert built by builder inside tx.
+func (repo *courseRepository) execTx(ctx context.Context, tx *sqlx.Tx, builder sq.InsertBuilder) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) GetMaterialByID(ctx context.Context, db *sqlx.DB, id string) (*db_models.Material, error) {
+	out := new(db_models.Material)
+	query, args, err := repo.querySelectCourseMaterial().Where(sq.Eq{"id": id}).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Material not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *courseRepository) InsertCourseMaterial(ctx context.Context, db *sqlx.DB, material *db_models.Material) error {
+	query, args, err := repo.queryInsertCourseMaterial().Values(
+		material.ID,
+		material.CourseID,
+		material.Name,
+		material.Type,
+		material.SectionID,
+		material.Content,
+		material.ContentText,
+		material.Metadata,
+		material.ContentHTML,
+		material.Position,
+		material.ReleaseAt,
+		material.ReleaseAfterDays,
+	).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) GetCourseByCreatorID(ctx context.Context, db *sqlx.DB, creatorId string) ([]*db_models.Course, error) {
+	var courses []*db_models.Course
+
+	collaborations := sq.Select("course_id").
+		From("course_collaborator").
+		Where(sq.Eq{"user_id": creatorId}).
+		Where(sq.Eq{"status": models.CollaboratorStatusAccepted})
+
+	collaborationQuery, collaborationArgs, err := collaborations.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := repo.querySelectCourse().
+		Where(sq.Or{
+			sq.Eq{"creator": creatorId},
+			sq.Expr("id IN ("+collaborationQuery+")", collaborationArgs...),
+		}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SelectContext(ctx, &courses, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return courses, nil
+		}
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+func (repo *courseRepository) UpdateCourseData(ctx context.Context, db *sqlx.DB, values *db_models.Course) error {
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("course_name", values.CourseName).
+		Set("description", values.Description).
+		Set("thumbnail", values.Thumbnail).
+		Where(sq.Eq{"id": values.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateCourseAccess(ctx context.Context, db *sqlx.DB, values *db_models.Course) error {
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("sequential", values.Sequential).
+		Set("min_score", values.MinScore).
+		Where(sq.Eq{"id": values.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateEnrollmentSettings(ctx context.Context, db *sqlx.DB, values *db_models.Course) error {
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("enrollment_mode", values.EnrollmentMode).
+		Set("enrollment_key", values.EnrollmentKey).
+		Set("capacity", values.Capacity).
+		Where(sq.Eq{"id": values.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateCourseMaterial(ctx context.Context, db *sqlx.DB, material *db_models.Material) error {
+	query, args, err := sq.Update("course_material").
+		Set("name", material.Name).
+		Set("type", material.Type).
+		Set("content", material.Content).
+		Set("content_text", material.ContentText).
+		Set("metadata", material.Metadata).
+		Set("content_html", material.ContentHTML).
+		Set("release_at", material.ReleaseAt).
+		Set("release_after_days", material.ReleaseAfterDays).
+		Where(sq.Eq{"id": material.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCourseMaterials removes the given course_material rows together with
+// the progress learners have logged on them. Everything runs in a single
+// transaction so a section is never left half deleted.
+func (repo *courseRepository) DeleteCourseMaterials(ctx context.Context, db *sqlx.DB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"user_progress", "material_progress"} {
+		query, args, err := sq.Delete(table).Where(sq.Eq{"material_id": ids}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Delete("course_material").Where(sq.Eq{"id": ids}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCourse removes a course along with its syllabus, enrollments and
+// learner progress.
+func (repo *courseRepository) DeleteCourse(ctx context.Context, db *sqlx.DB, id string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tables := []string{"user_progress", "material_progress", "on_progress_course", "solved_course", "course_material", "course_prerequisite", "learning_path_course", "course_collaborator", "course_review", "discussion", "announcement_read", "course_announcement", "cohort_member", "cohort_instructor", "cohort", "enrollment_request", "course_topic", "enrollment_history"}
+	for _, table := range tables {
+		query, args, err := sq.Delete(table).Where(sq.Eq{"course_id": id}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Delete("course_prerequisite").Where(sq.Eq{"prerequisite_id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = sq.Delete(repo.GetTableName()).Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetNextMaterialPosition returns the position right after the last item
+// sharing the same parent. Sections are stored with an empty section_id.
+func (repo *courseRepository) GetNextMaterialPosition(ctx context.Context, db *sqlx.DB, courseId, sectionId string) (int, error) {
+	var position int
+
+	query, args, err := sq.Select("COALESCE(MAX(position) + 1, 0)").
+		From("course_material").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"section_id": sectionId}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.GetContext(ctx, &position, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+// UpdateMaterialPositions moves every given item to its new parent and
+// position inside one transaction, so a reorder is applied all or nothing.
+func (repo *courseRepository) UpdateMaterialPositions(ctx context.Context, db *sqlx.DB, positions []*db_models.MaterialPosition) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, item := range positions {
+		query, args, err := sq.Update("course_material").
+			Set("section_id", item.SectionID).
+			Set("position", item.Position).
+			Where(sq.Eq{"id": item.ID}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) UpdateCourseStatus(ctx context.Context, db *sqlx.DB, input *models.CourseStatusUpdate) error {
+	var (
+		found bool = false
+	)
+
+	for _, stat := range COURSE_STATUS {
+		if input.Status == stat {
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New("Invalid Status")
+	}
+
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("status", input.Status).
+		Where(sq.Eq{"id": input.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) querySelectTopic() sq.SelectBuilder {
+	builder := sq.Select(
+		"id",
+		"name",
+	).From("topic")
+
+	return builder
+}
+
+func (repo *courseRepository) GetTopicList(ctx context.Context, db *sqlx.DB) ([]*db_models.Topic, error) {
+	var topics []*db_models.Topic
+
+	query, args, err := repo.querySelectTopic().OrderBy("name").ToSql()
+	if err != nil {
+		return topics, err
+	}
+
+	err = db.SelectContext(ctx, &topics, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return topics, nil
+		}
+		return topics, err
+	}
+
+	return topics, nil
+}
+
+func (repo *courseRepository) GetTopicById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Topic, error) {
+	out := new(db_models.Topic)
+	query, args, err := repo.querySelectTopic().Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Topic not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *courseRepository) GetTopicsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Topic, error) {
+	var topics []*db_models.Topic
+
+	query, args, err := sq.Select(
+		"topic.id",
+		"topic.name",
+	).From("topic").
+		Join("course_topic on course_topic.topic_id = topic.id").
+		Where(sq.Eq{"course_topic.course_id": courseId}).
+		OrderBy("topic.name").
+		ToSql()
+	if err != nil {
+		return topics, err
+	}
+
+	err = db.SelectContext(ctx, &topics, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return topics, nil
+		}
+		return topics, err
+	}
+
+	return topics, nil
+}
+
+func (repo *courseRepository) InsertTopic(ctx context.Context, db *sqlx.DB, topic *db_models.Topic) error {
+	query, args, err := sq.Insert("topic").
+		Columns("id", "name").
+		Values(topic.ID, topic.Name).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateTopic(ctx context.Context, db *sqlx.DB, topic *db_models.Topic) error {
+	query, args, err := sq.Update("topic").
+		Set("name", topic.Name).
+		Where(sq.Eq{"id": topic.ID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTopic removes a topic and detaches it from every course using it.
+func (repo *courseRepository) DeleteTopic(ctx context.Context, db *sqlx.DB, id string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Delete("course_topic").Where(sq.Eq{"topic_id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = sq.Delete("topic").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetCourseTopics replaces the topics attached to a course.
+func (repo *courseRepository) SetCourseTopics(ctx context.Context, db *sqlx.DB, courseId string, topicIds []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Delete("course_topic").Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(topicIds) > 0 {
+		queryBuilder := sq.Insert("course_topic").Columns("course_id", "topic_id")
+		for _, topicId := range topicIds {
+			queryBuilder = queryBuilder.Values(courseId, topicId)
+		}
+
+		query, args, err = queryBuilder.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) GetPrerequisitesByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Course, error) {
+	var courses []*db_models.Course
+
+	query, args, err := sq.Select(
+		"Course.id",
+		"Course.course_name",
+		"Course.description",
+		"Course.thumbnail",
+		"Course.creator",
+		"Course.status",
+	).From("course_prerequisite").
+		Join("Course ON Course.id = course_prerequisite.prerequisite_id").
+		Where(sq.Eq{"course_prerequisite.course_id": courseId}).
+		OrderBy("Course.course_name").
+		ToSql()
+	if err != nil {
+		return courses, err
+	}
+
+	err = db.SelectContext(ctx, &courses, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return courses, nil
+		}
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+// GetMissingPrerequisites returns the prerequisites of the course which do
+// not appear in the user's solved_course.
+func (repo *courseRepository) GetMissingPrerequisites(ctx context.Context, db *sqlx.DB, userId string, courseId string) ([]*db_models.Course, error) {
+	var courses []*db_models.Course
+
+	query, args, err := sq.Select(
+		"Course.id",
+		"Course.course_name",
+		"Course.description",
+		"Course.thumbnail",
+		"Course.creator",
+		"Course.status",
+	).From("course_prerequisite").
+		Join("Course ON Course.id = course_prerequisite.prerequisite_id").
+		Where(sq.Eq{"course_prerequisite.course_id": courseId}).
+		Where(sq.Expr("course_prerequisite.prerequisite_id NOT IN (SELECT course_id FROM solved_course WHERE user_id = ?)", userId)).
+		OrderBy("Course.course_name").
+		ToSql()
+	if err != nil {
+		return courses, err
 	}
 
 	err = db.SelectContext(ctx, &courses, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return courses, nil
+			return courses, nil
+		}
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+func (repo *courseRepository) SetCoursePrerequisites(ctx context.Context, db *sqlx.DB, courseId string, prerequisiteIds []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Delete("course_prerequisite").Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(prerequisiteIds) > 0 {
+		queryBuilder := sq.Insert("course_prerequisite").Columns("course_id", "prerequisite_id")
+		for _, prerequisiteId := range prerequisiteIds {
+			queryBuilder = queryBuilder.Values(courseId, prerequisiteId)
+		}
+
+		query, args, err = queryBuilder.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) GetEnrollmentStartDate(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*string, error) {
+	var startDate string
+
+	query, args, err := sq.Select("start_date").
+		From("on_progress_course").
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, &startDate, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &startDate, nil
+}
+
+func (repo *courseRepository) querySelectCollaborator() sq.SelectBuilder {
+	builder := sq.Select(
+		"course_collaborator.course_id",
+		"COALESCE(Course.course_name, '') AS course_name",
+		"course_collaborator.user_id",
+		"COALESCE(User.fullname, '') AS fullname",
+		"COALESCE(User.email, '') AS email",
+		"course_collaborator.role",
+		"course_collaborator.status",
+		"course_collaborator.invited_by",
+		"course_collaborator.created_at",
+	).From("course_collaborator").
+		LeftJoin("Course ON Course.id = course_collaborator.course_id").
+		LeftJoin("User ON User.id = course_collaborator.user_id")
+
+	return builder
+}
+
+func (repo *courseRepository) GetCollaboratorsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.CourseCollaborator, error) {
+	var collaborators []*db_models.CourseCollaborator
+
+	query, args, err := repo.querySelectCollaborator().
+		Where(sq.Eq{"course_collaborator.course_id": courseId}).
+		OrderBy("course_collaborator.created_at").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SelectContext(ctx, &collaborators, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return collaborators, nil
+		}
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+func (repo *courseRepository) GetCollaborator(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.CourseCollaborator, error) {
+	collaborator := new(db_models.CourseCollaborator)
+
+	query, args, err := repo.querySelectCollaborator().
+		Where(sq.Eq{"course_collaborator.course_id": courseId}).
+		Where(sq.Eq{"course_collaborator.user_id": userId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, collaborator, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Collaborator not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+// GetCollaboratorRole returns the role of the user on the course, or an empty
+// string when the user has no accepted collaboration on it.
+func (repo *courseRepository) GetCollaboratorRole(ctx context.Context, db *sqlx.DB, courseId string, userId string) (string, error) {
+	var role string
+
+	query, args, err := sq.Select("role").
+		From("course_collaborator").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"user_id": userId}).
+		Where(sq.Eq{"status": models.CollaboratorStatusAccepted}).ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	err = db.GetContext(ctx, &role, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (repo *courseRepository) GetCollaborationInvitations(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.CourseCollaborator, error) {
+	var invitations []*db_models.CourseCollaborator
+
+	query, args, err := repo.querySelectCollaborator().
+		Where(sq.Eq{"course_collaborator.user_id": userId}).
+		Where(sq.Eq{"course_collaborator.status": models.CollaboratorStatusPending}).
+		OrderBy("course_collaborator.created_at DESC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SelectContext(ctx, &invitations, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return invitations, nil
+		}
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (repo *courseRepository) InsertCollaborator(ctx context.Context, db *sqlx.DB, collaborator *db_models.CourseCollaborator) error {
+	query, args, err := sq.Insert("course_collaborator").
+		Columns("course_id", "user_id", "role", "status", "invited_by").
+		Values(collaborator.CourseID, collaborator.UserID, collaborator.Role, collaborator.Status, collaborator.InvitedBy).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateCollaborator(ctx context.Context, db *sqlx.DB, collaborator *db_models.CourseCollaborator) error {
+	query, args, err := sq.Update("course_collaborator").
+		Set("role", collaborator.Role).
+		Set("status", collaborator.Status).
+		Where(sq.Eq{"course_id": collaborator.CourseID}).
+		Where(sq.Eq{"user_id": collaborator.UserID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) DeleteCollaborator(ctx context.Context, db *sqlx.DB, courseId string, userId string) error {
+	query, args, err := sq.Delete("course_collaborator").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) querySelectReview() sq.SelectBuilder {
+	builder := sq.Select(
+		"course_review.course_id",
+		"course_review.user_id",
+		"COALESCE(User.username, '') AS username",
+		"course_review.rating",
+		"COALESCE(course_review.review, '') AS review",
+		"course_review.hidden",
+		"course_review.created_at",
+		"course_review.updated_at",
+	).From("course_review").
+		LeftJoin("User ON User.id = course_review.user_id")
+
+	return builder
+}
+
+func (repo *courseRepository) GetCourseReviews(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string) ([]*db_models.CourseReview, uint64, error) {
+	var reviews []*db_models.CourseReview
+	var count uint64
+
+	selectQuery, args, err := repo.querySelectReview().
+		Where(sq.Eq{"course_review.course_id": courseId}).
+		Where(sq.Eq{"course_review.hidden": false}).
+		OrderBy("course_review.updated_at DESC").ToSql()
+	if err != nil {
+		return reviews, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &reviews, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return reviews, count, nil
+		}
+		return reviews, count, err
+	}
+
+	countQuery, args, err := sq.Select("COUNT(*)").
+		From("course_review").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"hidden": false}).ToSql()
+	if err != nil {
+		return reviews, count, err
+	}
+
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return reviews, count, nil
+		}
+		return reviews, count, err
+	}
+
+	return reviews, count, nil
+}
+
+func (repo *courseRepository) GetCourseReview(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.CourseReview, error) {
+	review := new(db_models.CourseReview)
+
+	query, args, err := repo.querySelectReview().
+		Where(sq.Eq{"course_review.course_id": courseId}).
+		Where(sq.Eq{"course_review.user_id": userId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, review, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Review not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// GetCourseRatings returns the ratings of several courses at once. Courses
+// without any visible review have no row.
+func (repo *courseRepository) GetCourseRatings(ctx context.Context, db *sqlx.DB, courseIds []string) ([]*db_models.CourseRating, error) {
+	var ratings []*db_models.CourseRating
+
+	query, args, err := sq.Select("course_id", "AVG(rating) AS average", "COUNT(*) AS count").
+		From("course_review").
+		Where(sq.Eq{"course_id": courseIds}).
+		Where(sq.Eq{"hidden": false}).
+		GroupBy("course_id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SelectContext(ctx, &ratings, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ratings, nil
+		}
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// UpsertCourseReview stores the review of a user, replacing the rating and
+// text of an earlier review. Moderation of an earlier review is kept.
+func (repo *courseRepository) UpsertCourseReview(ctx context.Context, db *sqlx.DB, review *db_models.CourseReview) error {
+	query, args, err := sq.Insert("course_review").
+		Columns("course_id", "user_id", "rating", "review").
+		Values(review.CourseID, review.UserID, review.Rating, review.Review).
+		Suffix("ON DUPLICATE KEY UPDATE rating = VALUES(rating), review = VALUES(review), updated_at = CURRENT_TIMESTAMP").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) SetCourseReviewHidden(ctx context.Context, db *sqlx.DB, courseId string, userId string, hidden bool) error {
+	query, args, err := sq.Update("course_review").
+		Set("hidden", hidden).
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// querySelectAnnouncement selects announcements together with whether the
+// given user has read them.
+func (repo *courseRepository) querySelectAnnouncement(userId string) sq.SelectBuilder {
+	builder := sq.Select(
+		"course_announcement.id",
+		"course_announcement.course_id",
+		"course_announcement.author_id",
+		"COALESCE(User.username, '') AS author",
+		"course_announcement.title",
+		"course_announcement.content",
+	).Column(sq.Expr("EXISTS (SELECT 1 FROM announcement_read WHERE announcement_read.announcement_id = course_announcement.id AND announcement_read.user_id = ?) AS is_read", userId)).
+		Columns(
+			"course_announcement.created_at",
+			"course_announcement.updated_at",
+		).From("course_announcement").
+		LeftJoin("User ON User.id = course_announcement.author_id")
+
+	return builder
+}
+
+func (repo *courseRepository) GetCourseAnnouncements(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string, userId string) ([]*db_models.CourseAnnouncement, uint64, error) {
+	var announcements []*db_models.CourseAnnouncement
+	var count uint64
+
+	selectQuery, args, err := repo.querySelectAnnouncement(userId).
+		Where(sq.Eq{"course_announcement.course_id": courseId}).
+		OrderBy("course_announcement.created_at DESC").ToSql()
+	if err != nil {
+		return announcements, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &announcements, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return announcements, count, nil
+		}
+		return announcements, count, err
+	}
+
+	countQuery, args, err := sq.Select("COUNT(*)").
+		From("course_announcement").
+		Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return announcements, count, err
+	}
+
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return announcements, count, nil
+		}
+		return announcements, count, err
+	}
+
+	return announcements, count, nil
+}
+
+func (repo *courseRepository) GetCourseAnnouncement(ctx context.Context, db *sqlx.DB, id string) (*db_models.CourseAnnouncement, error) {
+	announcement := new(db_models.CourseAnnouncement)
+
+	query, args, err := repo.querySelectAnnouncement("").
+		Where(sq.Eq{"course_announcement.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, announcement, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Announcement not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+func (repo *courseRepository) InsertCourseAnnouncement(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement) error {
+	query, args, err := sq.Insert("course_announcement").
+		Columns("id", "course_id", "author_id", "title", "content").
+		Values(announcement.ID, announcement.CourseID, announcement.AuthorID, announcement.Title, announcement.Content).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) UpdateCourseAnnouncement(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement) error {
+	query, args, err := sq.Update("course_announcement").
+		Set("title", announcement.Title).
+		Set("content", announcement.Content).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": announcement.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCourseAnnouncement removes the announcement along with the read
+// state of every learner.
+func (repo *courseRepository) DeleteCourseAnnouncement(ctx context.Context, db *sqlx.DB, id string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Delete("announcement_read").Where(sq.Eq{"announcement_id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = sq.Delete("course_announcement").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MarkAnnouncementRead records that the user has read the announcement.
+// Marking an announcement twice keeps the first read time.
+func (repo *courseRepository) MarkAnnouncementRead(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement, userId string) error {
+	query, args, err := sq.Insert("announcement_read").
+		Options("IGNORE").
+		Columns("announcement_id", "course_id", "user_id").
+		Values(announcement.ID, announcement.CourseID, userId).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUnreadAnnouncements counts the unread announcements of every on progress
+// course of the user. Courses without unread announcements are left out.
+func (repo *courseRepository) GetUnreadAnnouncements(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.UnreadAnnouncement, error) {
+	var unread []*db_models.UnreadAnnouncement
+
+	query, args, err := sq.Select(
+		"Course.id AS course_id",
+		"Course.course_name",
+		"COUNT(course_announcement.id) AS unread",
+	).From("on_progress_course").
+		Join("Course ON Course.id = on_progress_course.course_id").
+		Join("course_announcement ON course_announcement.course_id = on_progress_course.course_id").
+		Where(sq.Eq{"on_progress_course.user_id": userId}).
+		Where("course_announcement.id NOT IN (SELECT announcement_id FROM announcement_read WHERE user_id = ?)", userId).
+		GroupBy("Course.id", "Course.course_name").
+		ToSql()
+	if err != nil {
+		return unread, err
+	}
+
+	err = db.SelectContext(ctx, &unread, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return unread, nil
+		}
+		return unread, err
+	}
+
+	return unread, nil
+}
+
+func (repo *courseRepository) querySelectCohort() sq.SelectBuilder {
+	builder := sq.Select(
+		"id",
+		"course_id",
+		"name",
+		"join_code",
+		"start_date",
+		"end_date",
+	).From("cohort")
+
+	return builder
+}
+
+func (repo *courseRepository) GetCohortsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Cohort, error) {
+	var cohorts []*db_models.Cohort
+
+	query, args, err := repo.querySelectCohort().
+		Where(sq.Eq{"course_id": courseId}).
+		OrderBy("start_date").ToSql()
+	if err != nil {
+		return cohorts, err
+	}
+
+	err = db.SelectContext(ctx, &cohorts, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return cohorts, nil
+		}
+		return cohorts, err
+	}
+
+	return cohorts, nil
+}
+
+func (repo *courseRepository) getCohort(ctx context.Context, db *sqlx.DB, where sq.Eq) (*db_models.Cohort, error) {
+	cohort := new(db_models.Cohort)
+
+	query, args, err := repo.querySelectCohort().Where(where).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, cohort, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Cohort not found"), http.StatusNotFound, nil)
 		}
-		return courses, err
+		return nil, err
 	}
 
-	return courses, nil
+	return cohort, nil
+}
+
+func (repo *courseRepository) GetCohortById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Cohort, error) {
+	return repo.getCohort(ctx, db, sq.Eq{"id": id})
+}
+
+func (repo *courseRepository) GetCohortByJoinCode(ctx context.Context, db *sqlx.DB, joinCode string) (*db_models.Cohort, error) {
+	return repo.getCohort(ctx, db, sq.Eq{"join_code": joinCode})
+}
+
+// insertCohortInstructors replaces the instructors of the cohort.
+func (repo *courseRepository) insertCohortInstructors(ctx context.Context, tx *sqlx.Tx, cohort *db_models.Cohort, instructors []string) error {
+	query, args, err := sq.Delete("cohort_instructor").Where(sq.Eq{"cohort_id": cohort.ID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(instructors) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert("cohort_instructor").Columns("cohort_id", "course_id", "user_id")
+	for _, userId := range instructors {
+		builder = builder.Values(cohort.ID, cohort.CourseID, userId)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) InsertCohort(ctx context.Context, db *sqlx.DB, cohort *db_models.Cohort, instructors []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Insert("cohort").
+		Columns("id", "course_id", "name", "join_code", "start_date", "end_date").
+		Values(cohort.ID, cohort.CourseID, cohort.Name, cohort.JoinCode, cohort.StartDate, cohort.EndDate).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertCohortInstructors(ctx, tx, cohort, instructors)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) UpdateCohort(ctx context.Context, db *sqlx.DB, cohort *db_models.Cohort, instructors []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Update("cohort").
+		Set("name", cohort.Name).
+		Set("start_date", cohort.StartDate).
+		Set("end_date", cohort.EndDate).
+		Where(sq.Eq{"id": cohort.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertCohortInstructors(ctx, tx, cohort, instructors)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCohort removes the cohort together with its instructors and members.
+// Members stay enrolled to the course.
+func (repo *courseRepository) DeleteCohort(ctx context.Context, db *sqlx.DB, id string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tables := []string{"cohort_member", "cohort_instructor"}
+	for _, table := range tables {
+		query, args, err := sq.Delete(table).Where(sq.Eq{"cohort_id": id}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Delete("cohort").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *courseRepository) getCohortUsers(ctx context.Context, db *sqlx.DB, table string, cohortId string) ([]*db_models.CohortUser, error) {
+	var users []*db_models.CohortUser
+
+	query, args, err := sq.Select(
+		table+".cohort_id",
+		table+".user_id",
+		"COALESCE(User.fullname, '') AS fullname",
+		"COALESCE(User.email, '') AS email",
+	).From(table).
+		LeftJoin(fmt.Sprintf("User ON User.id = %s.user_id", table)).
+		Where(sq.Eq{table + ".cohort_id": cohortId}).
+		OrderBy("fullname").ToSql()
+	if err != nil {
+		return users, err
+	}
+
+	err = db.SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return users, nil
+		}
+		return users, err
+	}
+
+	return users, nil
+}
+
+func (repo *courseRepository) GetCohortInstructors(ctx context.Context, db *sqlx.DB, cohortId string) ([]*db_models.CohortUser, error) {
+	return repo.getCohortUsers(ctx, db, "cohort_instructor", cohortId)
+}
+
+func (repo *courseRepository) GetCohortMembers(ctx context.Context, db *sqlx.DB, cohortId string) ([]*db_models.CohortUser, error) {
+	return repo.getCohortUsers(ctx, db, "cohort_member", cohortId)
+}
+
+func (repo *courseRepository) IsCohortInstructor(ctx context.Context, db *sqlx.DB, cohortId string, userId string) (bool, error) {
+	var count int
+
+	query, args, err := sq.Select("COUNT(*)").
+		From("cohort_instructor").
+		Where(sq.Eq{"cohort_id": cohortId}).
+		Where(sq.Eq{"user_id": userId}).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = db.GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CountEnrolledUsers counts the seats taken on the course.
+func (repo *courseRepository) CountEnrolledUsers(ctx context.Context, db *sqlx.DB, courseId string) (int, error) {
+	var count int
+
+	query, args, err := sq.Select("COUNT(*)").
+		From("on_progress_course").
+		Where(sq.Eq{"course_id": courseId}).ToSql()
+	if err != nil {
+		return count, err
+	}
+
+	err = db.GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (repo *courseRepository) querySelectEnrollmentRequest() sq.SelectBuilder {
+	builder := sq.Select(
+		"enrollment_request.course_id",
+		"enrollment_request.user_id",
+		"COALESCE(User.fullname, '') AS fullname",
+		"COALESCE(User.email, '') AS email",
+		"enrollment_request.cohort_id",
+		"enrollment_request.action",
+		"enrollment_request.keep_progress",
+		"enrollment_request.status",
+		"enrollment_request.created_at",
+	).From("enrollment_request").
+		LeftJoin("User ON User.id = enrollment_request.user_id")
+
+	return builder
+}
+
+// GetEnrollmentRequests lists the requests of the course with the given
+// status, oldest first so that waitlisted learners keep their place.
+func (repo *courseRepository) GetEnrollmentRequests(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string, status string) ([]*db_models.EnrollmentRequest, uint64, error) {
+	var requests []*db_models.EnrollmentRequest
+	var count uint64
+
+	selectQuery, args, err := repo.querySelectEnrollmentRequest().
+		Where(sq.Eq{"enrollment_request.course_id": courseId}).
+		Where(sq.Eq{"enrollment_request.status": status}).
+		OrderBy("enrollment_request.created_at").ToSql()
+	if err != nil {
+		return requests, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &requests, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return requests, count, nil
+		}
+		return requests, count, err
+	}
+
+	countQuery, args, err := sq.Select("COUNT(*)").
+		From("enrollment_request").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"status": status}).ToSql()
+	if err != nil {
+		return requests, count, err
+	}
+
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return requests, count, nil
+		}
+		return requests, count, err
+	}
+
+	return requests, count, nil
+}
+
+func (repo *courseRepository) GetEnrollmentRequest(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.EnrollmentRequest, error) {
+	request := new(db_models.EnrollmentRequest)
+
+	query, args, err := repo.querySelectEnrollmentRequest().
+		Where(sq.Eq{"enrollment_request.course_id": courseId}).
+		Where(sq.Eq{"enrollment_request.user_id": userId}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, request, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Enrollment request not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// UpsertEnrollmentRequest stores the request of a user, moving an earlier
+// request of the same user to the back of the queue.
+func (repo *courseRepository) UpsertEnrollmentRequest(ctx context.Context, db *sqlx.DB, request *db_models.EnrollmentRequest) error {
+	query, args, err := sq.Insert("enrollment_request").
+		Columns("course_id", "user_id", "cohort_id", "action", "keep_progress", "status").
+		Values(request.CourseID, request.UserID, request.CohortID, request.Action, request.KeepProgress, request.Status).
+		Suffix("ON DUPLICATE KEY UPDATE cohort_id = VALUES(cohort_id), action = VALUES(action), keep_progress = VALUES(keep_progress), status = VALUES(status), created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) SetEnrollmentRequestStatus(ctx context.Context, db *sqlx.DB, courseId string, userId string, status string) error {
+	query, args, err := sq.Update("enrollment_request").
+		Set("status", status).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *courseRepository) DeleteEnrollmentRequest(ctx context.Context, db *sqlx.DB, courseId string, userId string) error {
+	query, args, err := sq.Delete("enrollment_request").
+		Where(sq.Eq{"course_id": courseId}).
+		Where(sq.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetEnrollmentStats counts the enrollments of the course per day. Learners
+// who completed the course are moved to solved_course, which keeps their
+// start date, so both tables are counted.
+func (repo *courseRepository) GetEnrollmentStats(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.EnrollmentStat, error) {
+	var stats []*db_models.EnrollmentStat
+
+	enrollments := sq.Select("start_date").
+		From("on_progress_course").
+		Where(sq.Eq{"course_id": courseId}).
+		Suffix("UNION ALL SELECT start_date FROM solved_course WHERE course_id = ?", courseId)
+
+	query, args, err := sq.Select(
+		"DATE(start_date) AS date",
+		"COUNT(*) AS enrollments",
+	).FromSelect(enrollments, "enrollment").
+		Where("start_date IS NOT NULL").
+		GroupBy("DATE(start_date)").
+		OrderBy("date").ToSql()
+	if err != nil {
+		return stats, err
+	}
+
+	err = db.SelectContext(ctx, &stats, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return stats, nil
+		}
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// GetMaterialCompletions counts the learners who completed each material of
+// the course along with their average score.
+func (repo *courseRepository) GetMaterialCompletions(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.MaterialCompletion, error) {
+	var completions []*db_models.MaterialCompletion
+
+	query, args, err := sq.Select(
+		"material_id",
+		"COUNT(DISTINCT user_id) AS completed",
+		"COALESCE(AVG(score), 0) AS average_score",
+	).From("user_progress").
+		Where(sq.Eq{"course_id": courseId}).
+		GroupBy("material_id").ToSql()
+	if err != nil {
+		return completions, err
+	}
+
+	err = db.SelectContext(ctx, &completions, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return completions, nil
+		}
+		return completions, err
+	}
+
+	return completions, nil
+}
+
+// GetCompletionDurations returns how long, in seconds, each learner took to
+// complete the course, shortest first.
+func (repo *courseRepository) GetCompletionDurations(ctx context.Context, db *sqlx.DB, courseId string) ([]int64, error) {
+	var durations []int64
+
+	query, args, err := sq.Select("TIMESTAMPDIFF(SECOND, start_date, finish_date) AS duration").
+		From("solved_course").
+		Where(sq.Eq{"course_id": courseId}).
+		Where("start_date IS NOT NULL").
+		Where("finish_date IS NOT NULL").
+		OrderBy("duration").ToSql()
+	if err != nil {
+		return durations, err
+	}
+
+	err = db.SelectContext(ctx, &durations, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return durations, nil
+		}
+		return durations, err
+	}
+
+	return durations, nil
+}
+
+func (repo *courseRepository) IsAssignmentExists(ctx context.Context, db *sqlx.DB, id string) (bool, error) {
+	var count uint64
+
+	query, args, err := sq.Select("count(*)").
+		From("assignment").
+		Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = db.GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
 }
@@ -2,6 +2,9 @@ package course_repository_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
@@ -17,6 +21,7 @@ import (
 
 var (
 	courseId = uuid.New().String()
+	courseId2 = uuid.New().String()
 	
 	creatorId = uuid.New().String()
 
@@ -28,6 +33,8 @@ var (
 	materialId3 = uuid.New().String()
 
 	sectionId = uuid.New().String()
+
+	topicId = uuid.New().String()
 )
 
 func TestCourseRepository_GetCourseById(t *testing.T) {
@@ -84,7 +91,7 @@ func TestCourseRepository_GetCourseById(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta("SELECT id, course_name, description, thumbnail, creator FROM Course WHERE id = ?")
+			selectQuery := regexp.QuoteMeta("SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course WHERE id = ?")
 
 			if tt.mock.res != nil {
 				data := tt.mock.res
@@ -178,7 +185,7 @@ func TestCourseRepository_GetCompletedCourseById(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator FROM Course`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course`)
 			
 			countQuery := regexp.QuoteMeta(`SELECT count(id) FROM Course`)
 
@@ -282,7 +289,7 @@ func TestCourseRepository_GetOnProgressCourseByUserID(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator FROM Course`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course`)
 			
 			countQuery := regexp.QuoteMeta(`SELECT count(id) FROM Course`)
 
@@ -384,7 +391,7 @@ func TestCourseRepository_GetCourseList(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator FROM Course`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course`)
 			
 			countQuery := regexp.QuoteMeta(`SELECT count(id) FROM Course`)
 
@@ -413,7 +420,7 @@ func TestCourseRepository_GetCourseList(t *testing.T) {
 			}
 
 			r := course_repository.NewRepository()
-			got, cou, err := r.GetCourseList(tt.args.ctx, sqlxDB, tt.args.meta)
+			got, cou, err := r.GetCourseList(tt.args.ctx, sqlxDB, tt.args.meta, models.CourseFilter{})
 			assert.Equal(t, tt.wantRes, got, tt.name)
 			assert.Equal(t, tt.wantCount, cou, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
@@ -508,7 +515,7 @@ func TestCourseRepository_GetCourseSyllabusByCourseID(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id FROM course_material`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, position, release_at, release_after_days FROM course_material`)
 
 			if tt.mock.res != nil {
 				rows := sqlmock.NewRows([]string{
@@ -600,7 +607,7 @@ func TestCourseRepository_GetCourseMaterialByCourseIDAndSectionID(t *testing.T)
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, content, content_text FROM course_material`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, content, content_text, metadata, content_html, position, release_at, release_after_days FROM course_material`)
 
 			if tt.mock.res != nil {
 				rows := sqlmock.NewRows([]string{
@@ -726,18 +733,21 @@ func TestCourseRepository_InsertEnrollment(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		args    args
-		mock    mockQuery
-		wantErr error
+		name     string
+		args     args
+		mock     mockQuery
+		enrolled int
+		wantErr  error
 	}{
 		{
 			name: "[InsertEnrollment] Success to insert Enrollment",
 			args: args{
 				context.TODO(),
 				&models.EnrollInput{
-					UserID: userId,
-					CourseID: courseId,
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionEnroll,
+					KeepProgress: true,
 				},
 			},
 			mock: mockQuery{
@@ -749,6 +759,63 @@ func TestCourseRepository_InsertEnrollment(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "[InsertEnrollment] Success to re-enroll without keeping progress",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionReEnroll,
+					KeepProgress: false,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[InsertEnrollment] Success to enroll into a cohort",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionEnroll,
+					KeepProgress: true,
+					CohortID:     courseId2,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[InsertEnrollment] Success to take a free seat of a capped course",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionEnroll,
+					KeepProgress: true,
+					Capacity:     2,
+				},
+			},
+			enrolled: 1,
+			wantErr:  nil,
+		},
+		{
+			name: "[InsertEnrollment] Capped course is full",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionEnroll,
+					KeepProgress: true,
+					Capacity:     2,
+				},
+			},
+			enrolled: 2,
+			wantErr:  er.NewError(fmt.Errorf("%s", "The course is full"), http.StatusConflict, nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -760,13 +827,49 @@ func TestCourseRepository_InsertEnrollment(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			execQuery := regexp.QuoteMeta(`INSERT INTO on_progress_course (user_id,course_id) VALUES (?,?)`)
-
-			mock.ExpectExec(execQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectBegin()
+			if tt.args.input.Capacity > 0 {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM Course WHERE id = ? FOR UPDATE`)).
+					WithArgs(tt.args.input.CourseID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tt.args.input.CourseID))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM on_progress_course WHERE course_id = ?`)).
+					WithArgs(tt.args.input.CourseID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.enrolled))
+			}
+			if tt.wantErr != nil {
+				mock.ExpectRollback()
+
+				r := course_repository.NewRepository()
+				err = r.InsertEnrollment(tt.args.ctx, sqlxDB, tt.args.input)
+				assert.Equal(t, tt.wantErr, err, tt.name)
+				assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+				return
+			}
+			if !tt.args.input.KeepProgress {
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_progress WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM material_progress WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO on_progress_course (user_id,course_id) VALUES (?,?)`)).
+				WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO enrollment_history (user_id,course_id,action,keep_progress) VALUES (?,?,?,?)`)).
+				WithArgs(tt.args.input.UserID, tt.args.input.CourseID, tt.args.input.Action, tt.args.input.KeepProgress).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			if tt.args.input.CohortID != "" {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO cohort_member (cohort_id,course_id,user_id) VALUES (?,?,?) ON DUPLICATE KEY UPDATE cohort_id = VALUES(cohort_id), joined_at = CURRENT_TIMESTAMP`)).
+					WithArgs(tt.args.input.CohortID, tt.args.input.CourseID, tt.args.input.UserID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			mock.ExpectCommit()
 
 			r := course_repository.NewRepository()
 			err = r.InsertEnrollment(tt.args.ctx, sqlxDB, tt.args.input)
 			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
 		})
 	}
 }
@@ -822,7 +925,7 @@ func TestCourseRepository_GetCourseIDByMaterialID(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id FROM course_material`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, position, release_at, release_after_days FROM course_material`)
 
 			if tt.mock.data != nil{
 				rows := sqlmock.NewRows([]string{
@@ -901,7 +1004,7 @@ func TestCourseRepository_StoreUserProgress(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			execQuery := regexp.QuoteMeta(`INSERT INTO user_progress (user_id,course_id,material_id,score) VALUES (?,?,?,?)`)
+			execQuery := regexp.QuoteMeta(`INSERT INTO user_progress (user_id,course_id,material_id,score) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE score = GREATEST(score, VALUES(score))`)
 
 			mock.ExpectExec(execQuery).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -1128,7 +1231,7 @@ func TestCourseRepository_InsertCourseData(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			execQuery := regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator) VALUES (?,?,?,?,?)`)
+			execQuery := regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator,status) VALUES (?,?,?,?,?,?)`)
 
 			mock.ExpectExec(execQuery).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -1200,9 +1303,9 @@ func TestCourseRepository_InsertCourse(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			execCourseQuery := regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator) VALUES (?,?,?,?,?)`)
+			execCourseQuery := regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator,status) VALUES (?,?,?,?,?,?)`)
 
-			execMaterialQuery := regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text) VALUES (?,?,?,?,?,?,?)`)
+			execMaterialQuery := regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text,metadata,content_html,position,release_at,release_after_days) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`)
 
 			mock.ExpectExec(execCourseQuery).WillReturnResult(sqlmock.NewResult(1, 1))
 			
@@ -1274,7 +1377,7 @@ func TestCourseRepository_GetMaterialByID(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, content, content_text FROM course_material`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_id, name, type, section_id, content, content_text, metadata, content_html, position, release_at, release_after_days FROM course_material`)
 
 			if tt.mock.res != nil{
 				rows := sqlmock.NewRows([]string{
@@ -1366,7 +1469,7 @@ func TestCourseRepository_InsertCourseMaterial(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			execQuery := regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text) VALUES (?,?,?,?,?,?,?)`)
+			execQuery := regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text,metadata,content_html,position,release_at,release_after_days) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`)
 
 			mock.ExpectExec(execQuery).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -1436,7 +1539,7 @@ func TestCourseRepository_GetCourseByCreatorID(t *testing.T) {
 			defer db.Close()
 			sqlxDB := sqlx.NewDb(db, "sqlmock")
 
-			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator FROM Course WHERE creator = ?`)
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course WHERE (creator = ? OR id IN (SELECT course_id FROM course_collaborator WHERE user_id = ? AND status = ?))`)
 
 			if tt.mock.res != nil{
 				rows := sqlmock.NewRows([]string{
@@ -1462,3 +1565,1684 @@ func TestCourseRepository_GetCourseByCreatorID(t *testing.T) {
 		})
 	}
 }
+
+func TestCourseRepository_UpdateCourseData(t *testing.T) {
+
+	type args struct {
+		ctx   context.Context
+		input *db_models.Course
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[UpdateCourseData] Success to update course data.",
+			args: args{
+				context.TODO(),
+				&db_models.Course{
+					ID:          courseId,
+					CourseName:  "Introduction to Go",
+					Description: "",
+					Thumbnail:   "",
+					Creator:     creatorId,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			execQuery := regexp.QuoteMeta(`UPDATE Course SET course_name = ?, description = ?, thumbnail = ? WHERE id = ?`)
+
+			mock.ExpectExec(execQuery).
+				WithArgs(tt.args.input.CourseName, tt.args.input.Description, tt.args.input.Thumbnail, tt.args.input.ID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			r := course_repository.NewRepository()
+			err = r.UpdateCourseData(tt.args.ctx, sqlxDB, tt.args.input)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_DeleteCourseMaterials(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		ids []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[DeleteCourseMaterials] Success to delete section and its materials.",
+			args: args{
+				context.TODO(),
+				[]string{materialId, sectionId},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_progress WHERE material_id IN (?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM material_progress WHERE material_id IN (?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_material WHERE id IN (?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.DeleteCourseMaterials(tt.args.ctx, sqlxDB, tt.args.ids)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_DeleteCourse(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[DeleteCourse] Success to delete course.",
+			args: args{
+				context.TODO(),
+				courseId,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_progress WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM material_progress WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM on_progress_course WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM solved_course WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_material WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_prerequisite WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM learning_path_course WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_collaborator WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_review WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM discussion WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM announcement_read WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_announcement WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM cohort_member WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM cohort_instructor WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM cohort WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM enrollment_request WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_topic WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM enrollment_history WHERE course_id = ?`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_prerequisite WHERE prerequisite_id = ?`)).
+				WithArgs(tt.args.id).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM Course WHERE id = ?`)).
+				WithArgs(tt.args.id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.DeleteCourse(tt.args.ctx, sqlxDB, tt.args.id)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetNextMaterialPosition(t *testing.T) {
+
+	type args struct {
+		ctx       context.Context
+		courseId  string
+		sectionId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    int
+		want    int
+		wantErr error
+	}{
+		{
+			name: "[GetNextMaterialPosition] Success to get next position in section.",
+			args: args{
+				context.TODO(),
+				courseId,
+				sectionId,
+			},
+			mock:    3,
+			want:    3,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			selectQuery := regexp.QuoteMeta(`SELECT COALESCE(MAX(position) + 1, 0) FROM course_material WHERE course_id = ? AND section_id = ?`)
+
+			rows := sqlmock.NewRows([]string{"position"}).AddRow(tt.mock)
+			mock.ExpectQuery(selectQuery).WithArgs(tt.args.courseId, tt.args.sectionId).WillReturnRows(rows)
+
+			r := course_repository.NewRepository()
+			got, err := r.GetNextMaterialPosition(tt.args.ctx, sqlxDB, tt.args.courseId, tt.args.sectionId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_UpdateMaterialPositions(t *testing.T) {
+
+	type args struct {
+		ctx       context.Context
+		positions []*db_models.MaterialPosition
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[UpdateMaterialPositions] Success to reorder materials.",
+			args: args{
+				context.TODO(),
+				[]*db_models.MaterialPosition{
+					{ID: sectionId, SectionID: "", Position: 0},
+					{ID: materialId, SectionID: sectionId, Position: 0},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			execQuery := regexp.QuoteMeta(`UPDATE course_material SET section_id = ?, position = ? WHERE id = ?`)
+
+			mock.ExpectBegin()
+			for _, item := range tt.args.positions {
+				mock.ExpectExec(execQuery).
+					WithArgs(item.SectionID, item.Position, item.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.UpdateMaterialPositions(tt.args.ctx, sqlxDB, tt.args.positions)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_UpdateCourseStatus(t *testing.T) {
+
+	type args struct {
+		ctx   context.Context
+		input *models.CourseStatusUpdate
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[UpdateCourseStatus] Success to publish course.",
+			args: args{
+				context.TODO(),
+				&models.CourseStatusUpdate{
+					Id:     courseId,
+					Status: models.CourseStatusPublished,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[UpdateCourseStatus] Invalid course status.",
+			args: args{
+				context.TODO(),
+				&models.CourseStatusUpdate{
+					Id:     courseId,
+					Status: "deleted",
+				},
+			},
+			wantErr: errors.New("Invalid Status"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			if tt.wantErr == nil {
+				execQuery := regexp.QuoteMeta(`UPDATE Course SET status = ? WHERE id = ?`)
+				mock.ExpectExec(execQuery).
+					WithArgs(tt.args.input.Status, tt.args.input.Id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			r := course_repository.NewRepository()
+			err = r.UpdateCourseStatus(tt.args.ctx, sqlxDB, tt.args.input)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetCourseListFiltered(t *testing.T) {
+
+	type args struct {
+		ctx    context.Context
+		meta   *pagination.Meta
+		filter models.CourseFilter
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		order     string
+		wantCount uint64
+		wantErr   error
+	}{
+		{
+			name: "[GetCourseList] Success to filter courses by topic, creator and query.",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Limit: 10, Page: 1},
+				models.CourseFilter{
+					Topic:   topicId,
+					Creator: creatorId,
+					Query:   "golang",
+				},
+			},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "[GetCourseList] Success to sort filtered courses by rating.",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Limit: 10, Page: 1},
+				models.CourseFilter{
+					Topic:   topicId,
+					Creator: creatorId,
+					Query:   "golang",
+					Sort:    models.CourseSortRating,
+				},
+			},
+			order:     ` ORDER BY (SELECT COALESCE(AVG(rating), 0) FROM course_review WHERE course_review.course_id = Course.id AND hidden = false) DESC`,
+			wantCount: 1,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			where := `WHERE id IN (SELECT course_id FROM course_topic WHERE topic_id = ?) AND creator = ? AND (course_name LIKE ? OR description LIKE ?) AND status = ?`
+			selectQuery := regexp.QuoteMeta(`SELECT id, course_name, description, thumbnail, creator, status, sequential, min_score, enrollment_mode, enrollment_key, capacity FROM Course ` + where + tt.order + ` limit 0,10`)
+			countQuery := regexp.QuoteMeta(`SELECT count(id) FROM Course ` + where)
+
+			rows := sqlmock.NewRows([]string{"id", "course_name", "description", "thumbnail", "creator", "status"}).
+				AddRow(courseId, "Golang", "", "", creatorId, models.CourseStatusPublished)
+
+			mock.ExpectQuery(selectQuery).
+				WithArgs(topicId, creatorId, "%golang%", "%golang%", models.CourseStatusPublished).
+				WillReturnRows(rows)
+			mock.ExpectQuery(countQuery).
+				WithArgs(topicId, creatorId, "%golang%", "%golang%", models.CourseStatusPublished).
+				WillReturnRows(sqlmock.NewRows([]string{"count(id)"}).AddRow(tt.wantCount))
+
+			r := course_repository.NewRepository()
+			got, cou, err := r.GetCourseList(tt.args.ctx, sqlxDB, tt.args.meta, tt.args.filter)
+			assert.Equal(t, 1, len(got), tt.name)
+			assert.Equal(t, tt.wantCount, cou, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_SetCourseTopics(t *testing.T) {
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+		topicIds []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[SetCourseTopics] Success to replace course topics.",
+			args: args{
+				context.TODO(),
+				courseId,
+				[]string{topicId},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[SetCourseTopics] Success to clear course topics.",
+			args: args{
+				context.TODO(),
+				courseId,
+				[]string{},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_topic WHERE course_id = ?`)).
+				WithArgs(tt.args.courseId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if len(tt.args.topicIds) > 0 {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_topic (course_id,topic_id) VALUES (?,?)`)).
+					WithArgs(tt.args.courseId, tt.args.topicIds[0]).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.SetCourseTopics(tt.args.ctx, sqlxDB, tt.args.courseId, tt.args.topicIds)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_CompleteCourse(t *testing.T) {
+
+	type args struct {
+		ctx      context.Context
+		userId   string
+		courseId string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		enrolled int
+		average  float64
+		wantErr  error
+	}{
+		{
+			name: "[CompleteCourse] Success to complete course.",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			enrolled: 1,
+			average:  87.5,
+			wantErr:  nil,
+		},
+		{
+			name: "[CompleteCourse] Course is already completed.",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			enrolled: 0,
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM on_progress_course WHERE user_id = ? AND course_id = ? FOR UPDATE`)).
+				WithArgs(tt.args.userId, tt.args.courseId).
+				WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(tt.enrolled))
+
+			if tt.enrolled > 0 {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(AVG(best.score), 0) FROM (SELECT MAX(score) AS score FROM user_progress WHERE user_id = ? AND course_id = ? GROUP BY material_id) AS best`)).
+					WithArgs(tt.args.userId, tt.args.courseId).
+					WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(tt.average))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO solved_course (user_id,course_id,start_date,finish_date,final_score) SELECT user_id, course_id, start_date, NOW(), ? FROM on_progress_course WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(88, tt.args.userId, tt.args.courseId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM on_progress_course WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(tt.args.userId, tt.args.courseId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO enrollment_history (user_id,course_id,action,keep_progress) VALUES (?,?,?,?)`)).
+					WithArgs(tt.args.userId, tt.args.courseId, models.EnrollmentActionComplete, true).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			r := course_repository.NewRepository()
+			err = r.CompleteCourse(tt.args.ctx, sqlxDB, tt.args.userId, tt.args.courseId)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_DeleteEnrollment(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *models.EnrollInput
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[DeleteEnrollment] Success to unenroll and keep progress",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionUnenroll,
+					KeepProgress: true,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[DeleteEnrollment] Success to unenroll and drop progress",
+			args: args{
+				context.TODO(),
+				&models.EnrollInput{
+					UserID:       userId,
+					CourseID:     courseId,
+					Action:       models.EnrollmentActionUnenroll,
+					KeepProgress: false,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM on_progress_course WHERE user_id = ? AND course_id = ?`)).
+				WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if !tt.args.input.KeepProgress {
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_progress WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM material_progress WHERE user_id = ? AND course_id = ?`)).
+					WithArgs(tt.args.input.UserID, tt.args.input.CourseID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO enrollment_history (user_id,course_id,action,keep_progress) VALUES (?,?,?,?)`)).
+				WithArgs(tt.args.input.UserID, tt.args.input.CourseID, tt.args.input.Action, tt.args.input.KeepProgress).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.DeleteEnrollment(tt.args.ctx, sqlxDB, tt.args.input)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetEnrollmentHistoryByUserID(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		meta   *pagination.Meta
+		userId string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantRes   []*db_models.EnrollmentHistory
+		wantCount uint64
+		wantErr   error
+	}{
+		{
+			name: "[GetEnrollmentHistoryByUserID] Success to get enrollment history by user ID",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{
+					Limit: 10,
+					Page:  1,
+				},
+				userId,
+			},
+			wantRes: []*db_models.EnrollmentHistory{
+				{
+					UserID:       userId,
+					CourseID:     courseId,
+					CourseName:   "Course 1",
+					Action:       models.EnrollmentActionUnenroll,
+					KeepProgress: true,
+					CreatedAt:    "2022-04-02 10:00:00",
+				},
+				{
+					UserID:       userId,
+					CourseID:     courseId,
+					CourseName:   "Course 1",
+					Action:       models.EnrollmentActionEnroll,
+					KeepProgress: true,
+					CreatedAt:    "2022-04-01 10:00:00",
+				},
+			},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			rows := sqlmock.NewRows([]string{"user_id", "course_id", "course_name", "action", "keep_progress", "created_at"})
+			for _, row := range tt.wantRes {
+				rows.AddRow(row.UserID, row.CourseID, row.CourseName, row.Action, row.KeepProgress, row.CreatedAt)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT enrollment_history.user_id, enrollment_history.course_id, COALESCE(Course.course_name, '') AS course_name, enrollment_history.action, enrollment_history.keep_progress, enrollment_history.created_at FROM enrollment_history LEFT JOIN Course ON Course.id = enrollment_history.course_id WHERE enrollment_history.user_id = ? ORDER BY enrollment_history._id DESC limit 0,10`)).
+				WithArgs(tt.args.userId).
+				WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM enrollment_history WHERE user_id = ?`)).
+				WithArgs(tt.args.userId).
+				WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(tt.wantCount))
+
+			r := course_repository.NewRepository()
+			got, count, err := r.GetEnrollmentHistoryByUserID(tt.args.ctx, sqlxDB, tt.args.meta, tt.args.userId)
+			assert.Equal(t, tt.wantRes, got, tt.name)
+			assert.Equal(t, tt.wantCount, count, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetMissingPrerequisites(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		userId   string
+		courseId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []*db_models.Course
+		wantErr error
+	}{
+		{
+			name: "[GetMissingPrerequisites] Success to get prerequisites which are not completed",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			want: []*db_models.Course{
+				{
+					ID:         courseId2,
+					CourseName: "Introduction to Algorithm",
+					Creator:    creatorId,
+					Status:     models.CourseStatusPublished,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			rows := sqlmock.NewRows([]string{"id", "course_name", "description", "thumbnail", "creator", "status"})
+			for _, row := range tt.want {
+				rows.AddRow(row.ID, row.CourseName, row.Description, row.Thumbnail, row.Creator, row.Status)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT Course.id, Course.course_name, Course.description, Course.thumbnail, Course.creator, Course.status FROM course_prerequisite JOIN Course ON Course.id = course_prerequisite.prerequisite_id WHERE course_prerequisite.course_id = ? AND course_prerequisite.prerequisite_id NOT IN (SELECT course_id FROM solved_course WHERE user_id = ?) ORDER BY Course.course_name`)).
+				WithArgs(tt.args.courseId, tt.args.userId).
+				WillReturnRows(rows)
+
+			r := course_repository.NewRepository()
+			got, err := r.GetMissingPrerequisites(tt.args.ctx, sqlxDB, tt.args.userId, tt.args.courseId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_SetCoursePrerequisites(t *testing.T) {
+	type args struct {
+		ctx             context.Context
+		courseId        string
+		prerequisiteIds []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[SetCoursePrerequisites] Success to set course prerequisites",
+			args: args{
+				context.TODO(),
+				courseId,
+				[]string{courseId2},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[SetCoursePrerequisites] Success to clear course prerequisites",
+			args: args{
+				context.TODO(),
+				courseId,
+				[]string{},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM course_prerequisite WHERE course_id = ?`)).
+				WithArgs(tt.args.courseId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if len(tt.args.prerequisiteIds) > 0 {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_prerequisite (course_id,prerequisite_id) VALUES (?,?)`)).
+					WithArgs(tt.args.courseId, tt.args.prerequisiteIds[0]).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.SetCoursePrerequisites(tt.args.ctx, sqlxDB, tt.args.courseId, tt.args.prerequisiteIds)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_UpdateCourseAccess(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		course *db_models.Course
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[UpdateCourseAccess] Success to enable sequential mode",
+			args: args{
+				context.TODO(),
+				&db_models.Course{ID: courseId, Sequential: true, MinScore: 70},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE Course SET sequential = ?, min_score = ? WHERE id = ?`)).
+				WithArgs(tt.args.course.Sequential, tt.args.course.MinScore, tt.args.course.ID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			r := course_repository.NewRepository()
+			err = r.UpdateCourseAccess(tt.args.ctx, sqlxDB, tt.args.course)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetEnrollmentStartDate(t *testing.T) {
+	startDate := "2022-04-01 08:00:00"
+
+	type args struct {
+		ctx      context.Context
+		userId   string
+		courseId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		rows    *sqlmock.Rows
+		want    *string
+		wantErr error
+	}{
+		{
+			name: "[GetEnrollmentStartDate] Success to get enrollment start date",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			rows:    sqlmock.NewRows([]string{"start_date"}).AddRow(startDate),
+			want:    &startDate,
+			wantErr: nil,
+		},
+		{
+			name: "[GetEnrollmentStartDate] Return nil when user is not enrolled",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			rows:    sqlmock.NewRows([]string{"start_date"}),
+			want:    nil,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT start_date FROM on_progress_course WHERE user_id = ? AND course_id = ?`)).
+				WithArgs(tt.args.userId, tt.args.courseId).
+				WillReturnRows(tt.rows)
+
+			r := course_repository.NewRepository()
+			got, err := r.GetEnrollmentStartDate(tt.args.ctx, sqlxDB, tt.args.userId, tt.args.courseId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_CloneCourse(t *testing.T) {
+	cloneId := uuid.New().String()
+	cloneSectionId := uuid.New().String()
+	cloneMaterialId := uuid.New().String()
+
+	type args struct {
+		ctx   context.Context
+		clone *db_models.CourseClone
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[CloneCourse] Success to clone course with its syllabus and assignments",
+			args: args{
+				context.TODO(),
+				&db_models.CourseClone{
+					SourceID: courseId,
+					Course: db_models.Course{
+						ID:         cloneId,
+						CourseName: "Introduction to Programming",
+						Creator:    userId,
+						Status:     models.CourseStatusDraft,
+					},
+					Materials: []db_models.Material{
+						{ID: cloneSectionId, CourseID: cloneId, Name: "Week 1", Type: "section"},
+						{ID: cloneMaterialId, CourseID: cloneId, Name: "Quiz 1", Type: "assignment", SectionID: cloneSectionId},
+					},
+					Assignments: []db_models.ClonedAssignment{
+						{SourceID: materialId, ID: cloneMaterialId},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator,status,sequential,min_score,enrollment_mode,enrollment_key,capacity) VALUES (?,?,?,?,?,?,?,?,?,?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text,metadata,content_html,position,release_at,release_after_days) VALUES (?,?,?,?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?,?,?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assignment (id,creator,title,duration,topic,difficulty) SELECT ?, ?, title, duration, topic, difficulty FROM assignment WHERE id = ?`)).
+				WithArgs(cloneMaterialId, userId, materialId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assignment_problem (assignment_id,problem_id) SELECT ?, problem_id FROM assignment_problem WHERE assignment_id = ?`)).
+				WithArgs(cloneMaterialId, materialId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assignment_problems (assignment_id,problem_id) SELECT ?, problem_id FROM assignment_problems WHERE assignment_id = ?`)).
+				WithArgs(cloneMaterialId, materialId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_topic (course_id,topic_id) SELECT ?, topic_id FROM course_topic WHERE course_id = ?`)).
+				WithArgs(cloneId, courseId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_prerequisite (course_id,prerequisite_id) SELECT ?, prerequisite_id FROM course_prerequisite WHERE course_id = ?`)).
+				WithArgs(cloneId, courseId).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			r := course_repository.NewRepository()
+			err = r.CloneCourse(tt.args.ctx, sqlxDB, tt.args.clone)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_ImportCourse(t *testing.T) {
+	importId := uuid.New().String()
+	importSectionId := uuid.New().String()
+	importMaterialId := uuid.New().String()
+	importProblemId := uuid.New().String()
+
+	type args struct {
+		ctx   context.Context
+		input *db_models.CourseImport
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		failAt  string
+		wantErr error
+	}{
+		{
+			name: "[ImportCourse] Success to import course with its problems and assignments",
+			args: args{
+				context.TODO(),
+				&db_models.CourseImport{
+					Course: db_models.Course{
+						ID:         importId,
+						CourseName: "Introduction to Programming",
+						Creator:    userId,
+						Status:     models.CourseStatusDraft,
+						Sequential: true,
+						MinScore:   60,
+					},
+					Materials: []db_models.Material{
+						{ID: importSectionId, CourseID: importId, Name: "Week 1", Type: "section"},
+						{ID: importMaterialId, CourseID: importId, Name: "Quiz 1", Type: "assignment", SectionID: importSectionId},
+					},
+					Problems: []db_models.ProblemCandidate{
+						{ID: importProblemId, Creator: userId, Title: "Sum", Type: "isian", Status: "requested", Detail: `{"question":"1+1"}`},
+					},
+					Assignments: []db_models.AssignmentCreation{
+						{
+							Desc:     db_models.Assignment{ID: importMaterialId, Creator: userId, Title: "Quiz 1", Duration: 600},
+							Problems: []db_models.AssignmentProblem{{AssignmentID: importMaterialId, ProblemID: importProblemId}},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ImportCourse] Roll back when the course cannot be stored",
+			args: args{
+				context.TODO(),
+				&db_models.CourseImport{
+					Course: db_models.Course{ID: importId, CourseName: "Introduction to Programming", Creator: userId},
+					Problems: []db_models.ProblemCandidate{
+						{ID: importProblemId, Creator: userId, Title: "Sum", Type: "isian", Status: "requested", Detail: `{"question":"1+1"}`},
+					},
+				},
+			},
+			failAt:  "Course",
+			wantErr: errors.New("duplicate entry"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO Candidate_Problem (id,creator,title,type,topic,difficulty,status) VALUES (?,?,?,?,?,?,?)`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO Detail_Problem (id,detail) VALUES (?,?)`)).
+				WithArgs(importProblemId, `{"question":"1+1"}`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if len(tt.args.input.Assignments) > 0 {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assignment (id,creator,title,duration,topic,difficulty) VALUES (?,?,?,?,?,?)`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assignment_problem (assignment_id,problem_id) VALUES (?,?)`)).
+					WithArgs(importMaterialId, importProblemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			courseInsert := mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO Course (id,course_name,description,thumbnail,creator,status,sequential,min_score) VALUES (?,?,?,?,?,?,?,?)`))
+			if tt.failAt == "Course" {
+				courseInsert.WillReturnError(tt.wantErr)
+				mock.ExpectRollback()
+			} else {
+				courseInsert.WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_material (id,course_id,name,type,section_id,content,content_text,metadata,content_html,position,release_at,release_after_days) VALUES (?,?,?,?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?,?,?,?)`)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			r := course_repository.NewRepository()
+			err = r.ImportCourse(tt.args.ctx, sqlxDB, tt.args.input)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetCollaboratorRole(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		courseId string
+		userId   string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		rows    *sqlmock.Rows
+		want    string
+		wantErr error
+	}{
+		{
+			name: "[GetCollaboratorRole] Success to get collaborator role",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			rows:    sqlmock.NewRows([]string{"role"}).AddRow(models.CourseRoleEditor),
+			want:    models.CourseRoleEditor,
+			wantErr: nil,
+		},
+		{
+			name: "[GetCollaboratorRole] Return empty role when user is not a collaborator",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			rows:    sqlmock.NewRows([]string{"role"}),
+			want:    "",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT role FROM course_collaborator WHERE course_id = ? AND user_id = ? AND status = ?`)).
+				WithArgs(tt.args.courseId, tt.args.userId, models.CollaboratorStatusAccepted).
+				WillReturnRows(tt.rows)
+
+			r := course_repository.NewRepository()
+			got, err := r.GetCollaboratorRole(tt.args.ctx, sqlxDB, tt.args.courseId, tt.args.userId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_GetCollaborator(t *testing.T) {
+	createdAt := "2022-04-01 08:00:00"
+	columns := []string{"course_id", "course_name", "user_id", "fullname", "email", "role", "status", "invited_by", "created_at"}
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+		userId   string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		rows    *sqlmock.Rows
+		want    *db_models.CourseCollaborator
+		wantErr error
+	}{
+		{
+			name: "[GetCollaborator] Success to get collaborator",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			rows: sqlmock.NewRows(columns).
+				AddRow(courseId, "Golang", userId, "John Doe", "john@mail.com", models.CourseRoleEditor, models.CollaboratorStatusPending, creatorId, createdAt),
+			want: &db_models.CourseCollaborator{
+				CourseID:   courseId,
+				CourseName: "Golang",
+				UserID:     userId,
+				Fullname:   "John Doe",
+				Email:      "john@mail.com",
+				Role:       models.CourseRoleEditor,
+				Status:     models.CollaboratorStatusPending,
+				InvitedBy:  creatorId,
+				CreatedAt:  createdAt,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCollaborator] Collaborator not found",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			rows:    sqlmock.NewRows(columns),
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Collaborator not found"), http.StatusNotFound, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM course_collaborator LEFT JOIN Course ON Course.id = course_collaborator.course_id LEFT JOIN User ON User.id = course_collaborator.user_id WHERE course_collaborator.course_id = ? AND course_collaborator.user_id = ?`)).
+				WithArgs(tt.args.courseId, tt.args.userId).
+				WillReturnRows(tt.rows)
+
+			r := course_repository.NewRepository()
+			got, err := r.GetCollaborator(tt.args.ctx, sqlxDB, tt.args.courseId, tt.args.userId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestCourseRepository_InsertCollaborator(t *testing.T) {
+	collaborator := &db_models.CourseCollaborator{
+		CourseID:  courseId,
+		UserID:    userId,
+		Role:      models.CourseRoleTeachingAssistant,
+		Status:    models.CollaboratorStatusPending,
+		InvitedBy: creatorId,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_collaborator (course_id,user_id,role,status,invited_by) VALUES (?,?,?,?,?)`)).
+		WithArgs(courseId, userId, models.CourseRoleTeachingAssistant, models.CollaboratorStatusPending, creatorId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := course_repository.NewRepository()
+	err = r.InsertCollaborator(context.TODO(), sqlxDB, collaborator)
+	assert.Nil(t, err, "[InsertCollaborator] Success to insert collaborator")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[InsertCollaborator] Success to insert collaborator")
+}
+
+func TestCourseRepository_GetCourseRatings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT course_id, AVG(rating) AS average, COUNT(*) AS count FROM course_review WHERE course_id IN (?,?) AND hidden = ? GROUP BY course_id`)).
+		WithArgs(courseId, courseId2, false).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "average", "count"}).AddRow(courseId, 4.5, 2))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetCourseRatings(context.TODO(), sqlxDB, []string{courseId, courseId2})
+	assert.Equal(t, []*db_models.CourseRating{{CourseID: courseId, Average: 4.5, Count: 2}}, got, "[GetCourseRatings] Success to get course ratings")
+	assert.Nil(t, err, "[GetCourseRatings] Success to get course ratings")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetCourseRatings] Success to get course ratings")
+}
+
+func TestCourseRepository_UpsertCourseReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO course_review (course_id,user_id,rating,review) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE rating = VALUES(rating), review = VALUES(review), updated_at = CURRENT_TIMESTAMP`)).
+		WithArgs(courseId, userId, 4, "Great course").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := course_repository.NewRepository()
+	err = r.UpsertCourseReview(context.TODO(), sqlxDB, &db_models.CourseReview{
+		CourseID: courseId,
+		UserID:   userId,
+		Rating:   4,
+		Review:   "Great course",
+	})
+	assert.Nil(t, err, "[UpsertCourseReview] Success to store course review")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[UpsertCourseReview] Success to store course review")
+}
+
+func TestCourseRepository_MarkAnnouncementRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	announcementId := uuid.New().String()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO announcement_read (announcement_id,course_id,user_id) VALUES (?,?,?)`)).
+		WithArgs(announcementId, courseId, userId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := course_repository.NewRepository()
+	err = r.MarkAnnouncementRead(context.TODO(), sqlxDB, &db_models.CourseAnnouncement{ID: announcementId, CourseID: courseId}, userId)
+	assert.Nil(t, err, "[MarkAnnouncementRead] Success to mark announcement as read")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[MarkAnnouncementRead] Success to mark announcement as read")
+}
+
+func TestCourseRepository_GetUnreadAnnouncements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT Course.id AS course_id, Course.course_name, COUNT(course_announcement.id) AS unread FROM on_progress_course JOIN Course ON Course.id = on_progress_course.course_id JOIN course_announcement ON course_announcement.course_id = on_progress_course.course_id WHERE on_progress_course.user_id = ? AND course_announcement.id NOT IN (SELECT announcement_id FROM announcement_read WHERE user_id = ?) GROUP BY Course.id, Course.course_name`)).
+		WithArgs(userId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "course_name", "unread"}).AddRow(courseId, "Algorithms", 2))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetUnreadAnnouncements(context.TODO(), sqlxDB, userId)
+	assert.Nil(t, err, "[GetUnreadAnnouncements] Success to count unread announcements")
+	assert.Equal(t, []*db_models.UnreadAnnouncement{{CourseID: courseId, CourseName: "Algorithms", Unread: 2}}, got, "[GetUnreadAnnouncements] Success to count unread announcements")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetUnreadAnnouncements] Success to count unread announcements")
+}
+
+func TestCourseRepository_GetCourseAnnouncement(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	announcementId := uuid.New().String()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT course_announcement.id, course_announcement.course_id, course_announcement.author_id, COALESCE(User.username, '') AS author, course_announcement.title, course_announcement.content, EXISTS (SELECT 1 FROM announcement_read WHERE announcement_read.announcement_id = course_announcement.id AND announcement_read.user_id = ?) AS is_read, course_announcement.created_at, course_announcement.updated_at FROM course_announcement LEFT JOIN User ON User.id = course_announcement.author_id WHERE course_announcement.id = ?`)).
+		WithArgs("", announcementId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetCourseAnnouncement(context.TODO(), sqlxDB, announcementId)
+	assert.Nil(t, got, "[GetCourseAnnouncement] Announcement not found")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "Announcement not found"), http.StatusNotFound, nil), err, "[GetCourseAnnouncement] Announcement not found")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetCourseAnnouncement] Announcement not found")
+}
+
+func TestCourseRepository_GetCohortByJoinCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, course_id, name, join_code, start_date, end_date FROM cohort WHERE join_code = ?`)).
+		WithArgs("ABCD2345").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetCohortByJoinCode(context.TODO(), sqlxDB, "ABCD2345")
+	assert.Nil(t, got, "[GetCohortByJoinCode] Cohort not found")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "Cohort not found"), http.StatusNotFound, nil), err, "[GetCohortByJoinCode] Cohort not found")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetCohortByJoinCode] Cohort not found")
+}
+
+func TestCourseRepository_InsertCohort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	cohort := &db_models.Cohort{
+		ID:        uuid.New().String(),
+		CourseID:  courseId,
+		Name:      "Class A",
+		JoinCode:  "ABCD2345",
+		StartDate: "2022-08-01 00:00:00",
+		EndDate:   "2022-12-01 00:00:00",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO cohort (id,course_id,name,join_code,start_date,end_date) VALUES (?,?,?,?,?,?)`)).
+		WithArgs(cohort.ID, cohort.CourseID, cohort.Name, cohort.JoinCode, cohort.StartDate, cohort.EndDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM cohort_instructor WHERE cohort_id = ?`)).
+		WithArgs(cohort.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO cohort_instructor (cohort_id,course_id,user_id) VALUES (?,?,?),(?,?,?)`)).
+		WithArgs(cohort.ID, courseId, userId, cohort.ID, courseId, userId2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	r := course_repository.NewRepository()
+	err = r.InsertCohort(context.TODO(), sqlxDB, cohort, []string{userId, userId2})
+	assert.Nil(t, err, "[InsertCohort] Success to insert cohort")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[InsertCohort] Success to insert cohort")
+}
+
+func TestCourseRepository_CountEnrolledUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM on_progress_course WHERE course_id = ?`)).
+		WithArgs(courseId).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	r := course_repository.NewRepository()
+	got, err := r.CountEnrolledUsers(context.TODO(), sqlxDB, courseId)
+	assert.Nil(t, err, "[CountEnrolledUsers] Success to count enrolled users")
+	assert.Equal(t, 3, got, "[CountEnrolledUsers] Success to count enrolled users")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[CountEnrolledUsers] Success to count enrolled users")
+}
+
+func TestCourseRepository_GetEnrollmentRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT enrollment_request.course_id, enrollment_request.user_id, COALESCE(User.fullname, '') AS fullname, COALESCE(User.email, '') AS email, enrollment_request.cohort_id, enrollment_request.action, enrollment_request.keep_progress, enrollment_request.status, enrollment_request.created_at FROM enrollment_request LEFT JOIN User ON User.id = enrollment_request.user_id WHERE enrollment_request.course_id = ? AND enrollment_request.user_id = ?`)).
+		WithArgs(courseId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetEnrollmentRequest(context.TODO(), sqlxDB, courseId, userId)
+	assert.Nil(t, got, "[GetEnrollmentRequest] Enrollment request not found")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "Enrollment request not found"), http.StatusNotFound, nil), err, "[GetEnrollmentRequest] Enrollment request not found")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetEnrollmentRequest] Enrollment request not found")
+}
+
+func TestCourseRepository_UpsertEnrollmentRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	request := &db_models.EnrollmentRequest{
+		CourseID:     courseId,
+		UserID:       userId,
+		Action:       models.EnrollmentActionReEnroll,
+		KeepProgress: false,
+		Status:       "waitlisted",
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO enrollment_request (course_id,user_id,cohort_id,action,keep_progress,status) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE cohort_id = VALUES(cohort_id), action = VALUES(action), keep_progress = VALUES(keep_progress), status = VALUES(status), created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP`)).
+		WithArgs(courseId, userId, "", models.EnrollmentActionReEnroll, false, "waitlisted").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := course_repository.NewRepository()
+	err = r.UpsertEnrollmentRequest(context.TODO(), sqlxDB, request)
+	assert.Nil(t, err, "[UpsertEnrollmentRequest] Success to store enrollment request")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[UpsertEnrollmentRequest] Success to store enrollment request")
+}
+
+func TestCourseRepository_GetEnrollmentStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE(start_date) AS date, COUNT(*) AS enrollments FROM (SELECT start_date FROM on_progress_course WHERE course_id = ? UNION ALL SELECT start_date FROM solved_course WHERE course_id = ?) AS enrollment WHERE start_date IS NOT NULL GROUP BY DATE(start_date) ORDER BY date`)).
+		WithArgs(courseId, courseId).
+		WillReturnRows(sqlmock.NewRows([]string{"date", "enrollments"}).
+			AddRow("2022-05-01", 3).
+			AddRow("2022-05-03", 1))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetEnrollmentStats(context.TODO(), sqlxDB, courseId)
+	assert.Nil(t, err, "[GetEnrollmentStats] Success to count enrollments per day")
+	assert.Equal(t, []*db_models.EnrollmentStat{
+		{Date: "2022-05-01", Enrollments: 3},
+		{Date: "2022-05-03", Enrollments: 1},
+	}, got, "[GetEnrollmentStats] Success to count enrollments per day")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetEnrollmentStats] Success to count enrollments per day")
+}
+
+func TestCourseRepository_GetCompletionDurations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT TIMESTAMPDIFF(SECOND, start_date, finish_date) AS duration FROM solved_course WHERE course_id = ? AND start_date IS NOT NULL AND finish_date IS NOT NULL ORDER BY duration`)).
+		WithArgs(courseId).
+		WillReturnRows(sqlmock.NewRows([]string{"duration"}).AddRow(86400).AddRow(172800))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetCompletionDurations(context.TODO(), sqlxDB, courseId)
+	assert.Nil(t, err, "[GetCompletionDurations] Success to get completion durations")
+	assert.Equal(t, []int64{86400, 172800}, got, "[GetCompletionDurations] Success to get completion durations")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetCompletionDurations] Success to get completion durations")
+}
+
+func TestCourseRepository_IsAssignmentExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM assignment WHERE id = ?`)).
+		WithArgs(courseId).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+
+	r := course_repository.NewRepository()
+	got, err := r.IsAssignmentExists(context.TODO(), sqlxDB, courseId)
+	assert.Nil(t, err, "[IsAssignmentExists] Assignment exists")
+	assert.True(t, got, "[IsAssignmentExists] Assignment exists")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[IsAssignmentExists] Assignment exists")
+}
+
+func TestCourseRepository_GetMaterialProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, course_id, material_id, time_spent, percentage, last_position, completed, updated_at FROM material_progress WHERE material_id = ? AND user_id = ?`)).
+		WithArgs(courseId2, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	r := course_repository.NewRepository()
+	got, err := r.GetMaterialProgress(context.TODO(), sqlxDB, userId, courseId2)
+	assert.Nil(t, got, "[GetMaterialProgress] Material progress not found")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "Material progress not found"), http.StatusNotFound, nil), err, "[GetMaterialProgress] Material progress not found")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetMaterialProgress] Material progress not found")
+}
+
+func TestCourseRepository_UpsertMaterialProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	progress := &db_models.MaterialProgress{
+		UserID:       userId,
+		CourseID:     courseId,
+		MaterialID:   courseId2,
+		TimeSpent:    30,
+		Percentage:   40,
+		LastPosition: 120,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO material_progress (user_id,course_id,material_id,time_spent,percentage,last_position,completed) VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE time_spent = time_spent + VALUES(time_spent), percentage = GREATEST(percentage, VALUES(percentage)), last_position = VALUES(last_position), completed = completed OR VALUES(completed), updated_at = CURRENT_TIMESTAMP`)).
+		WithArgs(userId, courseId, courseId2, 30, 40, 120, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := course_repository.NewRepository()
+	err = r.UpsertMaterialProgress(context.TODO(), sqlxDB, progress)
+	assert.Nil(t, err, "[UpsertMaterialProgress] Success to store material progress")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[UpsertMaterialProgress] Success to store material progress")
+}
+
+func TestCourseRepository_CompleteMaterialProgress(t *testing.T) {
+	updateQuery := regexp.QuoteMeta(`UPDATE material_progress SET completed = ? WHERE completed = ? AND material_id = ? AND user_id = ?`)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO user_progress (user_id,course_id,material_id,score) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE score = GREATEST(score, VALUES(score))`)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "[CompleteMaterialProgress] Success to complete material progress",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery).
+					WithArgs(true, false, courseId2, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertQuery).
+					WithArgs(userId, courseId, courseId2, 100).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: true,
+		},
+		{
+			name: "[CompleteMaterialProgress] Material progress is already completed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery).
+					WithArgs(true, false, courseId2, userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			want: false,
+		},
+		{
+			name: "[CompleteMaterialProgress] Completion is rolled back when the material can not be logged",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery).
+					WithArgs(true, false, courseId2, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertQuery).
+					WithArgs(userId, courseId, courseId2, 100).
+					WillReturnError(errors.New("insert failed"))
+				mock.ExpectRollback()
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			tt.mock(mock)
+
+			r := course_repository.NewRepository()
+			got, err := r.CompleteMaterialProgress(context.TODO(), sqlxDB, userId, courseId, courseId2)
+			assert.Equal(t, tt.wantErr, err != nil, tt.name)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
@@ -12,20 +12,91 @@ import (
 type CourseRepository interface {
 	GetTableName() string
 	GetCourseById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Course, error)
-	GetCourseList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta) ([]*db_models.Course, uint64, error)
+	GetCourseList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, filter models.CourseFilter) ([]*db_models.Course, uint64, error)
+	GetCourseListByStatus(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, status string, filter models.CourseFilter) ([]*db_models.Course, uint64, error)
 	GetCompletedCourseByUserID(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, userid string) ([]*db_models.Course, uint64, error)
 	GetOnProgressCourseByUserID(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, userid string) ([]*db_models.Course, uint64, error)
 	GetCourseSyllabusByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Syllabus, error)
 	IsUserEnrolledToCourse(ctx context.Context, db *sqlx.DB, userid string, courseid string) (bool, error)
 	InsertEnrollment(ctx context.Context, db *sqlx.DB, values *models.EnrollInput) error
+	DeleteEnrollment(ctx context.Context, db *sqlx.DB, values *models.EnrollInput) error
+	HasEnrollmentHistory(ctx context.Context, db *sqlx.DB, userId, courseId string) (bool, error)
+	GetEnrollmentHistoryByUserID(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, userId string) ([]*db_models.EnrollmentHistory, uint64, error)
+	GetEnrollmentStartDate(ctx context.Context, db *sqlx.DB, userId string, courseId string) (*string, error)
+	IsCourseCompleted(ctx context.Context, db *sqlx.DB, userId string, courseId string) (bool, error)
+	CompleteCourse(ctx context.Context, db *sqlx.DB, userId string, courseId string) error
 	GetCourseMaterialByCourseIDAndSectionID(ctx context.Context, db *sqlx.DB, courseId string, sectionId string) ([]*db_models.Material, error)
 	GetCourseIDByMaterialID(ctx context.Context, db *sqlx.DB, materialID string) (string, error)
 	StoreUserProgress(ctx context.Context, db *sqlx.DB, materialID, courseID, userID string, score int) error
 	GetUserProgress(ctx context.Context, db *sqlx.DB, userID, courseID string) ([]*db_models.UserProgress, error)
 	CheckIsProgressLogged(ctx context.Context, db *sqlx.DB, userID, materialID string) (bool, error)
+	GetMaterialProgress(ctx context.Context, db *sqlx.DB, userID, materialID string) (*db_models.MaterialProgress, error)
+	GetMaterialProgressByCourseID(ctx context.Context, db *sqlx.DB, userID, courseID string) ([]*db_models.MaterialProgress, error)
+	UpsertMaterialProgress(ctx context.Context, db *sqlx.DB, progress *db_models.MaterialProgress) error
+	CompleteMaterialProgress(ctx context.Context, db *sqlx.DB, userID, courseID, materialID string) (bool, error)
 	InsertCourse(ctx context.Context, db *sqlx.DB, course *models.CourseCreation) error
 	GetMaterialByID(ctx context.Context, db *sqlx.DB, id string) (*db_models.Material, error)
 	InsertCourseData(ctx context.Context, db *sqlx.DB, course *db_models.Course) error
+	GetMaterialsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Material, error)
+	CloneCourse(ctx context.Context, db *sqlx.DB, clone *db_models.CourseClone) error
+	ImportCourse(ctx context.Context, db *sqlx.DB, input *db_models.CourseImport) error
 	InsertCourseMaterial(ctx context.Context, db *sqlx.DB, course *db_models.Material) error
 	GetCourseByCreatorID(ctx context.Context, db *sqlx.DB, creatorId string) ([]*db_models.Course, error)
+	UpdateCourseData(ctx context.Context, db *sqlx.DB, course *db_models.Course) error
+	UpdateCourseAccess(ctx context.Context, db *sqlx.DB, course *db_models.Course) error
+	UpdateCourseMaterial(ctx context.Context, db *sqlx.DB, material *db_models.Material) error
+	DeleteCourseMaterials(ctx context.Context, db *sqlx.DB, ids []string) error
+	DeleteCourse(ctx context.Context, db *sqlx.DB, id string) error
+	GetNextMaterialPosition(ctx context.Context, db *sqlx.DB, courseId, sectionId string) (int, error)
+	UpdateMaterialPositions(ctx context.Context, db *sqlx.DB, positions []*db_models.MaterialPosition) error
+	UpdateCourseStatus(ctx context.Context, db *sqlx.DB, input *models.CourseStatusUpdate) error
+	GetTopicList(ctx context.Context, db *sqlx.DB) ([]*db_models.Topic, error)
+	GetTopicById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Topic, error)
+	GetTopicsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Topic, error)
+	InsertTopic(ctx context.Context, db *sqlx.DB, topic *db_models.Topic) error
+	UpdateTopic(ctx context.Context, db *sqlx.DB, topic *db_models.Topic) error
+	DeleteTopic(ctx context.Context, db *sqlx.DB, id string) error
+	SetCourseTopics(ctx context.Context, db *sqlx.DB, courseId string, topicIds []string) error
+	GetPrerequisitesByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Course, error)
+	GetMissingPrerequisites(ctx context.Context, db *sqlx.DB, userId string, courseId string) ([]*db_models.Course, error)
+	SetCoursePrerequisites(ctx context.Context, db *sqlx.DB, courseId string, prerequisiteIds []string) error
+	GetCollaboratorsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.CourseCollaborator, error)
+	GetCollaborator(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.CourseCollaborator, error)
+	GetCollaboratorRole(ctx context.Context, db *sqlx.DB, courseId string, userId string) (string, error)
+	GetCollaborationInvitations(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.CourseCollaborator, error)
+	InsertCollaborator(ctx context.Context, db *sqlx.DB, collaborator *db_models.CourseCollaborator) error
+	UpdateCollaborator(ctx context.Context, db *sqlx.DB, collaborator *db_models.CourseCollaborator) error
+	DeleteCollaborator(ctx context.Context, db *sqlx.DB, courseId string, userId string) error
+	GetCourseReviews(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string) ([]*db_models.CourseReview, uint64, error)
+	GetCourseReview(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.CourseReview, error)
+	GetCourseRatings(ctx context.Context, db *sqlx.DB, courseIds []string) ([]*db_models.CourseRating, error)
+	UpsertCourseReview(ctx context.Context, db *sqlx.DB, review *db_models.CourseReview) error
+	SetCourseReviewHidden(ctx context.Context, db *sqlx.DB, courseId string, userId string, hidden bool) error
+	GetCourseAnnouncements(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string, userId string) ([]*db_models.CourseAnnouncement, uint64, error)
+	GetCourseAnnouncement(ctx context.Context, db *sqlx.DB, id string) (*db_models.CourseAnnouncement, error)
+	InsertCourseAnnouncement(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement) error
+	UpdateCourseAnnouncement(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement) error
+	DeleteCourseAnnouncement(ctx context.Context, db *sqlx.DB, id string) error
+	MarkAnnouncementRead(ctx context.Context, db *sqlx.DB, announcement *db_models.CourseAnnouncement, userId string) error
+	GetUnreadAnnouncements(ctx context.Context, db *sqlx.DB, userId string) ([]*db_models.UnreadAnnouncement, error)
+	GetCohortsByCourseID(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.Cohort, error)
+	GetCohortById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Cohort, error)
+	GetCohortByJoinCode(ctx context.Context, db *sqlx.DB, joinCode string) (*db_models.Cohort, error)
+	InsertCohort(ctx context.Context, db *sqlx.DB, cohort *db_models.Cohort, instructors []string) error
+	UpdateCohort(ctx context.Context, db *sqlx.DB, cohort *db_models.Cohort, instructors []string) error
+	DeleteCohort(ctx context.Context, db *sqlx.DB, id string) error
+	GetCohortInstructors(ctx context.Context, db *sqlx.DB, cohortId string) ([]*db_models.CohortUser, error)
+	GetCohortMembers(ctx context.Context, db *sqlx.DB, cohortId string) ([]*db_models.CohortUser, error)
+	IsCohortInstructor(ctx context.Context, db *sqlx.DB, cohortId string, userId string) (bool, error)
+	UpdateEnrollmentSettings(ctx context.Context, db *sqlx.DB, values *db_models.Course) error
+	CountEnrolledUsers(ctx context.Context, db *sqlx.DB, courseId string) (int, error)
+	GetEnrollmentRequests(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, courseId string, status string) ([]*db_models.EnrollmentRequest, uint64, error)
+	GetEnrollmentRequest(ctx context.Context, db *sqlx.DB, courseId string, userId string) (*db_models.EnrollmentRequest, error)
+	UpsertEnrollmentRequest(ctx context.Context, db *sqlx.DB, request *db_models.EnrollmentRequest) error
+	SetEnrollmentRequestStatus(ctx context.Context, db *sqlx.DB, courseId string, userId string, status string) error
+	DeleteEnrollmentRequest(ctx context.Context, db *sqlx.DB, courseId string, userId string) error
+	GetEnrollmentStats(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.EnrollmentStat, error)
+	GetMaterialCompletions(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.MaterialCompletion, error)
+	GetCompletionDurations(ctx context.Context, db *sqlx.DB, courseId string) ([]int64, error)
+	IsAssignmentExists(ctx context.Context, db *sqlx.DB, id string) (bool, error)
 }
@@ -0,0 +1,95 @@
+package course
+
+import (
+	"context"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+// GetResumePoints tells, for every course the user is taking, which material
+// to continue with and which one was visited last.
+func (serv *courseService) GetResumePoints(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.CourseResume, uint64, error) {
+	resumes := []*models.CourseResume{}
+
+	courses, count, err := serv.courseRepository.GetOnProgressCourseByUserID(ctx, serv.db, meta, userId)
+	if err != nil {
+		return resumes, count, err
+	}
+
+	for _, course := range courses {
+		resume, err := serv.getResumePoint(ctx, userId, course)
+		if err != nil {
+			return resumes, count, err
+		}
+
+		resumes = append(resumes, resume)
+	}
+
+	return resumes, count, nil
+}
+
+// getResumePoint walks the syllabus in order. The next material is the first
+// one the user has not completed. The last visited material is the one with
+// the latest progress event, or the last completed material when the user has
+// no progress events on the course.
+func (serv *courseService) getResumePoint(ctx context.Context, userId string, course *db.Course) (*models.CourseResume, error) {
+	syllabus, err := serv.getCourseSyllabus(ctx, course.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, course.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	done := map[string]bool{}
+	for _, progress := range userProgress {
+		done[progress.MaterialID] = true
+	}
+
+	visited, err := serv.courseRepository.GetMaterialProgressByCourseID(ctx, serv.db, userId, course.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resume := &models.CourseResume{
+		CourseID:   course.ID,
+		CourseName: course.CourseName,
+		Thumbnail:  course.Thumbnail,
+	}
+
+	var lastCompleted *models.ResumeMaterial
+	for _, section := range syllabus.Syllabus {
+		for _, material := range section.Subsections {
+			if len(visited) > 0 && visited[0].MaterialID == material.ID {
+				resume.LastVisited = toResumeMaterial(section, material)
+				resume.LastVisited.LastPosition = visited[0].LastPosition
+			}
+
+			if done[material.ID] {
+				lastCompleted = toResumeMaterial(section, material)
+			} else if resume.Next == nil {
+				resume.Next = toResumeMaterial(section, material)
+			}
+		}
+	}
+
+	if resume.LastVisited == nil {
+		resume.LastVisited = lastCompleted
+	}
+
+	return resume, nil
+}
+
+func toResumeMaterial(section *models.Section, material *models.Material) *models.ResumeMaterial {
+	return &models.ResumeMaterial{
+		MaterialID:   material.ID,
+		MaterialName: material.Name,
+		MaterialType: material.Type,
+		SectionID:    section.ID,
+		SectionName:  section.Name,
+	}
+}
@@ -0,0 +1,125 @@
+package course_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_GetResumePoints(t *testing.T) {
+	videoId := uuid.New().String()
+	quizId := uuid.New().String()
+
+	syllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Name: "Introduction", Type: "section"},
+		{ID: materialId, CourseID: courseId, Name: "Welcome", Type: "markdown", SectionID: &syllabusId},
+		{ID: videoId, CourseID: courseId, Name: "Overview", Type: "video", SectionID: &syllabusId, Position: 1},
+		{ID: syllabusId2, CourseID: courseId, Name: "Basics", Type: "section", Position: 1},
+		{ID: quizId, CourseID: courseId, Name: "Quiz 1", Type: "assignment", SectionID: &syllabusId2},
+	}
+
+	welcome := func() *models.ResumeMaterial {
+		return &models.ResumeMaterial{MaterialID: materialId, MaterialName: "Welcome", MaterialType: "markdown", SectionID: syllabusId, SectionName: "Introduction"}
+	}
+	overview := func() *models.ResumeMaterial {
+		return &models.ResumeMaterial{MaterialID: videoId, MaterialName: "Overview", MaterialType: "video", SectionID: syllabusId, SectionName: "Introduction"}
+	}
+	quiz := func() *models.ResumeMaterial {
+		return &models.ResumeMaterial{MaterialID: quizId, MaterialName: "Quiz 1", MaterialType: "assignment", SectionID: syllabusId2, SectionName: "Basics"}
+	}
+
+	tests := []struct {
+		name     string
+		done     []string
+		visited  []*db_models.MaterialProgress
+		wantNext *models.ResumeMaterial
+		wantLast *models.ResumeMaterial
+	}{
+		{
+			name:     "[GetResumePoints] Course not started yet",
+			wantNext: welcome(),
+		},
+		{
+			name: "[GetResumePoints] Resume a material opened last",
+			done: []string{materialId},
+			visited: []*db_models.MaterialProgress{
+				{UserID: userId, CourseID: courseId, MaterialID: videoId, Percentage: 40, LastPosition: 42},
+				{UserID: userId, CourseID: courseId, MaterialID: materialId, Percentage: 100, Completed: true},
+			},
+			wantNext: overview(),
+			wantLast: func() *models.ResumeMaterial {
+				last := overview()
+				last.LastPosition = 42
+				return last
+			}(),
+		},
+		{
+			name:     "[GetResumePoints] Fall back to the last completed material",
+			done:     []string{materialId, videoId},
+			wantNext: quiz(),
+			wantLast: overview(),
+		},
+		{
+			name: "[GetResumePoints] Every material is completed",
+			done: []string{materialId, videoId, quizId},
+			visited: []*db_models.MaterialProgress{
+				{UserID: userId, CourseID: courseId, MaterialID: quizId, Percentage: 100, Completed: true},
+			},
+			wantLast: quiz(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			var progress []*db_models.UserProgress
+			for _, id := range tt.done {
+				progress = append(progress, &db_models.UserProgress{UserID: userId, CourseID: courseId, MaterialID: id, Score: 100})
+			}
+
+			repoMock.
+				On("GetOnProgressCourseByUserID", mock.Anything, mock.Anything, mock.Anything, userId).
+				Return([]*db_models.Course{{ID: courseId, CourseName: "Algorithms", Thumbnail: "thumbnail.png"}}, uint64(1), nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, courseId).
+				Return(syllabus, nil)
+
+			repoMock.
+				On("GetUserProgress", mock.Anything, mock.Anything, userId, courseId).
+				Return(progress, nil)
+
+			repoMock.
+				On("GetMaterialProgressByCourseID", mock.Anything, mock.Anything, userId, courseId).
+				Return(tt.visited, nil)
+
+			got, count, err := svc.GetResumePoints(context.TODO(), &pagination.Meta{Page: 1, Limit: 10}, userId)
+
+			assert.Nil(t, err, tt.name)
+			assert.Equal(t, uint64(1), count, tt.name)
+			assert.Equal(t, []*models.CourseResume{
+				{
+					CourseID:    courseId,
+					CourseName:  "Algorithms",
+					Thumbnail:   "thumbnail.png",
+					Next:        tt.wantNext,
+					LastVisited: tt.wantLast,
+				},
+			}, got, tt.name)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+// getCourseRatings returns the ratings of the courses keyed by course id, with
+// the average rounded to one decimal. Courses without visible reviews are left
+// out, their zero value is the rating to show.
+func (serv *courseService) getCourseRatings(ctx context.Context, courseIds []string) (map[string]db.CourseRating, error) {
+	ratings := map[string]db.CourseRating{}
+	if len(courseIds) == 0 {
+		return ratings, nil
+	}
+
+	db_ratings, err := serv.courseRepository.GetCourseRatings(ctx, serv.db, courseIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rating := range db_ratings {
+		rating.Average = math.Round(rating.Average*10) / 10
+		ratings[rating.CourseID] = *rating
+	}
+
+	return ratings, nil
+}
+
+func (serv *courseService) GetCourseReviews(ctx context.Context, meta *pagination.Meta, courseId string) ([]*models.CourseReview, uint64, error) {
+	reviews := []*models.CourseReview{}
+
+	db_reviews, count, err := serv.courseRepository.GetCourseReviews(ctx, serv.db, meta, courseId)
+	if err != nil {
+		return reviews, count, err
+	}
+
+	for _, review := range db_reviews {
+		reviews = append(reviews, &models.CourseReview{
+			UserID:    review.UserID,
+			Username:  review.Username,
+			Rating:    review.Rating,
+			Review:    review.Review,
+			Hidden:    review.Hidden,
+			CreatedAt: review.CreatedAt,
+			UpdatedAt: review.UpdatedAt,
+		})
+	}
+
+	return reviews, count, nil
+}
+
+// ReviewCourse rates the course on behalf of an enrolled learner, replacing
+// the previous review of the learner if there is one.
+func (serv *courseService) ReviewCourse(ctx context.Context, courseId string, input *models.CourseReviewInput, userId string) (*models.CourseUpdateResponse, error) {
+	if input.Rating < models.MinCourseRating || input.Rating > models.MaxCourseRating {
+		errs := []er.ErrorStruct{
+			{
+				Field:  "rating",
+				Reason: fmt.Sprintf("Rating must be between %d and %d", models.MinCourseRating, models.MaxCourseRating),
+			},
+		}
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid rating"), http.StatusBadRequest, &errs)
+	}
+
+	progress, err := serv.ComputeUserProgress(ctx, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.Percentage < models.MinReviewProgress {
+		return nil, er.NewError(fmt.Errorf("You need to complete at least %d%% of the course to review it", models.MinReviewProgress), http.StatusForbidden, nil)
+	}
+
+	err = serv.courseRepository.UpsertCourseReview(ctx, serv.db, &db.CourseReview{
+		CourseID: courseId,
+		UserID:   userId,
+		Rating:   input.Rating,
+		Review:   input.Review,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Review Submitted Succesfully",
+	}
+
+	return resp, nil
+}
+
+// ModerateCourseReview hides a review from the course page and from the
+// rating of the course, or shows it again.
+func (serv *courseService) ModerateCourseReview(ctx context.Context, courseId string, reviewerId string, input *models.ReviewModerationInput) (*models.CourseUpdateResponse, error) {
+	_, err := serv.courseRepository.GetCourseReview(ctx, serv.db, courseId, reviewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.SetCourseReviewHidden(ctx, serv.db, courseId, reviewerId, input.Hidden)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Review Updated Succesfully",
+	}
+
+	return resp, nil
+}
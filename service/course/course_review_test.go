@@ -0,0 +1,175 @@
+package course_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+)
+
+func TestCourseService_ReviewCourse(t *testing.T) {
+	syllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Type: "section"},
+		{ID: syllabusId2, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+		{ID: syllabusId3, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+	}
+
+	type mockRepo struct {
+		enrolled bool
+		progress []*db_models.UserProgress
+	}
+
+	tests := []struct {
+		name    string
+		input   *models.CourseReviewInput
+		mock    mockRepo
+		wantErr error
+	}{
+		{
+			name:  "[ReviewCourse] Success to review course",
+			input: &models.CourseReviewInput{Rating: 4, Review: "Great course"},
+			mock: mockRepo{
+				enrolled: true,
+				progress: []*db_models.UserProgress{{MaterialID: syllabusId2}, {MaterialID: syllabusId3}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "[ReviewCourse] Rating out of range",
+			input: &models.CourseReviewInput{Rating: 6},
+			mock: mockRepo{
+				enrolled: true,
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid rating"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "rating", Reason: "Rating must be between 1 and 5"},
+			}),
+		},
+		{
+			name:  "[ReviewCourse] Progress below threshold",
+			input: &models.CourseReviewInput{Rating: 5},
+			mock: mockRepo{
+				enrolled: true,
+				progress: []*db_models.UserProgress{},
+			},
+			wantErr: er.NewError(fmt.Errorf("You need to complete at least %d%% of the course to review it", models.MinReviewProgress), http.StatusForbidden, nil),
+		},
+		{
+			name:  "[ReviewCourse] User is not enrolled to the course",
+			input: &models.CourseReviewInput{Rating: 5},
+			mock: mockRepo{
+				enrolled: false,
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(tt.mock.enrolled, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetUserProgress", mock.Anything, mock.Anything, userId, courseId).
+				Return(tt.mock.progress, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, courseId).
+				Return(syllabus, nil)
+
+			var stored *db_models.CourseReview
+			repoMock.
+				On("UpsertCourseReview", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					stored = args.Get(2).(*db_models.CourseReview)
+				}).
+				Return(nil)
+
+			got, err := svc.ReviewCourse(context.TODO(), courseId, tt.input, userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, stored, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Review Submitted Succesfully", got.Message, tt.name)
+			assert.Equal(t, &db_models.CourseReview{
+				CourseID: courseId,
+				UserID:   userId,
+				Rating:   tt.input.Rating,
+				Review:   tt.input.Review,
+			}, stored, tt.name)
+		})
+	}
+}
+
+func TestCourseService_ModerateCourseReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		review  *db_models.CourseReview
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "[ModerateCourseReview] Success to hide review",
+			review:  &db_models.CourseReview{CourseID: courseId, UserID: userId, Rating: 1},
+			wantErr: nil,
+		},
+		{
+			name:    "[ModerateCourseReview] Review not found",
+			err:     er.NewError(fmt.Errorf("%s", "Review not found"), http.StatusNotFound, nil),
+			wantErr: er.NewError(fmt.Errorf("%s", "Review not found"), http.StatusNotFound, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseReview", mock.Anything, mock.Anything, courseId, userId).
+				Return(tt.review, tt.err)
+
+			repoMock.
+				On("SetCourseReviewHidden", mock.Anything, mock.Anything, courseId, userId, true).
+				Return(nil)
+
+			got, err := svc.ModerateCourseReview(context.TODO(), courseId, userId, &models.ReviewModerationInput{Hidden: true})
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "SetCourseReviewHidden", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Review Updated Succesfully", got.Message, tt.name)
+			repoMock.AssertCalled(t, "SetCourseReviewHidden", mock.Anything, mock.Anything, courseId, userId, true)
+		})
+	}
+}
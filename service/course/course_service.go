@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -13,14 +14,20 @@ import (
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/user/user_repository"
 )
 
 type courseService struct {
-	db               *sqlx.DB
-	courseRepository course_repository.CourseRepository
-	userRepository   user_repository.UserRepository
+	db                   *sqlx.DB
+	courseRepository     course_repository.CourseRepository
+	userRepository       user_repository.UserRepository
+	assignmentRepository assignment_repository.AssignmentRepository
+	problemRepository    problem_repository.ProblemRepository
+	searchService        search.SearchService
 }
 
 func NewService(db *sqlx.DB) CourseService {
@@ -47,12 +54,30 @@ func (serv *courseService) GetCourseDetail(ctx context.Context, id string) (*mod
 		username = user.Username
 	}
 
+	topics, err := serv.getCourseTopics(ctx, course.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := serv.getCourseRatings(ctx, []string{course.ID})
+	if err != nil {
+		return nil, err
+	}
+
 	resp := models.Course{
-		ID:          course.ID,
-		CourseName:  course.CourseName,
-		Description: course.Description,
-		Thumbnail:   course.Thumbnail,
-		Creator:     username,
+		ID:             course.ID,
+		CourseName:     course.CourseName,
+		Description:    course.Description,
+		Thumbnail:      course.Thumbnail,
+		Creator:        username,
+		Status:         course.Status,
+		Sequential:     course.Sequential,
+		MinScore:       course.MinScore,
+		EnrollmentMode: course.EnrollmentMode,
+		Capacity:       course.Capacity,
+		Topics:         topics,
+		Rating:         ratings[course.ID].Average,
+		ReviewCount:    ratings[course.ID].Count,
 	}
 
 	return &resp, nil
@@ -89,6 +114,7 @@ func (serv *courseService) GetCompeletedCourse(ctx context.Context, meta *pagina
 			Description: course.Description,
 			Thumbnail:   course.Thumbnail,
 			Creator:     username,
+			Status:      course.Status,
 		}
 
 		courses = append(courses, &temp)
@@ -128,6 +154,7 @@ func (serv *courseService) GetOnProgressCourse(ctx context.Context, meta *pagina
 			Description: course.Description,
 			Thumbnail:   course.Thumbnail,
 			Creator:     username,
+			Status:      course.Status,
 		}
 
 		courses = append(courses, &temp)
@@ -136,13 +163,23 @@ func (serv *courseService) GetOnProgressCourse(ctx context.Context, meta *pagina
 	return courses, count, nil
 }
 
-func (serv *courseService) GetCoursePagination(ctx context.Context, meta *pagination.Meta) ([]*models.Course, uint64, error) {
+func (serv *courseService) GetCoursePagination(ctx context.Context, meta *pagination.Meta, filter models.CourseFilter) ([]*models.Course, uint64, error) {
 	var (
 		count   uint64
 		courses []*models.Course
 	)
 
-	db_courses, count, err := serv.courseRepository.GetCourseList(ctx, serv.db, meta)
+	db_courses, count, err := serv.courseRepository.GetCourseList(ctx, serv.db, meta, filter)
+	if err != nil {
+		return courses, count, err
+	}
+
+	courseIds := []string{}
+	for _, course := range db_courses {
+		courseIds = append(courseIds, course.ID)
+	}
+
+	ratings, err := serv.getCourseRatings(ctx, courseIds)
 	if err != nil {
 		return courses, count, err
 	}
@@ -161,12 +198,21 @@ func (serv *courseService) GetCoursePagination(ctx context.Context, meta *pagina
 			username = user.Username
 		}
 
+		topics, err := serv.getCourseTopics(ctx, course.ID)
+		if err != nil {
+			return courses, count, err
+		}
+
 		temp := models.Course{
 			ID:          course.ID,
 			CourseName:  course.CourseName,
 			Description: course.Description,
 			Thumbnail:   course.Thumbnail,
 			Creator:     username,
+			Status:      course.Status,
+			Topics:      topics,
+			Rating:      ratings[course.ID].Average,
+			ReviewCount: ratings[course.ID].Count,
 		}
 
 		courses = append(courses, &temp)
@@ -175,7 +221,55 @@ func (serv *courseService) GetCoursePagination(ctx context.Context, meta *pagina
 	return courses, count, nil
 }
 
-func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId string) (*models.SyllabusResponse, error) {
+// GetCourseSyllabus returns the syllabus of the course with the sections not
+// yet released to the user marked as locked. The staff of the course and
+// admins see every section unlocked.
+func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.SyllabusResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	syllabus, err := serv.getCourseSyllabus(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAdmin {
+		return syllabus, nil
+	}
+
+	staff, err := serv.hasCourseRole(ctx, course, userId, courseStaffRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	if staff {
+		return syllabus, nil
+	}
+
+	completed, err := serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if completed {
+		return syllabus, nil
+	}
+
+	unreleased, err := serv.getUnreleasedSections(ctx, userId, courseId, syllabus.Syllabus)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range syllabus.Syllabus {
+		section.Locked = unreleased[section.ID]
+	}
+
+	return syllabus, nil
+}
+
+func (serv *courseService) getCourseSyllabus(ctx context.Context, courseId string) (*models.SyllabusResponse, error) {
 	db_syllabus, err := serv.courseRepository.GetCourseSyllabusByCourseID(ctx, serv.db, courseId)
 	if err != nil {
 		return nil, err
@@ -194,9 +288,10 @@ func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId strin
 				if material.SectionID != nil {
 					if *material.SectionID == sectionId {
 						temp := models.Material{
-							ID:   material.ID,
-							Name: material.Name,
-							Type: material.Type,
+							ID:       material.ID,
+							Name:     material.Name,
+							Type:     material.Type,
+							Position: material.Position,
 						}
 
 						subSections = append(subSections, &temp)
@@ -205,9 +300,12 @@ func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId strin
 			}
 
 			temp := models.Section{
-				ID:          syllabus.ID,
-				Name:        syllabus.Name,
-				Subsections: subSections,
+				ID:               syllabus.ID,
+				Name:             syllabus.Name,
+				Position:         syllabus.Position,
+				ReleaseAt:        formatReleaseDate(syllabus.ReleaseAt),
+				ReleaseAfterDays: syllabus.ReleaseAfterDays,
+				Subsections:      subSections,
 			}
 
 			sections = append(sections, &temp)
@@ -218,9 +316,10 @@ func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId strin
 		var subSections []*models.Material
 		for _, material := range db_syllabus {
 			temp := models.Material{
-				ID:   material.ID,
-				Name: material.Name,
-				Type: material.Type,
+				ID:       material.ID,
+				Name:     material.Name,
+				Type:     material.Type,
+				Position: material.Position,
 			}
 
 			subSections = append(subSections, &temp)
@@ -242,7 +341,31 @@ func (serv *courseService) GetCourseSyllabus(ctx context.Context, courseId strin
 	return &data, nil
 }
 
-func (serv *courseService) GetCourseMaterial(ctx context.Context, courseId string, sectionId string) (*models.SectionContentResponse, error) {
+// GetCourseMaterial returns the content of a section to the learners of the
+// course. The staff of the course and admins may preview every material,
+// locked materials are returned without their content.
+func (serv *courseService) GetCourseMaterial(ctx context.Context, courseId string, sectionId string, userId string, isAdmin bool) (*models.SectionContentResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := isAdmin
+	if !staff {
+		staff, err = serv.hasCourseRole(ctx, course, userId, courseStaffRoles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	locked := map[string]bool{}
+	if !staff {
+		locked, err = serv.getLockedMaterials(ctx, userId, course)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	db_material, err := serv.courseRepository.GetCourseMaterialByCourseIDAndSectionID(ctx, serv.db, courseId, sectionId)
 	if err != nil {
 		return nil, err
@@ -257,6 +380,16 @@ func (serv *courseService) GetCourseMaterial(ctx context.Context, courseId strin
 			Type:        material.Type,
 			Content:     material.Content,
 			ContentText: material.ContentText,
+			ContentHTML: materialHTML(material),
+			Payload:     materialPayload(material),
+		}
+
+		if locked[material.ID] {
+			temp.Content = ""
+			temp.ContentText = ""
+			temp.ContentHTML = ""
+			temp.Payload = nil
+			temp.Locked = true
 		}
 
 		materials = append(materials, &temp)
@@ -270,114 +403,137 @@ func (serv *courseService) GetCourseMaterial(ctx context.Context, courseId strin
 	return &data, nil
 }
 
-func (serv *courseService) Enroll(ctx context.Context, userId string, courseId string) (*models.EnrollResponse, error) {
-	check, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
+// Enroll enrolls the user to the course following its enrollment mode. A join
+// code adds the user to the cohort it belongs to as well.
+func (serv *courseService) Enroll(ctx context.Context, userId string, courseId string, joinCode string) (*models.EnrollResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	if check {
-		return nil, er.NewError(fmt.Errorf("%s", "You are already enrolled to the course"), http.StatusBadRequest, nil)
+	if course.Status != models.CourseStatusPublished {
+		return nil, er.NewError(fmt.Errorf("%s", "The course is not open for enrollment"), http.StatusBadRequest, nil)
 	}
 
-	values := &models.EnrollInput{
-		UserID:   userId,
-		CourseID: courseId,
+	cohortId, err := serv.resolveJoinCode(ctx, course, joinCode)
+	if err != nil {
+		return nil, err
 	}
 
-	err = serv.courseRepository.InsertEnrollment(ctx, serv.db, values)
+	check, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	out := &models.EnrollResponse{
-		Status:  "Success",
-		Message: "You have successfully enrolled to the course.",
+	if check {
+		return nil, er.NewError(fmt.Errorf("%s", "You are already enrolled to the course"), http.StatusBadRequest, nil)
 	}
 
-	return out, nil
-}
+	completed, err := serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
 
-func (serv *courseService) StoreUserProgress(ctx context.Context, userId, materialId string) (*models.StoreProgressResponse, error) {
-	courseId, err := serv.courseRepository.GetCourseIDByMaterialID(ctx, serv.db, materialId)
+	if completed {
+		return nil, er.NewError(fmt.Errorf("%s", "You have already completed the course"), http.StatusBadRequest, nil)
+	}
+
+	err = serv.checkPrerequisites(ctx, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	check, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
+	enrolledBefore, err := serv.courseRepository.HasEnrollmentHistory(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	if !check {
-		return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	if enrolledBefore {
+		return nil, er.NewError(fmt.Errorf("%s", "You have enrolled to the course before, use re-enroll instead"), http.StatusBadRequest, nil)
 	}
 
-	isLogged, err := serv.courseRepository.CheckIsProgressLogged(ctx, serv.db, userId, materialId)
+	err = serv.checkEnrollmentRequest(ctx, course, userId)
 	if err != nil {
 		return nil, err
 	}
 
-	if isLogged {
-		return nil, er.NewError(fmt.Errorf("%s", "Your progress has already been recorded"), http.StatusBadRequest, nil)
+	values := &models.EnrollInput{
+		UserID:       userId,
+		CourseID:     courseId,
+		Action:       models.EnrollmentActionEnroll,
+		KeepProgress: true,
+		CohortID:     cohortId,
 	}
 
-	err = serv.courseRepository.StoreUserProgress(ctx, serv.db, materialId, courseId, userId, 100)
+	return serv.admitEnrollment(ctx, course, values, "You have successfully enrolled to the course.")
+}
+
+// ReEnroll enrolls a user who left the course again. Progress kept from the
+// previous enrollment is dropped unless keepProgress is set.
+func (serv *courseService) ReEnroll(ctx context.Context, userId string, courseId string, joinCode string, keepProgress bool) (*models.EnrollResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	out := &models.StoreProgressResponse{
-		Status:  "Success",
-		Message: "User progress updated successfully",
+	if course.Status != models.CourseStatusPublished {
+		return nil, er.NewError(fmt.Errorf("%s", "The course is not open for enrollment"), http.StatusBadRequest, nil)
 	}
 
-	return out, nil
-}
+	cohortId, err := serv.resolveJoinCode(ctx, course, joinCode)
+	if err != nil {
+		return nil, err
+	}
 
-func (serv *courseService) ComputeUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressPercentageResponse, error) {
 	check, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	if !check {
-		return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	if check {
+		return nil, er.NewError(fmt.Errorf("%s", "You are already enrolled to the course"), http.StatusBadRequest, nil)
 	}
 
-	userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, courseId)
+	completed, err := serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	syllabus, err := serv.GetCourseSyllabus(ctx, courseId)
+	if completed {
+		return nil, er.NewError(fmt.Errorf("%s", "You have already completed the course"), http.StatusBadRequest, nil)
+	}
+
+	enrolledBefore, err := serv.courseRepository.HasEnrollmentHistory(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	materialDone := len(userProgress)
-	courseLength := 0
+	if !enrolledBefore {
+		return nil, er.NewError(fmt.Errorf("%s", "You have never enrolled to the course"), http.StatusBadRequest, nil)
+	}
 
-	for _, section := range syllabus.Syllabus {
-		for range section.Subsections {
-			courseLength += 1
-		}
+	err = serv.checkPrerequisites(ctx, userId, courseId)
+	if err != nil {
+		return nil, err
 	}
 
-	intResult := 0
-	if courseLength != 0 {
-		result := float64(materialDone) / float64(courseLength) * 100
-		intResult = int(math.Round(result))
+	err = serv.checkEnrollmentRequest(ctx, course, userId)
+	if err != nil {
+		return nil, err
 	}
 
-	out := &models.GetProgressPercentageResponse{
-		Percentage: intResult,
+	values := &models.EnrollInput{
+		UserID:       userId,
+		CourseID:     courseId,
+		Action:       models.EnrollmentActionReEnroll,
+		KeepProgress: keepProgress,
+		CohortID:     cohortId,
 	}
 
-	return out, nil
+	return serv.admitEnrollment(ctx, course, values, "You have successfully re-enrolled to the course.")
 }
 
-func (serv *courseService) GetUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressResponse, error) {
+func (serv *courseService) Unenroll(ctx context.Context, userId string, courseId string, keepProgress bool) (*models.EnrollResponse, error) {
 	check, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
 	if err != nil {
 		return nil, err
@@ -387,202 +543,1691 @@ func (serv *courseService) GetUserProgress(ctx context.Context, userId, courseId
 		return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
 	}
 
-	userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, courseId)
+	values := &models.EnrollInput{
+		UserID:       userId,
+		CourseID:     courseId,
+		Action:       models.EnrollmentActionUnenroll,
+		KeepProgress: keepProgress,
+	}
+
+	err = serv.courseRepository.DeleteEnrollment(ctx, serv.db, values)
 	if err != nil {
 		return nil, err
 	}
 
-	var out []*models.UserProgress
-	for _, progress := range userProgress {
-		temp := models.UserProgress{
-			UserID:     progress.UserID,
-			CourseID:   progress.CourseID,
-			MaterialID: progress.MaterialID,
-			Score:      progress.Score,
-		}
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
 
-		out = append(out, &temp)
+	err = serv.promoteWaitlist(ctx, course)
+	if err != nil {
+		return nil, err
 	}
 
-	resp := &models.GetProgressResponse{
-		Progress: out,
+	out := &models.EnrollResponse{
+		Status:  "Success",
+		Message: "You have successfully unenrolled from the course.",
 	}
 
-	return resp, nil
+	return out, nil
 }
 
-func (serv *courseService) CreateNewCourse(ctx context.Context, course *models.CourseCreation) (*models.CourseCreationResponse, error) {
-	newID := uuid.New().String()
-	course.Course.ID = newID
-
-	for _, sect := range course.Sections {
-		newID = uuid.New().String()
-		sect.ID = newID
-		for _, mat := range sect.Subsections {
-			newID = uuid.New().String()
-			mat.ID = newID
-		}
-	}
+func (serv *courseService) GetEnrollmentHistory(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.EnrollmentHistory, uint64, error) {
+	history := []*models.EnrollmentHistory{}
 
-	err := serv.courseRepository.InsertCourse(ctx, serv.db, course)
+	db_history, count, err := serv.courseRepository.GetEnrollmentHistoryByUserID(ctx, serv.db, meta, userId)
 	if err != nil {
-		return nil, err
+		return history, count, err
 	}
 
-	resp := &models.CourseCreationResponse{
-		Status:  "Success",
-		Message: "Course Created Succesfully",
+	for _, item := range db_history {
+		history = append(history, &models.EnrollmentHistory{
+			CourseID:     item.CourseID,
+			CourseName:   item.CourseName,
+			Action:       item.Action,
+			KeepProgress: item.KeepProgress,
+			CreatedAt:    item.CreatedAt,
+		})
 	}
 
-	return resp, nil
+	return history, count, nil
 }
 
-func (serv *courseService) UploadImage(ctx context.Context, request *http.Request, baseURL string) (*models.UploadImageResponse, error) {
-
-	imageId := uuid.New().String()
-	url := imageId + ".png"
-
-	request.ParseMultipartForm(10 << 20)
+// getLockedMaterials tells which materials of the course are still locked for
+// the user. It fails when the user is neither enrolled to nor has completed
+// the course. Materials of unreleased sections are locked, and in sequential
+// mode so is every material following one that is not completed yet. Once
+// the course is completed nothing is locked.
+func (serv *courseService) getLockedMaterials(ctx context.Context, userId string, course *db.Course) (map[string]bool, error) {
+	locked := map[string]bool{}
 
-	file, _, err := request.FormFile("imageFile")
+	enrolled, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, course.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	defer file.Close()
+	if !enrolled {
+		completed, err := serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, course.ID)
+		if err != nil {
+			return nil, err
+		}
 
-	// Create a temporary file within our temp-images directory that follows
-	// a particular naming pattern
-	tempFile, err := ioutil.TempFile("static/image/", "course-*-"+url)
+		if !completed {
+			return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+		}
+
+		return locked, nil
+	}
+
+	syllabus, err := serv.getCourseSyllabus(ctx, course.ID)
 	if err != nil {
 		return nil, err
 	}
-	defer tempFile.Close()
 
-	// read all of the contents of our uploaded file into a
-	// byte array
-	fileBytes, err := ioutil.ReadAll(file)
+	unreleased, err := serv.getUnreleasedSections(ctx, userId, course.ID, syllabus.Syllabus)
 	if err != nil {
 		return nil, err
 	}
-	// write this byte array to our temporary file
-	tempFile.Write(fileBytes)
 
-	// return that we have successfully uploaded our file!
-	url = baseURL + tempFile.Name()
-	resp := &models.UploadImageResponse{
-		Status:  "Success",
-		Message: "File Uploaded Succesfully",
-		URL:     url,
-	}
+	// Assignments may be submitted more than once, the best score counts.
+	scores := map[string]int{}
+	if course.Sequential {
+		userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, course.ID)
+		if err != nil {
+			return nil, err
+		}
 
-	return resp, nil
-}
+		for _, progress := range userProgress {
+			score, ok := scores[progress.MaterialID]
+			if !ok || progress.Score > score {
+				scores[progress.MaterialID] = progress.Score
+			}
+		}
+	}
 
-func (serv *courseService) CreateCourseDesc(ctx context.Context, course *models.CourseDescriptionInput, creatorId string) (*models.CourseCreationResponse, error) {
-	id := uuid.New().String()
+	unlocked := true
+	for _, section := range syllabus.Syllabus {
+		for _, material := range section.Subsections {
+			if unreleased[section.ID] {
+				locked[material.ID] = true
+			}
 
-	input := db.Course{
-		ID:          id,
-		CourseName:  course.CourseName,
-		Description: course.Description,
-		Thumbnail:   course.Thumbnail,
-		Creator:     creatorId,
-	}
+			if !course.Sequential {
+				continue
+			}
 
-	err := serv.courseRepository.InsertCourseData(ctx, serv.db, &input)
-	if err != nil {
-		return nil, err
-	}
+			if !unlocked {
+				locked[material.ID] = true
+				continue
+			}
 
-	resp := &models.CourseCreationResponse{
-		Status:  "Success",
-		Message: "Course Description Created Succesfully",
-		Id:      id,
+			score, done := scores[material.ID]
+			unlocked = done && score >= course.MinScore
+		}
 	}
 
-	return resp, nil
+	return locked, nil
 }
 
-func (serv *courseService) CreateCourseSection(ctx context.Context, input *models.CourseSectionInput, creatorId string) (*models.CourseCreationResponse, error) {
-	id := uuid.New().String()
+// CheckMaterialAccess makes sure the user may record progress on the
+// material and returns the course it belongs to.
+func (serv *courseService) CheckMaterialAccess(ctx context.Context, userId, materialId string) (string, error) {
+	courseId, err := serv.courseRepository.GetCourseIDByMaterialID(ctx, serv.db, materialId)
+	if err != nil {
+		return "", err
+	}
 
-	material := &db.Material{
-		ID:          id,
-		CourseID:    input.CourseID,
-		Name:        input.Name,
-		Type:        "section",
-		SectionID:   "",
-		Content:     "",
-		ContentText: "",
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return "", err
 	}
 
-	err := serv.courseRepository.InsertCourseMaterial(ctx, serv.db, material)
+	locked, err := serv.getLockedMaterials(ctx, userId, course)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	resp := &models.CourseCreationResponse{
-		Status:  "Success",
-		Message: "Section Created Succesfully",
-		Id:      id,
+	if locked[materialId] {
+		return "", er.NewError(fmt.Errorf("%s", "The material is locked until the previous material is completed"), http.StatusForbidden, nil)
 	}
 
-	return resp, nil
+	return courseId, nil
 }
 
-func (serv *courseService) CreateCourseMaterial(ctx context.Context, input *models.CourseMaterialInput, creatorId string) (*models.CourseCreationResponse, error) {
-	id := uuid.New().String()
-	courseId, err := serv.courseRepository.GetCourseIDByMaterialID(ctx, serv.db, input.SectionID)
+// maxHeartbeatTimeSpent caps the seconds a single progress event adds to the
+// time spent on a material, so a client can not inflate it in one request.
+const maxHeartbeatTimeSpent = 5 * 60
+
+// StoreUserProgress records a progress event on the material. The material
+// is completed, and starts counting towards the course progress, once the
+// percentage reached by the user meets the threshold of its type. Only the
+// event that flips the material to completed logs it, so concurrent events
+// complete a material once.
+func (serv *courseService) StoreUserProgress(ctx context.Context, userId, materialId string, event *models.ProgressEvent) (*models.StoreProgressResponse, error) {
+	courseId, err := serv.CheckMaterialAccess(ctx, userId, materialId)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = serv.courseRepository.GetMaterialByID(ctx, serv.db, input.SectionID)
+	material, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, materialId)
 	if err != nil {
 		return nil, err
 	}
 
-	material := &db.Material{
-		ID:          id,
-		CourseID:    courseId,
-		Name:        input.Name,
-		Type:        input.Type,
-		SectionID:   input.SectionID,
-		Content:     input.Content,
-		ContentText: input.ContentText,
+	progress, err := serv.courseRepository.GetMaterialProgress(ctx, serv.db, userId, materialId)
+	if err != nil {
+		if e, ok := err.(er.Error); !ok || e.HTTPStatusCode() != http.StatusNotFound {
+			return nil, err
+		}
+		progress = &db.MaterialProgress{UserID: userId, CourseID: courseId, MaterialID: materialId}
 	}
 
-	err = serv.courseRepository.InsertCourseMaterial(ctx, serv.db, material)
+	// Materials completed before progress events existed are only logged.
+	isLogged, err := serv.courseRepository.CheckIsProgressLogged(ctx, serv.db, userId, materialId)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &models.CourseCreationResponse{
-		Status:  "Success",
-		Message: "Section Created Succesfully",
-		Id:      id,
+	timeSpent := event.TimeSpent
+	if timeSpent > maxHeartbeatTimeSpent {
+		timeSpent = maxHeartbeatTimeSpent
 	}
 
-	return resp, nil
+	progress.TimeSpent += timeSpent
+	if event.Percentage > progress.Percentage {
+		progress.Percentage = event.Percentage
+	}
+	progress.LastPosition = event.Position
+
+	threshold := completionThreshold(material.Type)
+	reached := threshold >= 0 && progress.Percentage >= threshold
+
+	err = serv.courseRepository.UpsertMaterialProgress(ctx, serv.db, &db.MaterialProgress{
+		UserID:       userId,
+		CourseID:     courseId,
+		MaterialID:   materialId,
+		TimeSpent:    timeSpent,
+		Percentage:   event.Percentage,
+		LastPosition: event.Position,
+		Completed:    isLogged,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reached && !isLogged {
+		_, err = serv.courseRepository.CompleteMaterialProgress(ctx, serv.db, userId, courseId, materialId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	progress.Completed = progress.Completed || isLogged || reached
+
+	// The course is checked on every event on a completed material, so a
+	// completion that failed to be recorded is retried by the next event.
+	completed := false
+	if progress.Completed {
+		completed, err = serv.CheckCourseCompletion(ctx, userId, courseId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := &models.StoreProgressResponse{
+		Status:    "Success",
+		Message:   "User progress updated successfully",
+		Completed: completed,
+		Progress:  toMaterialProgress(progress, threshold),
+	}
+
+	return out, nil
+}
+
+func toMaterialProgress(progress *db.MaterialProgress, threshold int) *models.MaterialProgress {
+	return &models.MaterialProgress{
+		MaterialID:   progress.MaterialID,
+		TimeSpent:    progress.TimeSpent,
+		Percentage:   progress.Percentage,
+		LastPosition: progress.LastPosition,
+		Threshold:    threshold,
+		Completed:    progress.Completed,
+		UpdatedAt:    progress.UpdatedAt,
+	}
+}
+
+func (serv *courseService) ComputeUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressPercentageResponse, error) {
+	check, err := serv.hasCourseAccess(ctx, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	}
+
+	done, total, err := serv.countCompletedMaterials(ctx, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	intResult := 0
+	if total != 0 {
+		result := float64(done) / float64(total) * 100
+		intResult = int(math.Round(result))
+	}
+
+	// Only a fully completed syllabus reads as 100%.
+	if intResult == 100 && done < total {
+		intResult = 99
+	}
+
+	out := &models.GetProgressPercentageResponse{
+		Percentage: intResult,
+	}
+
+	return out, nil
+}
+
+// hasCourseAccess tells whether the user is enrolled to the course or has
+// already completed it.
+func (serv *courseService) hasCourseAccess(ctx context.Context, userId, courseId string) (bool, error) {
+	enrolled, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return false, err
+	}
+
+	if enrolled {
+		return true, nil
+	}
+
+	return serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, courseId)
+}
+
+// countCompletedMaterials returns how many materials of the syllabus the user
+// has completed and how many materials the syllabus holds. A material counts
+// once however many progress rows it has.
+func (serv *courseService) countCompletedMaterials(ctx context.Context, userId, courseId string) (int, int, error) {
+	userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	syllabus, err := serv.getCourseSyllabus(ctx, courseId)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	progressed := map[string]bool{}
+	for _, progress := range userProgress {
+		progressed[progress.MaterialID] = true
+	}
+
+	done, total := 0, 0
+	for _, section := range syllabus.Syllabus {
+		for _, material := range section.Subsections {
+			total += 1
+			if progressed[material.ID] {
+				done += 1
+			}
+		}
+	}
+
+	return done, total, nil
+}
+
+// CheckCourseCompletion records the course as completed once the user has
+// completed every material of the syllabus. It returns whether the course is
+// completed.
+func (serv *courseService) CheckCourseCompletion(ctx context.Context, userId, courseId string) (bool, error) {
+	check, err := serv.hasCourseAccess(ctx, userId, courseId)
+	if err != nil {
+		return false, err
+	}
+
+	if !check {
+		return false, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	}
+
+	done, total, err := serv.countCompletedMaterials(ctx, userId, courseId)
+	if err != nil {
+		return false, err
+	}
+
+	if total == 0 || done < total {
+		return false, nil
+	}
+
+	err = serv.courseRepository.CompleteCourse(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (serv *courseService) GetUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressResponse, error) {
+	check, err := serv.hasCourseAccess(ctx, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	}
+
+	userProgress, err := serv.courseRepository.GetUserProgress(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*models.UserProgress
+	for _, progress := range userProgress {
+		temp := models.UserProgress{
+			UserID:     progress.UserID,
+			CourseID:   progress.CourseID,
+			MaterialID: progress.MaterialID,
+			Score:      progress.Score,
+		}
+
+		out = append(out, &temp)
+	}
+
+	materialProgress, err := serv.courseRepository.GetMaterialProgressByCourseID(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	syllabus, err := serv.courseRepository.GetCourseSyllabusByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]string{}
+	for _, material := range syllabus {
+		types[material.ID] = material.Type
+	}
+
+	materials := []*models.MaterialProgress{}
+	for _, progress := range materialProgress {
+		materials = append(materials, toMaterialProgress(progress, completionThreshold(types[progress.MaterialID])))
+	}
+
+	resp := &models.GetProgressResponse{
+		Progress:  out,
+		Materials: materials,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) CreateNewCourse(ctx context.Context, course *models.CourseCreation) (*models.CourseCreationResponse, error) {
+	newID := uuid.New().String()
+	course.Course.ID = newID
+	course.Course.Status = models.CourseStatusDraft
+
+	err := serv.validateCourseMaterials(ctx, course.Sections)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sect := range course.Sections {
+		releaseAt, releaseAfterDays, err := parseReleaseRule(sect.ReleaseAt, sect.ReleaseAfterDays)
+		if err != nil {
+			return nil, err
+		}
+		sect.ReleaseAt = releaseAt
+		sect.ReleaseAfterDays = releaseAfterDays
+
+		newID = uuid.New().String()
+		sect.ID = newID
+		for _, mat := range sect.Subsections {
+			// Assignment materials take the id of the assignment they embed.
+			if mat.Type == models.MaterialTypeAssignment {
+				mat.ID = mat.Content
+				continue
+			}
+
+			newID = uuid.New().String()
+			mat.ID = newID
+		}
+	}
+
+	err = serv.courseRepository.InsertCourse(ctx, serv.db, course)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Course Created Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UploadImage(ctx context.Context, request *http.Request, baseURL string) (*models.UploadImageResponse, error) {
+
+	imageId := uuid.New().String()
+	url := imageId + ".png"
+
+	request.ParseMultipartForm(10 << 20)
+
+	file, _, err := request.FormFile("imageFile")
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	// read all of the contents of our uploaded file into a
+	// byte array
+	fileBytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// return that we have successfully uploaded our file!
+	url, err = storeImage(fileBytes, url, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.UploadImageResponse{
+		Status:  "Success",
+		Message: "File Uploaded Succesfully",
+		URL:     url,
+	}
+
+	return resp, nil
+}
+
+// storeImage writes an uploaded file into static/image and returns the url it
+// is served from.
+func storeImage(data []byte, name string, baseURL string) (string, error) {
+	// Create a temporary file within our temp-images directory that follows
+	// a particular naming pattern
+	tempFile, err := ioutil.TempFile(imageDirectory, "course-*-"+name)
+	if err != nil {
+		return "", err
+	}
+	defer tempFile.Close()
+
+	// write this byte array to our temporary file
+	_, err = tempFile.Write(data)
+	if err != nil {
+		return "", err
+	}
+
+	return baseURL + tempFile.Name(), nil
+}
+
+func (serv *courseService) CreateCourseDesc(ctx context.Context, course *models.CourseDescriptionInput, creatorId string) (*models.CourseCreationResponse, error) {
+	id := uuid.New().String()
+
+	input := db.Course{
+		ID:          id,
+		CourseName:  course.CourseName,
+		Description: course.Description,
+		Thumbnail:   course.Thumbnail,
+		Creator:     creatorId,
+		Status:      models.CourseStatusDraft,
+	}
+
+	err := serv.courseRepository.InsertCourseData(ctx, serv.db, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Course Description Created Succesfully",
+		Id:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) CreateCourseSection(ctx context.Context, input *models.CourseSectionInput, creatorId string) (*models.CourseCreationResponse, error) {
+	id := uuid.New().String()
+
+	_, err := serv.authorizeCourse(ctx, input.CourseID, creatorId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseAt, releaseAfterDays, err := parseReleaseRule(input.ReleaseAt, input.ReleaseAfterDays)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := serv.courseRepository.GetNextMaterialPosition(ctx, serv.db, input.CourseID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	material := &db.Material{
+		ID:               id,
+		CourseID:         input.CourseID,
+		Name:             input.Name,
+		Type:             "section",
+		SectionID:        "",
+		Content:          "",
+		ContentText:      "",
+		Position:         position,
+		ReleaseAt:        releaseAt,
+		ReleaseAfterDays: releaseAfterDays,
+	}
+
+	err = serv.courseRepository.InsertCourseMaterial(ctx, serv.db, material)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Section Created Succesfully",
+		Id:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) CreateCourseMaterial(ctx context.Context, input *models.CourseMaterialInput, creatorId string) (*models.CourseCreationResponse, error) {
+	id := uuid.New().String()
+	courseId, err := serv.courseRepository.GetCourseIDByMaterialID(ctx, serv.db, input.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.authorizeCourse(ctx, courseId, creatorId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = serv.courseRepository.GetMaterialByID(ctx, serv.db, input.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.validateMaterial(ctx, input.Type, input.Content, input.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assignment materials take the id of the assignment they embed, learners
+	// submit their answers with it.
+	if input.Type == models.MaterialTypeAssignment {
+		id = input.Content
+
+		_, err = serv.courseRepository.GetMaterialByID(ctx, serv.db, id)
+		if err == nil {
+			return nil, er.NewError(fmt.Errorf("%s", "The assignment is already part of a course"), http.StatusBadRequest, nil)
+		}
+		if e, ok := err.(er.Error); !ok || e.HTTPStatusCode() != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	position, err := serv.courseRepository.GetNextMaterialPosition(ctx, serv.db, courseId, input.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	material := &db.Material{
+		ID:          id,
+		CourseID:    courseId,
+		Name:        input.Name,
+		Type:        input.Type,
+		SectionID:   input.SectionID,
+		Content:     input.Content,
+		ContentText: input.ContentText,
+		Metadata:    models.EncodeMaterialMetadata(input.Metadata),
+		Position:    position,
+	}
+	renderMaterial(material)
+
+	err = serv.courseRepository.InsertCourseMaterial(ctx, serv.db, material)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, courseId)
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Section Created Succesfully",
+		Id:      id,
+	}
+
+	return resp, nil
+}
+
+// CloneCourse deep-copies a course with its syllabus and linked assignments.
+// Every section and material gets a new id, materials are moved to the copies
+// of their sections and assignment materials keep sharing their id with the
+// copied assignment.
+func (serv *courseService) CloneCourse(ctx context.Context, courseId string, input *models.CourseCloneInput, userId string, isAdmin bool) (*models.CourseCreationResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin {
+		allowed, err := serv.hasCourseRole(ctx, course, userId, courseEditorRoles)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to clone this course"), http.StatusForbidden, nil)
+		}
+	}
+
+	clone := &db.CourseClone{
+		SourceID: course.ID,
+		Course:   *course,
+	}
+	clone.Course.ID = uuid.New().String()
+	clone.Course.Creator = userId
+	clone.Course.Status = models.CourseStatusDraft
+	if input.CourseName != "" {
+		clone.Course.CourseName = input.CourseName
+	}
+
+	materials, err := serv.courseRepository.GetMaterialsByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+	for _, material := range materials {
+		ids[material.ID] = uuid.New().String()
+	}
+
+	for _, material := range materials {
+		copied := *material
+		copied.ID = ids[material.ID]
+		copied.CourseID = clone.Course.ID
+		if material.SectionID != "" {
+			copied.SectionID = ids[material.SectionID]
+		}
+
+		// Assignment materials embed the copy of their assignment, which
+		// takes the id of the copied material.
+		if material.Type == models.MaterialTypeAssignment {
+			copied.Content = copied.ID
+			clone.Assignments = append(clone.Assignments, db.ClonedAssignment{
+				SourceID: material.ID,
+				ID:       copied.ID,
+			})
+		}
+
+		clone.Materials = append(clone.Materials, copied)
+	}
+
+	err = serv.courseRepository.CloneCourse(ctx, serv.db, clone)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, clone.Course.ID)
+	}
+
+	resp := &models.CourseCreationResponse{
+		Status:  "Success",
+		Message: "Course Cloned Succesfully",
+		Id:      clone.Course.ID,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) GetCourseByCreatorID(ctx context.Context, creatorId string) ([]*models.Course, error) {
+	db_courses, err := serv.courseRepository.GetCourseByCreatorID(ctx, serv.db, creatorId)
+	if err != nil {
+		return nil, err
+	}
+
+	var courses []*models.Course
+	for _, course := range db_courses {
+		courses = append(courses, &models.Course{
+			ID:          course.ID,
+			CourseName:  course.CourseName,
+			Description: course.Description,
+			Thumbnail:   course.Thumbnail,
+			Creator:     course.Creator,
+			Status:      course.Status,
+		})
+	}
+
+	return courses, nil
+}
+
+var (
+	courseOwnerRoles  = []string{models.CourseRoleOwner}
+	courseEditorRoles = []string{models.CourseRoleOwner, models.CourseRoleEditor}
+	courseStaffRoles  = []string{models.CourseRoleOwner, models.CourseRoleEditor, models.CourseRoleTeachingAssistant}
+)
+
+// getCourseRole returns the role the user holds on the course. The creator is
+// always the owner, other users need an accepted collaboration. An empty
+// string is returned for everyone else.
+func (serv *courseService) getCourseRole(ctx context.Context, course *db.Course, userId string) (string, error) {
+	if course.Creator == userId {
+		return models.CourseRoleOwner, nil
+	}
+
+	return serv.courseRepository.GetCollaboratorRole(ctx, serv.db, course.ID, userId)
+}
+
+func (serv *courseService) hasCourseRole(ctx context.Context, course *db.Course, userId string, roles []string) (bool, error) {
+	role, err := serv.getCourseRole(ctx, course, userId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, allowed := range roles {
+		if role == allowed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// authorizeCourse loads the course and makes sure the user holds one of the
+// given roles on it.
+func (serv *courseService) authorizeCourse(ctx context.Context, courseId, userId string, roles []string) (*db.Course, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := serv.hasCourseRole(ctx, course, userId, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil)
+	}
+
+	return course, nil
+}
+
+func (serv *courseService) UpdateCourseDesc(ctx context.Context, courseId string, input *models.CourseDescriptionUpdate, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CourseName != nil {
+		course.CourseName = *input.CourseName
+	}
+	if input.Description != nil {
+		course.Description = *input.Description
+	}
+	if input.Thumbnail != nil {
+		course.Thumbnail = *input.Thumbnail
+	}
+
+	err = serv.courseRepository.UpdateCourseData(ctx, serv.db, course)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, courseId)
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Description Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteCourse(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseOwnerRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteCourse(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, courseId)
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Deleted Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCourseSection(ctx context.Context, sectionId string, input *models.CourseSectionUpdateInput, userId string) (*models.CourseUpdateResponse, error) {
+	section, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, sectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if section.Type != "section" {
+		return nil, er.NewError(fmt.Errorf("%s", "Section not found"), http.StatusNotFound, nil)
+	}
+
+	_, err = serv.authorizeCourse(ctx, section.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseAt, releaseAfterDays, err := parseReleaseRule(input.ReleaseAt, input.ReleaseAfterDays)
+	if err != nil {
+		return nil, err
+	}
+
+	section.Name = input.Name
+	section.ReleaseAt = releaseAt
+	section.ReleaseAfterDays = releaseAfterDays
+	err = serv.courseRepository.UpdateCourseMaterial(ctx, serv.db, section)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Section Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteCourseSection(ctx context.Context, sectionId string, cascade bool, userId string) (*models.CourseUpdateResponse, error) {
+	section, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, sectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if section.Type != "section" {
+		return nil, er.NewError(fmt.Errorf("%s", "Section not found"), http.StatusNotFound, nil)
+	}
+
+	_, err = serv.authorizeCourse(ctx, section.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	materials, err := serv.courseRepository.GetCourseMaterialByCourseIDAndSectionID(ctx, serv.db, section.CourseID, sectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(materials) > 0 && !cascade {
+		return nil, er.NewError(fmt.Errorf("%s", "Section still has materials, delete them first or use cascade"), http.StatusBadRequest, nil)
+	}
+
+	ids := []string{}
+	for _, material := range materials {
+		ids = append(ids, material.ID)
+	}
+	ids = append(ids, sectionId)
+
+	err = serv.courseRepository.DeleteCourseMaterials(ctx, serv.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, section.CourseID)
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Section Deleted Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCourseMaterial(ctx context.Context, materialId string, input *models.CourseMaterialUpdate, userId string) (*models.CourseUpdateResponse, error) {
+	material, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, materialId)
+	if err != nil {
+		return nil, err
+	}
+
+	if material.Type == "section" {
+		return nil, er.NewError(fmt.Errorf("%s", "Material not found"), http.StatusNotFound, nil)
+	}
+
+	_, err = serv.authorizeCourse(ctx, material.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		material.Name = *input.Name
+	}
+	if input.Type != nil {
+		if *input.Type == "section" {
+			return nil, er.NewError(fmt.Errorf("%s", "Material type cannot be section"), http.StatusBadRequest, nil)
+		}
+		material.Type = *input.Type
+	}
+	if input.Content != nil {
+		material.Content = *input.Content
+	}
+	if input.ContentText != nil {
+		material.ContentText = *input.ContentText
+	}
+	if input.Metadata != nil {
+		material.Metadata = models.EncodeMaterialMetadata(input.Metadata)
+	}
+
+	// Materials written before the type registry existed may still be renamed
+	// without touching their content.
+	if input.Type != nil || input.Content != nil || input.Metadata != nil {
+		if material.Type == models.MaterialTypeAssignment {
+			if material.Content != "" && material.Content != material.ID {
+				return nil, er.NewError(fmt.Errorf("%s", "The assignment of a material can not be changed"), http.StatusBadRequest, nil)
+			}
+			material.Content = material.ID
+		}
+
+		err = serv.validateMaterial(ctx, material.Type, material.Content, models.DecodeMaterialMetadata(material.Metadata))
+		if err != nil {
+			return nil, err
+		}
+	}
+	renderMaterial(material)
+
+	err = serv.courseRepository.UpdateCourseMaterial(ctx, serv.db, material)
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, material.CourseID)
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Material Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteCourseMaterial(ctx context.Context, materialId string, userId string) (*models.CourseUpdateResponse, error) {
+	material, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, materialId)
+	if err != nil {
+		return nil, err
+	}
+
+	if material.Type == "section" {
+		return nil, er.NewError(fmt.Errorf("%s", "Material not found"), http.StatusNotFound, nil)
+	}
+
+	_, err = serv.authorizeCourse(ctx, material.CourseID, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteCourseMaterials(ctx, serv.db, []string{materialId})
+	if err != nil {
+		return nil, err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, material.CourseID)
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Material Deleted Succesfully",
+	}
+
+	return resp, nil
+}
+
+// ReorderSyllabus rewrites the position of every section and material of a
+// course from the given order. Materials listed under another section are
+// moved there. The input must list the whole syllabus so positions stay
+// contiguous.
+func (serv *courseService) ReorderSyllabus(ctx context.Context, courseId string, input *models.SyllabusOrderInput, userId string) (*models.CourseUpdateResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	db_syllabus, err := serv.courseRepository.GetCourseSyllabusByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[string]*db.Syllabus{}
+	for _, item := range db_syllabus {
+		items[item.ID] = item
+	}
+
+	var (
+		errs      []er.ErrorStruct
+		positions []*db.MaterialPosition
+	)
+	seen := map[string]bool{}
+
+	for sectPosition, sect := range input.Sections {
+		field := fmt.Sprintf("sections[%d]", sectPosition)
+		item, ok := items[sect.ID]
+		if !ok || item.Type != "section" {
+			errs = append(errs, er.ErrorStruct{Field: field + ".sectionID", Reason: "Section does not belong to this course"})
+			continue
+		}
+		if seen[sect.ID] {
+			errs = append(errs, er.ErrorStruct{Field: field + ".sectionID", Reason: "Section is listed more than once"})
+			continue
+		}
+		seen[sect.ID] = true
+
+		positions = append(positions, &db.MaterialPosition{
+			ID:        sect.ID,
+			SectionID: "",
+			Position:  sectPosition,
+		})
+
+		for matPosition, materialId := range sect.Materials {
+			matField := fmt.Sprintf("%s.materials[%d]", field, matPosition)
+			item, ok := items[materialId]
+			if !ok || item.Type == "section" {
+				errs = append(errs, er.ErrorStruct{Field: matField, Reason: "Material does not belong to this course"})
+				continue
+			}
+			if seen[materialId] {
+				errs = append(errs, er.ErrorStruct{Field: matField, Reason: "Material is listed more than once"})
+				continue
+			}
+			seen[materialId] = true
+
+			positions = append(positions, &db.MaterialPosition{
+				ID:        materialId,
+				SectionID: sect.ID,
+				Position:  matPosition,
+			})
+		}
+	}
+
+	for _, item := range db_syllabus {
+		if !seen[item.ID] {
+			errs = append(errs, er.ErrorStruct{Field: "sections", Reason: fmt.Sprintf("%s is missing from the new order", item.Name)})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid syllabus order"), http.StatusBadRequest, &errs)
+	}
+
+	err = serv.courseRepository.UpdateMaterialPositions(ctx, serv.db, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Syllabus Reordered Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) GetCourseReviewList(ctx context.Context, meta *pagination.Meta) ([]*models.Course, uint64, error) {
+	var (
+		count   uint64
+		courses []*models.Course
+	)
+
+	db_courses, count, err := serv.courseRepository.GetCourseListByStatus(ctx, serv.db, meta, models.CourseStatusInReview, models.CourseFilter{})
+	if err != nil {
+		return courses, count, err
+	}
+
+	for _, course := range db_courses {
+		courses = append(courses, &models.Course{
+			ID:          course.ID,
+			CourseName:  course.CourseName,
+			Description: course.Description,
+			Thumbnail:   course.Thumbnail,
+			Creator:     course.Creator,
+			Status:      course.Status,
+		})
+	}
+
+	return courses, count, nil
+}
+
+// changeCourseStatus moves a course to the next status, making sure it
+// currently sits in one of the allowed source statuses.
+func (serv *courseService) changeCourseStatus(ctx context.Context, course *db.Course, from []string, to string) error {
+	allowed := false
+	for _, status := range from {
+		if course.Status == status {
+			allowed = true
+		}
+	}
+
+	if !allowed {
+		return er.NewError(fmt.Errorf("Course with status %s cannot be moved to %s", course.Status, to), http.StatusBadRequest, nil)
+	}
+
+	value := &models.CourseStatusUpdate{
+		Id:     course.ID,
+		Status: to,
+	}
+
+	err := serv.courseRepository.UpdateCourseStatus(ctx, serv.db, value)
+	if err != nil {
+		return err
+	}
+
+	if serv.searchService != nil {
+		serv.searchService.TrySyncCourse(ctx, course.ID)
+	}
+
+	return nil
+}
+
+func (serv *courseService) SubmitCourseForReview(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	syllabus, err := serv.courseRepository.GetCourseSyllabusByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionCount := 0
+	for _, item := range syllabus {
+		if item.Type == "section" {
+			sectionCount += 1
+		}
+	}
+
+	if sectionCount == 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "Course needs at least one section before it can be reviewed"), http.StatusBadRequest, nil)
+	}
+
+	err = serv.changeCourseStatus(ctx, course, []string{models.CourseStatusDraft}, models.CourseStatusInReview)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Submitted For Review",
+	}
+
+	return resp, nil
 }
 
-func (serv *courseService) GetCourseByCreatorID(ctx context.Context, creatorId string) ([]*models.Course, error) {
-	db_courses, err := serv.courseRepository.GetCourseByCreatorID(ctx, serv.db, creatorId)
+func (serv *courseService) PublishCourse(ctx context.Context, courseId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
 	if err != nil {
 		return nil, err
 	}
 
-	var courses []*models.Course
-	for _, course := range db_courses {
-		courses = append(courses, &models.Course{
-			ID:          course.ID,
-			CourseName:  course.CourseName,
-			Description: course.Description,
-			Thumbnail:   course.Thumbnail,
-			Creator:     course.Creator,
+	err = serv.changeCourseStatus(ctx, course, []string{models.CourseStatusInReview}, models.CourseStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Published Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) RejectCourse(ctx context.Context, courseId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.changeCourseStatus(ctx, course, []string{models.CourseStatusInReview}, models.CourseStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Sent Back To Draft",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) ArchiveCourse(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CourseUpdateResponse, error) {
+	var (
+		course *db.Course
+		err    error
+	)
+
+	if isAdmin {
+		course, err = serv.courseRepository.GetCourseById(ctx, serv.db, courseId)
+	} else {
+		course, err = serv.authorizeCourse(ctx, courseId, userId, courseOwnerRoles)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.changeCourseStatus(ctx, course, []string{models.CourseStatusDraft, models.CourseStatusPublished}, models.CourseStatusArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Archived Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) getCourseTopics(ctx context.Context, courseId string) ([]*models.Topic, error) {
+	db_topics, err := serv.courseRepository.GetTopicsByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := []*models.Topic{}
+	for _, topic := range db_topics {
+		topics = append(topics, &models.Topic{
+			ID:   topic.ID,
+			Name: topic.Name,
 		})
 	}
 
-	return courses, nil
+	return topics, nil
+}
+
+func (serv *courseService) GetTopicList(ctx context.Context) ([]*models.Topic, error) {
+	db_topics, err := serv.courseRepository.GetTopicList(ctx, serv.db)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := []*models.Topic{}
+	for _, topic := range db_topics {
+		topics = append(topics, &models.Topic{
+			ID:   topic.ID,
+			Name: topic.Name,
+		})
+	}
+
+	return topics, nil
+}
+
+func (serv *courseService) CreateTopic(ctx context.Context, input *models.TopicInput) (*models.TopicResponse, error) {
+	id := uuid.New().String()
+
+	topic := &db.Topic{
+		ID:   id,
+		Name: input.Name,
+	}
+
+	err := serv.courseRepository.InsertTopic(ctx, serv.db, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.TopicResponse{
+		Status:  "Success",
+		Message: "Topic Created Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateTopic(ctx context.Context, id string, input *models.TopicInput) (*models.TopicResponse, error) {
+	topic, err := serv.courseRepository.GetTopicById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	topic.Name = input.Name
+	err = serv.courseRepository.UpdateTopic(ctx, serv.db, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.TopicResponse{
+		Status:  "Success",
+		Message: "Topic Updated Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) DeleteTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
+	_, err := serv.courseRepository.GetTopicById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.courseRepository.DeleteTopic(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.TopicResponse{
+		Status:  "Success",
+		Message: "Topic Deleted Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) SetCourseTopics(ctx context.Context, courseId string, input *models.CourseTopicInput, userId string) (*models.CourseUpdateResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []er.ErrorStruct
+	topicIds := []string{}
+	seen := map[string]bool{}
+
+	for idx, topicId := range input.Topics {
+		if seen[topicId] {
+			continue
+		}
+		seen[topicId] = true
+
+		_, err := serv.courseRepository.GetTopicById(ctx, serv.db, topicId)
+		if err != nil {
+			if e, ok := err.(er.Error); ok && e.HTTPStatusCode() == http.StatusNotFound {
+				errs = append(errs, er.ErrorStruct{Field: fmt.Sprintf("topics[%d]", idx), Reason: "Topic not found"})
+				continue
+			}
+			return nil, err
+		}
+
+		topicIds = append(topicIds, topicId)
+	}
+
+	if len(errs) > 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid course topics"), http.StatusBadRequest, &errs)
+	}
+
+	err = serv.courseRepository.SetCourseTopics(ctx, serv.db, courseId, topicIds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Topics Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+// checkPrerequisites rejects the enrollment when the user has not completed
+// every prerequisite of the course.
+func (serv *courseService) checkPrerequisites(ctx context.Context, userId, courseId string) error {
+	missing, err := serv.courseRepository.GetMissingPrerequisites(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var errs []er.ErrorStruct
+	for _, course := range missing {
+		errs = append(errs, er.ErrorStruct{Field: course.ID, Reason: fmt.Sprintf("%s has not been completed", course.CourseName)})
+	}
+
+	return er.NewError(fmt.Errorf("%s", "You have not completed the prerequisites of the course"), http.StatusBadRequest, &errs)
+}
+
+func (serv *courseService) GetCoursePrerequisites(ctx context.Context, courseId string, userId string) ([]*models.Prerequisite, error) {
+	prerequisites := []*models.Prerequisite{}
+
+	db_prerequisites, err := serv.courseRepository.GetPrerequisitesByCourseID(ctx, serv.db, courseId)
+	if err != nil {
+		return prerequisites, err
+	}
+
+	missing, err := serv.courseRepository.GetMissingPrerequisites(ctx, serv.db, userId, courseId)
+	if err != nil {
+		return prerequisites, err
+	}
+
+	notCompleted := map[string]bool{}
+	for _, course := range missing {
+		notCompleted[course.ID] = true
+	}
+
+	for _, course := range db_prerequisites {
+		prerequisites = append(prerequisites, &models.Prerequisite{
+			ID:         course.ID,
+			CourseName: course.CourseName,
+			Completed:  !notCompleted[course.ID],
+		})
+	}
+
+	return prerequisites, nil
+}
+
+// requiresCourse tells whether courseId can be reached by following the
+// prerequisites of from, which means adding from as a prerequisite of
+// courseId would create a cycle.
+func (serv *courseService) requiresCourse(ctx context.Context, from, courseId string, visited map[string]bool) (bool, error) {
+	if from == courseId {
+		return true, nil
+	}
+
+	if visited[from] {
+		return false, nil
+	}
+	visited[from] = true
+
+	prerequisites, err := serv.courseRepository.GetPrerequisitesByCourseID(ctx, serv.db, from)
+	if err != nil {
+		return false, err
+	}
+
+	for _, course := range prerequisites {
+		found, err := serv.requiresCourse(ctx, course.ID, courseId, visited)
+		if err != nil || found {
+			return found, err
+		}
+	}
+
+	return false, nil
+}
+
+func (serv *courseService) SetCoursePrerequisites(ctx context.Context, courseId string, input *models.CoursePrerequisiteInput, userId string) (*models.CourseUpdateResponse, error) {
+	_, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []er.ErrorStruct
+	prerequisiteIds := []string{}
+	seen := map[string]bool{}
+
+	for idx, prerequisiteId := range input.Prerequisites {
+		if seen[prerequisiteId] {
+			continue
+		}
+		seen[prerequisiteId] = true
+
+		field := fmt.Sprintf("prerequisites[%d]", idx)
+
+		if prerequisiteId == courseId {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "A course cannot be its own prerequisite"})
+			continue
+		}
+
+		_, err := serv.courseRepository.GetCourseById(ctx, serv.db, prerequisiteId)
+		if err != nil {
+			if e, ok := err.(er.Error); ok && e.HTTPStatusCode() == http.StatusNotFound {
+				errs = append(errs, er.ErrorStruct{Field: field, Reason: "Course not found"})
+				continue
+			}
+			return nil, err
+		}
+
+		cycle, err := serv.requiresCourse(ctx, prerequisiteId, courseId, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+
+		if cycle {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "Course already requires this course"})
+			continue
+		}
+
+		prerequisiteIds = append(prerequisiteIds, prerequisiteId)
+	}
+
+	if len(errs) > 0 {
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid course prerequisites"), http.StatusBadRequest, &errs)
+	}
+
+	err = serv.courseRepository.SetCoursePrerequisites(ctx, serv.db, courseId, prerequisiteIds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Prerequisites Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+func (serv *courseService) UpdateCourseAccess(ctx context.Context, courseId string, input *models.CourseAccessInput, userId string) (*models.CourseUpdateResponse, error) {
+	course, err := serv.authorizeCourse(ctx, courseId, userId, courseEditorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	course.Sequential = input.Sequential
+	course.MinScore = input.MinScore
+
+	err = serv.courseRepository.UpdateCourseAccess(ctx, serv.db, course)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CourseUpdateResponse{
+		Status:  "Success",
+		Message: "Course Access Updated Succesfully",
+	}
+
+	return resp, nil
+}
+
+// releaseDateLayout is how section release dates are stored.
+const releaseDateLayout = "2006-01-02 15:04:05"
+
+// parseReleaseRule validates the release rule of a section and converts
+// releaseAt from RFC3339 into releaseDateLayout.
+func parseReleaseRule(releaseAt *string, releaseAfterDays *int) (*string, *int, error) {
+	if releaseAt != nil && releaseAfterDays != nil {
+		return nil, nil, er.NewError(fmt.Errorf("%s", "A section can only have one release rule"), http.StatusBadRequest, nil)
+	}
+
+	if releaseAt == nil {
+		return nil, releaseAfterDays, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, *releaseAt)
+	if err != nil {
+		return nil, nil, er.NewError(fmt.Errorf("%s", "Invalid section release date"), http.StatusBadRequest, nil)
+	}
+
+	formatted := date.UTC().Format(releaseDateLayout)
+	return &formatted, nil, nil
+}
+
+func formatReleaseDate(releaseAt *string) *string {
+	if releaseAt == nil {
+		return nil
+	}
+
+	date, err := time.Parse(releaseDateLayout, *releaseAt)
+	if err != nil {
+		return releaseAt
+	}
+
+	formatted := date.Format(time.RFC3339)
+	return &formatted
+}
+
+// getUnreleasedSections tells which sections have not been released to the
+// user yet. Sections released some days after enrollment stay unreleased
+// while the user is not enrolled.
+func (serv *courseService) getUnreleasedSections(ctx context.Context, userId, courseId string, sections []*models.Section) (map[string]bool, error) {
+	unreleased := map[string]bool{}
+	now := time.Now().UTC()
+
+	var startDate *time.Time
+	startLoaded := false
+
+	for _, section := range sections {
+		switch {
+		case section.ReleaseAt != nil:
+			releaseAt, err := time.Parse(time.RFC3339, *section.ReleaseAt)
+			if err != nil {
+				return nil, err
+			}
+
+			if now.Before(releaseAt) {
+				unreleased[section.ID] = true
+			}
+		case section.ReleaseAfterDays != nil:
+			if !startLoaded {
+				startLoaded = true
+
+				start, err := serv.courseRepository.GetEnrollmentStartDate(ctx, serv.db, userId, courseId)
+				if err != nil {
+					return nil, err
+				}
+
+				if start != nil {
+					date, err := time.Parse(releaseDateLayout, *start)
+					if err != nil {
+						return nil, err
+					}
+					startDate = &date
+				}
+			}
+
+			if startDate == nil || now.Before(startDate.AddDate(0, 0, *section.ReleaseAfterDays)) {
+				unreleased[section.ID] = true
+			}
+		}
+	}
+
+	return unreleased, nil
 }
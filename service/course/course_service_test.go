@@ -2,9 +2,11 @@ package course_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -74,7 +76,8 @@ func TestCourseService_GetCourseDetail(t *testing.T) {
 				CourseName: "",
 				Description: "",
 				Thumbnail: "",
-				Creator: creatorId,
+				Creator: "creator",
+				Topics: []*models.Topic{},
 			},
 			wantErr: nil,
 		},
@@ -85,12 +88,24 @@ func TestCourseService_GetCourseDetail(t *testing.T) {
 			sqlxDB, _ := sqlx.Open("test", "test")
 
 			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			userMock.
+				On("GetUserById", mock.Anything, mock.Anything, creatorId).
+				Return(&db_models.User{ID: creatorId, Username: "creator"}, nil)
 
 			repoMock.
 				On("GetCourseById",mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.res, tt.mock.err)
+			repoMock.
+				On("GetTopicsByCourseID",mock.Anything, mock.Anything, mock.Anything).
+				Return([]*db_models.Topic{}, nil)
+			repoMock.
+				On("GetCourseRatings", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*db_models.CourseRating{}, nil)
 
 			got, err := svc.GetCourseDetail(tt.args.ctx, tt.args.id)
 			
@@ -165,21 +180,21 @@ func TestCourseService_GetCompletedCourse(t *testing.T) {
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 				{
 					ID: courseId2,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 				{
 					ID: courseId3,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 			},
 			wantCount: 3,
@@ -192,8 +207,14 @@ func TestCourseService_GetCompletedCourse(t *testing.T) {
 			sqlxDB, _ := sqlx.Open("test", "test")
 
 			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			userMock.
+				On("GetUserById", mock.Anything, mock.Anything, creatorId).
+				Return(&db_models.User{ID: creatorId, Username: "creator"}, nil)
 
 			repoMock.
 				On("GetCompletedCourseByUserID",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -272,21 +293,21 @@ func TestCourseService_GetOnProgressCourse(t *testing.T) {
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 				{
 					ID: courseId2,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 				{
 					ID: courseId3,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: creatorId,
+					Creator: "creator",
 				},
 			},
 			wantCount: 3,
@@ -299,8 +320,14 @@ func TestCourseService_GetOnProgressCourse(t *testing.T) {
 			sqlxDB, _ := sqlx.Open("test", "test")
 
 			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			userMock.
+				On("GetUserById", mock.Anything, mock.Anything, creatorId).
+				Return(&db_models.User{ID: creatorId, Username: "creator"}, nil)
 
 			repoMock.
 				On("GetOnProgressCourseByUserID",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -351,21 +378,21 @@ func TestCourseService_GetCoursePagination(t *testing.T) {
 						CourseName: "",
 						Description: "",
 						Thumbnail: "",
-						Creator: "",
+						Creator: creatorId,
 					},
 					{
 						ID: courseId2,
 						CourseName: "",
 						Description: "",
 						Thumbnail: "",
-						Creator: "",
+						Creator: creatorId,
 					},
 					{
 						ID: courseId3,
 						CourseName: "",
 						Description: "",
 						Thumbnail: "",
-						Creator: "",
+						Creator: creatorId,
 					},
 				},
 				count: 3,
@@ -377,21 +404,24 @@ func TestCourseService_GetCoursePagination(t *testing.T) {
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: "",
+					Creator: "creator",
+					Topics: []*models.Topic{},
 				},
 				{
 					ID: courseId2,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: "",
+					Creator: "creator",
+					Topics: []*models.Topic{},
 				},
 				{
 					ID: courseId3,
 					CourseName: "",
 					Description: "",
 					Thumbnail: "",
-					Creator: "",
+					Creator: "creator",
+					Topics: []*models.Topic{},
 				},
 			},
 			wantCount: 3,
@@ -404,14 +434,26 @@ func TestCourseService_GetCoursePagination(t *testing.T) {
 			sqlxDB, _ := sqlx.Open("test", "test")
 
 			repoMock := new(mocks.CourseRepository)
+			userMock := new(mocks.UserRepository)
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
+			svc.InjectUserRepository(userMock)
+
+			userMock.
+				On("GetUserById", mock.Anything, mock.Anything, creatorId).
+				Return(&db_models.User{ID: creatorId, Username: "creator"}, nil)
 
 			repoMock.
-				On("GetCourseList",mock.Anything, mock.Anything, mock.Anything).
+				On("GetCourseList",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.res,tt.mock.count, tt.mock.err)
+			repoMock.
+				On("GetTopicsByCourseID",mock.Anything, mock.Anything, mock.Anything).
+				Return([]*db_models.Topic{}, nil)
+			repoMock.
+				On("GetCourseRatings", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*db_models.CourseRating{}, nil)
 
-			got, cou, err := svc.GetCoursePagination(tt.args.ctx, tt.args.meta)
+			got, cou, err := svc.GetCoursePagination(tt.args.ctx, tt.args.meta, models.CourseFilter{})
 			
 			assert.Equal(t, tt.wantCourses, got, tt.name)
 			assert.Equal(t, tt.wantCount, cou, tt.name)
@@ -421,14 +463,25 @@ func TestCourseService_GetCoursePagination(t *testing.T) {
 }
 
 func TestCourseService_GetCourseSyllabus(t *testing.T) {
+	sectionId2 := uuid.New().String()
+	sectionId3 := uuid.New().String()
+	sectionId4 := uuid.New().String()
+	pastDate := "2020-01-01 00:00:00"
+	futureDate := time.Now().UTC().AddDate(1, 0, 0).Format("2006-01-02 15:04:05")
+	startDate := time.Now().UTC().AddDate(0, 0, -2).Format("2006-01-02 15:04:05")
+	oneDay := 1
+	sevenDays := 7
+
 	type mockRepo struct {
-		res []*db_models.Syllabus
-		err error
+		res  []*db_models.Syllabus
+		role string
+		err  error
 	}
 
 	type args struct {
 		ctx context.Context
 		courseId  string
+		userId    string
 	}
 
 	tests := []struct {
@@ -443,6 +496,7 @@ func TestCourseService_GetCourseSyllabus(t *testing.T) {
 			args: args{
 				context.TODO(),
 				courseId,
+				userId,
 			},
 			mock: mockRepo{
 				res: []*db_models.Syllabus{
@@ -497,6 +551,7 @@ func TestCourseService_GetCourseSyllabus(t *testing.T) {
 			args: args{
 				context.TODO(),
 				courseId,
+				userId,
 			},
 			mock: mockRepo{
 				res: []*db_models.Syllabus{
@@ -539,6 +594,55 @@ func TestCourseService_GetCourseSyllabus(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "[GetCourseSyllabus] Lock sections which are not released yet",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			mock: mockRepo{
+				res: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section", ReleaseAt: &pastDate},
+					{ID: sectionId2, CourseID: courseId, Type: "section", ReleaseAt: &futureDate},
+					{ID: sectionId3, CourseID: courseId, Type: "section", ReleaseAfterDays: &oneDay},
+					{ID: sectionId4, CourseID: courseId, Type: "section", ReleaseAfterDays: &sevenDays},
+				},
+				err: nil,
+			},
+			want: &models.SyllabusResponse{
+				Syllabus: []*models.Section{
+					{ID: syllabusId, ReleaseAt: func() *string { s := "2020-01-01T00:00:00Z"; return &s }()},
+					{ID: sectionId2, ReleaseAt: func() *string { s, _ := time.Parse("2006-01-02 15:04:05", futureDate); f := s.Format(time.RFC3339); return &f }(), Locked: true},
+					{ID: sectionId3, ReleaseAfterDays: &oneDay},
+					{ID: sectionId4, ReleaseAfterDays: &sevenDays, Locked: true},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCourseSyllabus] Teaching assistants see every section unlocked",
+			args: args{
+				context.TODO(),
+				courseId,
+				userId,
+			},
+			mock: mockRepo{
+				res: []*db_models.Syllabus{
+					{ID: sectionId2, CourseID: courseId, Type: "section", ReleaseAt: &futureDate},
+					{ID: sectionId4, CourseID: courseId, Type: "section", ReleaseAfterDays: &sevenDays},
+				},
+				role: models.CourseRoleTeachingAssistant,
+				err:  nil,
+			},
+			want: &models.SyllabusResponse{
+				Syllabus: []*models.Section{
+					{ID: sectionId2, ReleaseAt: func() *string { s, _ := time.Parse("2006-01-02 15:04:05", futureDate); f := s.Format(time.RFC3339); return &f }()},
+					{ID: sectionId4, ReleaseAfterDays: &sevenDays},
+				},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -549,11 +653,27 @@ func TestCourseService_GetCourseSyllabus(t *testing.T) {
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
 
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, tt.args.courseId, tt.args.userId).
+				Return(tt.mock.role, nil)
+
 			repoMock.
 				On("GetCourseSyllabusByCourseID",mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.res, tt.mock.err)
 
-			got, err := svc.GetCourseSyllabus(tt.args.ctx, tt.args.courseId)
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(false, nil)
+
+			repoMock.
+				On("GetEnrollmentStartDate", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(&startDate, nil)
+
+			got, err := svc.GetCourseSyllabus(tt.args.ctx, tt.args.courseId, tt.args.userId, false)
 			
 			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
@@ -563,15 +683,57 @@ func TestCourseService_GetCourseSyllabus(t *testing.T) {
 }
 
 func TestCourseService_GetCourseMaterial(t *testing.T) {
+	syllabusId4 := uuid.New().String()
+
+	materials := []*db_models.Material{
+		{
+			ID: syllabusId2,
+			CourseID: courseId,
+			Name: "",
+			Type: "video",
+			SectionID: syllabusId,
+			Content: "video.mp4",
+			ContentText: "",
+		},
+		{
+			ID: syllabusId3,
+			CourseID: courseId,
+			Name: "",
+			Type: "assignment",
+			SectionID: syllabusId,
+			Content: "",
+			ContentText: "",
+		},
+		{
+			ID: syllabusId4,
+			CourseID: courseId,
+			Name: "",
+			Type: "text",
+			SectionID: syllabusId,
+			Content: "",
+			ContentText: "Lorem ipsum",
+		},
+	}
+
+	syllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Type: "section"},
+		{ID: syllabusId2, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+		{ID: syllabusId3, CourseID: courseId, Type: "assignment", SectionID: &syllabusId},
+		{ID: syllabusId4, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+	}
+
 	type mockRepo struct {
-		res  []*db_models.Material
-		err  error
+		course    *db_models.Course
+		enrolled  bool
+		completed bool
+		progress  []*db_models.UserProgress
 	}
 
 	type args struct {
 		ctx       context.Context
 		courseId  string
 		sectionId string
+		userId    string
 	}
 
 	tests := []struct {
@@ -587,51 +749,110 @@ func TestCourseService_GetCourseMaterial(t *testing.T) {
 				context.TODO(),
 				courseId,
 				syllabusId,
+				userId,
 			},
 			mock: mockRepo{
-				res: []*db_models.Material{
-					{	
-						ID: syllabusId2,
-						CourseID: courseId,
-						Name: "",
-						Type: "video",
-						SectionID: syllabusId,
-						Content: "",
-						ContentText: "",
-					},
-					{	
-						ID: syllabusId3,
-						CourseID: courseId,
-						Name: "",
-						Type: "assignment",
-						SectionID: syllabusId,
-						Content: "",
-						ContentText: "",
-					},
+				course: &db_models.Course{ID: courseId, Creator: creatorId},
+				enrolled: true,
+			},
+			want: &models.SectionContentResponse{
+				ID: syllabusId,
+				Subsections: []*models.MaterialContent{
+					{ID: syllabusId2, Type: "video", Content: "video.mp4", Payload: &models.VideoPayload{URL: "video.mp4"}},
+					{ID: syllabusId3, Type: "assignment", Payload: &models.AssignmentPayload{AssignmentID: syllabusId3}},
+					{ID: syllabusId4, Type: "text", ContentText: "Lorem ipsum", ContentHTML: "<p>Lorem ipsum</p>\n", Payload: &models.TextPayload{}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCourseMaterial] Lock materials after an assignment below the minimum score",
+			args: args{
+				context.TODO(),
+				courseId,
+				syllabusId,
+				userId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId, Sequential: true, MinScore: 60},
+				enrolled: true,
+				progress: []*db_models.UserProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, Score: 100},
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId3, Score: 40},
 				},
-				err: nil,
 			},
 			want: &models.SectionContentResponse{
 				ID: syllabusId,
 				Subsections: []*models.MaterialContent{
-					{
-						ID: syllabusId2,
-						Name: "",
-						Type: "video",
-						Content: "",
-						ContentText: "",
-					},
-					{
-						ID: syllabusId3,
-						Name: "",
-						Type: "assignment",
-						Content: "",
-						ContentText: "",
-					},
+					{ID: syllabusId2, Type: "video", Content: "video.mp4", Payload: &models.VideoPayload{URL: "video.mp4"}},
+					{ID: syllabusId3, Type: "assignment", Payload: &models.AssignmentPayload{AssignmentID: syllabusId3}},
+					{ID: syllabusId4, Type: "text", Locked: true},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCourseMaterial] Unlock materials once the assignment is passed",
+			args: args{
+				context.TODO(),
+				courseId,
+				syllabusId,
+				userId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId, Sequential: true, MinScore: 60},
+				enrolled: true,
+				progress: []*db_models.UserProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, Score: 100},
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId3, Score: 40},
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId3, Score: 80},
+				},
+			},
+			want: &models.SectionContentResponse{
+				ID: syllabusId,
+				Subsections: []*models.MaterialContent{
+					{ID: syllabusId2, Type: "video", Content: "video.mp4", Payload: &models.VideoPayload{URL: "video.mp4"}},
+					{ID: syllabusId3, Type: "assignment", Payload: &models.AssignmentPayload{AssignmentID: syllabusId3}},
+					{ID: syllabusId4, Type: "text", ContentText: "Lorem ipsum", ContentHTML: "<p>Lorem ipsum</p>\n", Payload: &models.TextPayload{}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetCourseMaterial] Creator previews a sequential course",
+			args: args{
+				context.TODO(),
+				courseId,
+				syllabusId,
+				creatorId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId, Sequential: true},
+			},
+			want: &models.SectionContentResponse{
+				ID: syllabusId,
+				Subsections: []*models.MaterialContent{
+					{ID: syllabusId2, Type: "video", Content: "video.mp4", Payload: &models.VideoPayload{URL: "video.mp4"}},
+					{ID: syllabusId3, Type: "assignment", Payload: &models.AssignmentPayload{AssignmentID: syllabusId3}},
+					{ID: syllabusId4, Type: "text", ContentText: "Lorem ipsum", ContentHTML: "<p>Lorem ipsum</p>\n", Payload: &models.TextPayload{}},
 				},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "[GetCourseMaterial] User is not enrolled to the course",
+			args: args{
+				context.TODO(),
+				courseId,
+				syllabusId,
+				userId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId},
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -642,11 +863,35 @@ func TestCourseService_GetCourseMaterial(t *testing.T) {
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
 
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(tt.mock.course, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, tt.args.courseId, tt.args.userId).
+				Return("", nil)
+
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.enrolled, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.completed, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(syllabus, nil)
+
+			repoMock.
+				On("GetUserProgress", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.progress, nil)
+
 			repoMock.
 				On("GetCourseMaterialByCourseIDAndSectionID",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.res, tt.mock.err)
+				Return(materials, nil)
 
-			got, err := svc.GetCourseMaterial(tt.args.ctx, tt.args.courseId, tt.args.sectionId)
+			got, err := svc.GetCourseMaterial(tt.args.ctx, tt.args.courseId, tt.args.sectionId, tt.args.userId, false)
 			
 			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
@@ -657,10 +902,14 @@ func TestCourseService_GetCourseMaterial(t *testing.T) {
 
 func TestCourseService_Enroll(t *testing.T) {
 	type mockRepo struct {
+		course *db_models.Course
 		check struct {
 			is  bool
 			err error
 		}
+		completed bool
+		enrolledBefore bool
+		missing []*db_models.Course
 		insert struct {
 			err error
 		}
@@ -687,6 +936,7 @@ func TestCourseService_Enroll(t *testing.T) {
 				courseId,
 			},
 			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
 				check: struct{is bool; err error}{
 					false,
 					nil,
@@ -709,6 +959,7 @@ func TestCourseService_Enroll(t *testing.T) {
 				courseId,
 			},
 			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
 				check: struct{is bool; err error}{
 					true,
 					nil,
@@ -720,6 +971,93 @@ func TestCourseService_Enroll(t *testing.T) {
 			want: nil,
 			wantErr: er.NewError(fmt.Errorf("%s", "You are already enrolled to the course"), http.StatusBadRequest, nil),
 		},
+		{
+			name: "[Enroll] Already completed the course",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				check: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				completed: true,
+				insert: struct{err error}{
+					nil,
+				},
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have already completed the course"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[Enroll] Enrolled to the course before",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				check: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				enrolledBefore: true,
+				insert: struct{err error}{
+					nil,
+				},
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have enrolled to the course before, use re-enroll instead"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[Enroll] Prerequisites are not completed",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				check: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				missing: []*db_models.Course{
+					{ID: courseId2, CourseName: "Introduction to Algorithm"},
+				},
+				insert: struct{err error}{
+					nil,
+				},
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have not completed the prerequisites of the course"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: courseId2, Reason: "Introduction to Algorithm has not been completed"},
+			}),
+		},
+		{
+			name: "[Enroll] Course is not published",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusDraft},
+				check: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				insert: struct{err error}{
+					nil,
+				},
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "The course is not open for enrollment"), http.StatusBadRequest, nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -730,15 +1068,31 @@ func TestCourseService_Enroll(t *testing.T) {
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
 
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.course, nil)
+
 			repoMock.
 				On("IsUserEnrolledToCourse",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.check.is, tt.mock.check.err)
 
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.completed, nil)
+
+			repoMock.
+				On("GetMissingPrerequisites", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.missing, nil)
+
+			repoMock.
+				On("HasEnrollmentHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.enrolledBefore, nil)
+
 			repoMock.
 				On("InsertEnrollment", mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.insert.err)
 
-			got, err := svc.Enroll(tt.args.ctx, tt.args.userId, tt.args.courseId)
+			got, err := svc.Enroll(tt.args.ctx, tt.args.userId, tt.args.courseId, "")
 			
 			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
@@ -764,12 +1118,19 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 		insert struct {
 			err error
 		}
+		claimed  bool
+		course   *db_models.Course
+		material *db_models.Material
+		stored   *db_models.MaterialProgress
+		progress []*db_models.UserProgress
+		syllabus []*db_models.Syllabus
 	}
 
 	type args struct {
 		ctx context.Context
 		userId     string
 		materialId string
+		event      *models.ProgressEvent
 	}
 
 	tests := []struct {
@@ -777,6 +1138,7 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 		args     args
 		mock     mockRepo
 		want    *models.StoreProgressResponse
+		wantStored bool
 		wantErr  error
 	}{
 		{
@@ -785,6 +1147,7 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 				context.TODO(),
 				userId,
 				materialId,
+				&models.ProgressEvent{TimeSpent: 30, Percentage: 40, Position: 120},
 			},
 			mock: mockRepo{
 				get: struct{res string; err error}{
@@ -806,15 +1169,23 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 			want: &models.StoreProgressResponse{
 				Status:  "Success",
 				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    30,
+					Percentage:   40,
+					LastPosition: 120,
+					Threshold:    80,
+				},
 			},
 			wantErr: nil,
 		},
 		{
-			name: "[StoreUserProgress] User is not enrolled to the course",
+			name: "[StoreUserProgress] Success to accumulate user progress",
 			args: args{
 				context.TODO(),
 				userId,
 				materialId,
+				&models.ProgressEvent{TimeSpent: 30, Percentage: 20, Position: 60},
 			},
 			mock: mockRepo{
 				get: struct{res string; err error}{
@@ -822,11 +1193,278 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 					nil,
 				},
 				checkEnrolled: struct{is bool; err error}{
-					false,
-					nil,
-				},
-				checkLogged: struct{is bool; err error}{
-					false,
+					true,
+					nil,
+				},
+				material: &db_models.Material{ID: materialId, CourseID: courseId, Type: "video"},
+				stored: &db_models.MaterialProgress{UserID: userId, CourseID: courseId, MaterialID: materialId, TimeSpent: 90, Percentage: 50, LastPosition: 300},
+			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    120,
+					Percentage:   50,
+					LastPosition: 60,
+					Threshold:    90,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Success to complete the material",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 30, Percentage: 95, Position: 570},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				material: &db_models.Material{ID: materialId, CourseID: courseId, Type: "video"},
+				stored: &db_models.MaterialProgress{UserID: userId, CourseID: courseId, MaterialID: materialId, TimeSpent: 540, Percentage: 85, LastPosition: 510},
+				claimed: true,
+				progress: []*db_models.UserProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: materialId, Score: 100},
+				},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+					{ID: materialId, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+					{ID: syllabusId2, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+				},
+			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    570,
+					Percentage:   95,
+					LastPosition: 570,
+					Threshold:    90,
+					Completed:    true,
+				},
+			},
+			wantStored: true,
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Material is completed by a concurrent event",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 30, Percentage: 95, Position: 570},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				material: &db_models.Material{ID: materialId, CourseID: courseId, Type: "video"},
+				stored: &db_models.MaterialProgress{UserID: userId, CourseID: courseId, MaterialID: materialId, TimeSpent: 540, Percentage: 85, LastPosition: 510},
+				claimed: false,
+			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    570,
+					Percentage:   95,
+					LastPosition: 570,
+					Threshold:    90,
+					Completed:    true,
+				},
+			},
+			wantStored: true,
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Course completion is retried on a completed material",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 10, Percentage: 100, Position: 600},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				material: &db_models.Material{ID: materialId, CourseID: courseId, Type: "video"},
+				stored: &db_models.MaterialProgress{UserID: userId, CourseID: courseId, MaterialID: materialId, TimeSpent: 590, Percentage: 100, LastPosition: 590, Completed: true},
+				progress: []*db_models.UserProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: materialId, Score: 100},
+				},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+					{ID: materialId, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+				},
+			},
+			want: &models.StoreProgressResponse{
+				Status:    "Success",
+				Message:   "User progress updated successfully",
+				Completed: true,
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    600,
+					Percentage:   100,
+					LastPosition: 600,
+					Threshold:    90,
+					Completed:    true,
+				},
+			},
+			wantStored: true,
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Time spent is capped per event",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 86400, Percentage: 40, Position: 120},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    300,
+					Percentage:   40,
+					LastPosition: 120,
+					Threshold:    80,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Assignment is not completed by progress events",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 30, Percentage: 100},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				material: &db_models.Material{ID: materialId, CourseID: courseId, Type: "assignment"},
+			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID: materialId,
+					TimeSpent:  30,
+					Percentage: 100,
+					Threshold:  -1,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Success to complete the course",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{TimeSpent: 45, Percentage: 100, Position: 2000},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				checkLogged: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				insert: struct{err error}{
+					nil,
+				},
+				claimed: true,
+				progress: []*db_models.UserProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: materialId, Score: 100},
+				},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+					{ID: materialId, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+				},
+			},
+			want: &models.StoreProgressResponse{
+				Status:    "Success",
+				Message:   "User progress updated successfully",
+				Completed: true,
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    45,
+					Percentage:   100,
+					LastPosition: 2000,
+					Threshold:    80,
+					Completed:    true,
+				},
+			},
+			wantStored: true,
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] User is not enrolled to the course",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					false,
+					nil,
+				},
+				checkLogged: struct{is bool; err error}{
+					false,
 					nil,
 				},
 				insert: struct{err error}{
@@ -837,11 +1475,12 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 			wantErr: er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil),
 		},
 		{
-			name: "[StoreUserProgress] User progress is already logged",
+			name: "[StoreUserProgress] Material is already completed",
 			args: args{
 				context.TODO(),
 				userId,
 				materialId,
+				&models.ProgressEvent{TimeSpent: 15, Percentage: 10, Position: 5},
 			},
 			mock: mockRepo{
 				get: struct{res string; err error}{
@@ -860,8 +1499,46 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 					nil,
 				},
 			},
+			want: &models.StoreProgressResponse{
+				Status:  "Success",
+				Message: "User progress updated successfully",
+				Progress: &models.MaterialProgress{
+					MaterialID:   materialId,
+					TimeSpent:    15,
+					Percentage:   10,
+					LastPosition: 5,
+					Threshold:    80,
+					Completed:    true,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[StoreUserProgress] Material is locked",
+			args: args{
+				context.TODO(),
+				userId,
+				materialId,
+				&models.ProgressEvent{},
+			},
+			mock: mockRepo{
+				get: struct{res string; err error}{
+					courseId,
+					nil,
+				},
+				checkEnrolled: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				course: &db_models.Course{ID: courseId, Sequential: true},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+					{ID: syllabusId2, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+					{ID: materialId, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+				},
+			},
 			want: nil,
-			wantErr: er.NewError(fmt.Errorf("%s", "Your progress has already been recorded"), http.StatusBadRequest, nil),
+			wantErr: er.NewError(fmt.Errorf("%s", "The material is locked until the previous material is completed"), http.StatusForbidden, nil),
 		},
 	}
 
@@ -877,22 +1554,91 @@ func TestCourseService_StoreUserProgress(t *testing.T) {
 				On("GetCourseIDByMaterialID",mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.get.res, tt.mock.get.err)
 
+			courseData := tt.mock.course
+			if courseData == nil {
+				courseData = &db_models.Course{ID: courseId}
+			}
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(courseData, nil)
+
 			repoMock.
 				On("IsUserEnrolledToCourse",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.checkEnrolled.is, tt.mock.checkEnrolled.err)
 			
+			material := tt.mock.material
+			if material == nil {
+				material = &db_models.Material{ID: materialId, CourseID: courseId, Type: "text"}
+			}
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, mock.Anything).
+				Return(material, nil)
+
+			if tt.mock.stored != nil {
+				repoMock.
+					On("GetMaterialProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(tt.mock.stored, nil)
+			} else {
+				repoMock.
+					On("GetMaterialProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, er.NewError(fmt.Errorf("%s", "Material progress not found"), http.StatusNotFound, nil))
+			}
+
+			repoMock.
+				On("UpsertMaterialProgress", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
 			repoMock.
 				On("CheckIsProgressLogged",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.checkLogged.is, tt.mock.checkLogged.err)
 
+			repoMock.
+				On("CompleteMaterialProgress", mock.Anything, mock.Anything, userId, courseId, materialId).
+				Return(tt.mock.claimed, nil)
+
 			repoMock.
 				On("StoreUserProgress",mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.insert.err)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+
+			repoMock.
+				On("GetUserProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.progress, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.syllabus, nil)
+
+			repoMock.
+				On("CompleteCourse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
 			
-			got, err := svc.StoreUserProgress(tt.args.ctx, tt.args.userId, tt.args.materialId)
+			got, err := svc.StoreUserProgress(tt.args.ctx, tt.args.userId, tt.args.materialId, tt.args.event)
 			
 			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
+
+			if tt.wantStored {
+				repoMock.AssertCalled(t, "CompleteMaterialProgress", mock.Anything, mock.Anything, userId, courseId, materialId)
+			} else {
+				repoMock.AssertNotCalled(t, "CompleteMaterialProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			repoMock.AssertNotCalled(t, "StoreUserProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			if tt.wantErr == nil {
+				timeSpent := tt.args.event.TimeSpent
+				if timeSpent > 300 {
+					timeSpent = 300
+				}
+				repoMock.AssertCalled(t, "UpsertMaterialProgress", mock.Anything, mock.Anything, mock.MatchedBy(func(progress *db_models.MaterialProgress) bool {
+					return progress.TimeSpent == timeSpent
+				}))
+			}
 		})
 	}
 }
@@ -919,6 +1665,18 @@ func TestCourseService_ComputeUserProgress(t *testing.T) {
 		courseId string
 	}
 
+	longSyllabus := []*db_models.Syllabus{
+		{ID: syllabusId, CourseID: courseId, Type: "section"},
+	}
+	var longProgress []*db_models.UserProgress
+	for i := 0; i < 200; i++ {
+		id := uuid.New().String()
+		longSyllabus = append(longSyllabus, &db_models.Syllabus{ID: id, CourseID: courseId, Type: "text", SectionID: &syllabusId, Position: i})
+		if i > 0 {
+			longProgress = append(longProgress, &db_models.UserProgress{UserID: userId, CourseID: courseId, MaterialID: id, Score: 100})
+		}
+	}
+
 	tests := []struct {
 		name     string
 		args     args
@@ -927,7 +1685,7 @@ func TestCourseService_ComputeUserProgress(t *testing.T) {
 		wantErr  error
 	}{
 		{
-			name: "[ComputeUserProgress] Success to compute user progress",
+			name: "[ComputeUserProgress] Count every material once",
 			args: args{
 				context.TODO(),
 				userId,
@@ -940,36 +1698,97 @@ func TestCourseService_ComputeUserProgress(t *testing.T) {
 				},
 				getUser: struct{res []*db_models.UserProgress; err error}{
 					[]*db_models.UserProgress{
-						{
-							UserID: userId,
-							CourseID: courseId,
-							MaterialID: syllabusId2,
-							Score: 100,
-						},
-						{
-							UserID: userId,
-							CourseID: courseId,
-							MaterialID: syllabusId3,
-							Score: 100,
-						},
+						{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, Score: 40},
+						{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, Score: 80},
+						{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, Score: 100},
+						{UserID: userId, CourseID: courseId, MaterialID: materialId, Score: 100},
 					},
 					nil,
 				},
 				getSyllabus: struct{res []*db_models.Syllabus; err error}{
 					[]*db_models.Syllabus{
-						{
-							ID: syllabusId,
-							CourseID: courseId,
-							Name: "",
-							Type: "section",
-							SectionID: nil,
-						},
-						{
-							ID: syllabusId2,
-							CourseID: courseId,
-							Name: "",
-							Type: "video",
-							SectionID: &syllabusId,
+						{ID: syllabusId, CourseID: courseId, Type: "section"},
+						{ID: syllabusId2, CourseID: courseId, Type: "assignment", SectionID: &syllabusId},
+						{ID: syllabusId3, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+					},
+					nil,
+				},
+			},
+			want: &models.GetProgressPercentageResponse{
+				Percentage: 50,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ComputeUserProgress] Incomplete syllabus does not round up to 100",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				check: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				getUser: struct{res []*db_models.UserProgress; err error}{
+					longProgress,
+					nil,
+				},
+				getSyllabus: struct{res []*db_models.Syllabus; err error}{
+					longSyllabus,
+					nil,
+				},
+			},
+			want: &models.GetProgressPercentageResponse{
+				Percentage: 99,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ComputeUserProgress] Success to compute user progress",
+			args: args{
+				context.TODO(),
+				userId,
+				courseId,
+			},
+			mock: mockRepo{
+				check: struct{is bool; err error}{
+					true,
+					nil,
+				},
+				getUser: struct{res []*db_models.UserProgress; err error}{
+					[]*db_models.UserProgress{
+						{
+							UserID: userId,
+							CourseID: courseId,
+							MaterialID: syllabusId2,
+							Score: 100,
+						},
+						{
+							UserID: userId,
+							CourseID: courseId,
+							MaterialID: syllabusId3,
+							Score: 100,
+						},
+					},
+					nil,
+				},
+				getSyllabus: struct{res []*db_models.Syllabus; err error}{
+					[]*db_models.Syllabus{
+						{
+							ID: syllabusId,
+							CourseID: courseId,
+							Name: "",
+							Type: "section",
+							SectionID: nil,
+						},
+						{
+							ID: syllabusId2,
+							CourseID: courseId,
+							Name: "",
+							Type: "video",
+							SectionID: &syllabusId,
 						},
 						{
 							ID: syllabusId3,
@@ -1025,6 +1844,10 @@ func TestCourseService_ComputeUserProgress(t *testing.T) {
 				On("IsUserEnrolledToCourse",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.check.is, tt.mock.check.err)
 
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+
 			if tt.mock.getUser.res != nil {
 				repoMock.
 					On("GetUserProgress",mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -1055,6 +1878,7 @@ func TestCourseService_GetUserProgress(t *testing.T) {
 			res []*db_models.UserProgress
 			err error
 		}
+		materials []*db_models.MaterialProgress
 	}
 
 	type args struct {
@@ -1099,6 +1923,10 @@ func TestCourseService_GetUserProgress(t *testing.T) {
 					},
 					nil,
 				},
+				materials: []*db_models.MaterialProgress{
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId3, TimeSpent: 600, Percentage: 100, LastPosition: 580, Completed: true, UpdatedAt: "2022-04-02 10:00:00"},
+					{UserID: userId, CourseID: courseId, MaterialID: syllabusId2, TimeSpent: 120, Percentage: 100, Completed: true, UpdatedAt: "2022-04-01 10:00:00"},
+				},
 			},
 			want: &models.GetProgressResponse{
 				Progress: []*models.UserProgress{
@@ -1115,6 +1943,10 @@ func TestCourseService_GetUserProgress(t *testing.T) {
 						Score: 100,
 					},
 				},
+				Materials: []*models.MaterialProgress{
+					{MaterialID: syllabusId3, TimeSpent: 600, Percentage: 100, LastPosition: 580, Threshold: 90, Completed: true, UpdatedAt: "2022-04-02 10:00:00"},
+					{MaterialID: syllabusId2, TimeSpent: 120, Percentage: 100, Threshold: 80, Completed: true, UpdatedAt: "2022-04-01 10:00:00"},
+				},
 			},
 			wantErr: nil,
 		},
@@ -1158,6 +1990,22 @@ func TestCourseService_GetUserProgress(t *testing.T) {
 					Return(tt.mock.get.res, tt.mock.get.err)
 			}
 
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+
+			repoMock.
+				On("GetMaterialProgressByCourseID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.materials, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+					{ID: syllabusId2, CourseID: courseId, Type: "text", SectionID: &syllabusId},
+					{ID: syllabusId3, CourseID: courseId, Type: "video", SectionID: &syllabusId},
+				}, nil)
+
 			got, err := svc.GetUserProgress(tt.args.ctx, tt.args.userId, tt.args.courseId)
 			
 			assert.Equal(t, tt.want, got, tt.name)
@@ -1169,7 +2017,8 @@ func TestCourseService_GetUserProgress(t *testing.T) {
 
 func TestCourseService_CreateNewCourse(t *testing.T) {
 	type mockRepo struct {
-		err error
+		err              error
+		assignmentExists bool
 	}
 
 	type args struct {
@@ -1177,6 +2026,15 @@ func TestCourseService_CreateNewCourse(t *testing.T) {
 		course *models.CourseCreation
 	}
 
+	invalidErrs := []er.ErrorStruct{
+		{Field: "sections[0].subSections[0].materialContent", Reason: "Content must be the URL of the video"},
+		{Field: "sections[1].subSections[0].materialType", Reason: "Material type must be one of assignment, file, link, markdown, text, video"},
+	}
+
+	missingErrs := []er.ErrorStruct{
+		{Field: "sections[0].subSections[0].materialContent", Reason: "Assignment does not exist"},
+	}
+
 	tests := []struct {
 		name     string
 		args     args
@@ -1193,14 +2051,16 @@ func TestCourseService_CreateNewCourse(t *testing.T) {
 					Sections: []*models.SectionCreation{
 						{
 							Subsections: []*models.MaterialCreation{
-								{},
+								{Type: "text", Content: "<p>Hello</p>"},
+								{Type: "assignment", Content: "assignment-1"},
 							},
 						},
 					},
 				},
 			},
 			mock: mockRepo{
-				err: nil,
+				err:              nil,
+				assignmentExists: true,
 			},
 			want: &models.CourseCreationResponse{
 				Status:  "Success",
@@ -1208,6 +2068,54 @@ func TestCourseService_CreateNewCourse(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "[CreateNewCourse] Invalid materials",
+			args: args{
+				context.TODO(),
+				&models.CourseCreation{
+					Course: models.Course{},
+					Sections: []*models.SectionCreation{
+						{
+							Subsections: []*models.MaterialCreation{
+								{Type: "video", Content: "not a url"},
+							},
+						},
+						{
+							Subsections: []*models.MaterialCreation{
+								{},
+							},
+						},
+					},
+				},
+			},
+			mock: mockRepo{
+				err: nil,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &invalidErrs),
+		},
+		{
+			name: "[CreateNewCourse] Assignment does not exist",
+			args: args{
+				context.TODO(),
+				&models.CourseCreation{
+					Course: models.Course{},
+					Sections: []*models.SectionCreation{
+						{
+							Subsections: []*models.MaterialCreation{
+								{Type: "assignment", Content: "assignment-1"},
+							},
+						},
+					},
+				},
+			},
+			mock: mockRepo{
+				err:              nil,
+				assignmentExists: false,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid material"), http.StatusBadRequest, &missingErrs),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1218,6 +2126,10 @@ func TestCourseService_CreateNewCourse(t *testing.T) {
 			svc := course.NewService(sqlxDB)
 			svc.InjectCourseRepository(repoMock)
 
+			repoMock.
+				On("IsAssignmentExists", mock.Anything, mock.Anything, "assignment-1").
+				Return(tt.mock.assignmentExists, nil)
+
 			repoMock.
 				On("InsertCourse",mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mock.err)
@@ -1227,6 +2139,9 @@ func TestCourseService_CreateNewCourse(t *testing.T) {
 			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
 
+			if tt.wantErr == nil {
+				assert.Equal(t, "assignment-1", tt.args.course.Sections[0].Subsections[1].ID, tt.name)
+			}
 		})
 	}
 }
@@ -1253,25 +2168,1221 @@ func TestCourseService_CreateCourseDesc(t *testing.T) {
 		wantErr  error
 	}{
 		{
-			name: "[CreateCourseDesc] Success to create course with description",
-			args: args{
-				context.TODO(),
-				&models.CourseDescriptionInput{
-					CourseName: "",
-					Description: "",
-					Thumbnail: "",
-				},
-				creatorId,
+			name: "[CreateCourseDesc] Success to create course with description",
+			args: args{
+				context.TODO(),
+				&models.CourseDescriptionInput{
+					CourseName: "",
+					Description: "",
+					Thumbnail: "",
+				},
+				creatorId,
+			},
+			mock: mockRepo{
+				err: nil,
+			},
+			want: &models.CourseCreationResponse{
+				Status:  "Success",
+				Message: "Course Description Created Succesfully",
+				Id: "",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("InsertCourseData",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.err)
+
+			got, err := svc.CreateCourseDesc(tt.args.ctx, tt.args.course, tt.args.creatorId)
+			
+			assert.Equal(t, tt.want.Status, got.Status, tt.name)
+			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_CreateCourseSection(t *testing.T) {
+	type mockRepo struct {
+		role string
+		err  error
+	}
+
+	type args struct {
+		ctx        context.Context
+		input     *models.CourseSectionInput
+		creatorId  string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mock     mockRepo
+		want    *models.CourseCreationResponse
+		wantErr  error
+	}{
+		{
+			name: "[CreateCourseSection] Success to create course with section",
+			args: args{
+				context.TODO(),
+				&models.CourseSectionInput{
+					Name: "",
+					CourseID: "",
+				},
+				creatorId,
+			},
+			mock: mockRepo{
+				err: nil,
+			},
+			want: &models.CourseCreationResponse{
+				Status:  "Success",
+				Message: "Section Created Succesfully",
+				Id: "",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[CreateCourseSection] Success to create section as editor",
+			args: args{
+				context.TODO(),
+				&models.CourseSectionInput{
+					Name: "",
+					CourseID: courseId,
+				},
+				userId,
+			},
+			mock: mockRepo{
+				role: models.CourseRoleEditor,
+				err:  nil,
+			},
+			want: &models.CourseCreationResponse{
+				Status:  "Success",
+				Message: "Section Created Succesfully",
+				Id: "",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[CreateCourseSection] Not allowed to create section as teaching assistant",
+			args: args{
+				context.TODO(),
+				&models.CourseSectionInput{
+					Name: "",
+					CourseID: courseId,
+				},
+				userId,
+			},
+			mock: mockRepo{
+				role: models.CourseRoleTeachingAssistant,
+				err:  nil,
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.input.CourseID).
+				Return(&db_models.Course{ID: tt.args.input.CourseID, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, tt.args.input.CourseID, tt.args.creatorId).
+				Return(tt.mock.role, nil)
+
+			repoMock.
+				On("GetNextMaterialPosition", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(0, nil)
+
+			repoMock.
+				On("InsertCourseMaterial",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.err)
+
+			got, err := svc.CreateCourseSection(tt.args.ctx, tt.args.input, tt.args.creatorId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				return
+			}
+
+			assert.Equal(t, tt.want.Status, got.Status, tt.name)
+			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_CreateCourseMaterial(t *testing.T) {
+	type mockRepo struct {
+		getId struct {
+			id  string
+			err error
+		}
+		getMat struct {
+			res *db_models.Material
+			err  error
+		}
+		insert struct {
+			err error
+		}
+	}
+
+	type args struct {
+		ctx        context.Context
+		input     *models.CourseMaterialInput
+		creatorId  string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mock     mockRepo
+		want    *models.CourseCreationResponse
+		wantErr  error
+	}{
+		{
+			name: "[CreateCourseMaterial] Success to create course with material",
+			args: args{
+				context.TODO(),
+				&models.CourseMaterialInput{
+					Name: "Lesson",
+					Type: "markdown",
+					Content: "# Lesson",
+					ContentText: "Lesson",
+					SectionID: syllabusId,
+				},
+				creatorId,
+			},
+			mock: mockRepo{
+				getId: struct{id string; err error}{
+					syllabusId,
+					nil,
+				},
+				getMat: struct{res *db_models.Material; err error}{
+					nil,
+					nil,
+				},
+				insert: struct{err error}{
+					nil,
+				},
+			},
+			want: &models.CourseCreationResponse{
+				Status:  "Success",
+				Message: "Section Created Succesfully",
+				Id: "",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseIDByMaterialID",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.getId.id, tt.mock.getId.err)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, tt.mock.getId.id).
+				Return(&db_models.Course{ID: tt.mock.getId.id, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetMaterialByID",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.getMat.res, tt.mock.getMat.err)
+
+			repoMock.
+				On("GetNextMaterialPosition", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(0, nil)
+
+			repoMock.
+				On("InsertCourseMaterial",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.insert.err)
+
+			got, err := svc.CreateCourseMaterial(tt.args.ctx, tt.args.input, tt.args.creatorId)
+			
+			assert.Equal(t, tt.want.Status, got.Status, tt.name)
+			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_GetCourseByCreatorID(t *testing.T) {
+	type mockRepo struct {
+		res []*db_models.Course
+		err error
+	}
+
+	type args struct {
+		ctx context.Context
+		creatorId  string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mock     mockRepo
+		want     []*models.Course
+		wantErr  error
+	}{
+		{
+			name: "[GetCourseByCreatorID] Success to get course by creator ID",
+			args: args{
+				context.TODO(),
+				creatorId,
+			},
+			mock: mockRepo{
+				res: []*db_models.Course{
+					{
+						ID: courseId,
+						CourseName: "",
+						Description: "",
+						Thumbnail: "",
+						Creator: creatorId,
+					},
+				},
+				err: nil,
+			},
+			want: []*models.Course{
+				{
+					ID: courseId,
+					CourseName: "",
+					Description: "",
+					Thumbnail: "",
+					Creator: creatorId,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseByCreatorID",mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.res, tt.mock.err)
+
+			got, err := svc.GetCourseByCreatorID(tt.args.ctx, tt.args.creatorId)
+			
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_UpdateCourseDesc(t *testing.T) {
+	type mockRepo struct {
+		get struct {
+			res *db_models.Course
+			err error
+		}
+		update struct {
+			err error
+		}
+	}
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+		input    *models.CourseDescriptionUpdate
+		userId   string
+	}
+
+	newName := "Introduction to Go"
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    mockRepo
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[UpdateCourseDesc] Success to update course description",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseDescriptionUpdate{
+					CourseName: &newName,
+				},
+				creatorId,
+			},
+			mock: mockRepo{
+				get: struct {
+					res *db_models.Course
+					err error
+				}{
+					&db_models.Course{
+						ID:      courseId,
+						Creator: creatorId,
+					},
+					nil,
+				},
+			},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Course Description Updated Succesfully",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[UpdateCourseDesc] User is not the course creator",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseDescriptionUpdate{
+					CourseName: &newName,
+				},
+				userId,
+			},
+			mock: mockRepo{
+				get: struct {
+					res *db_models.Course
+					err error
+				}{
+					&db_models.Course{
+						ID:      courseId,
+						Creator: creatorId,
+					},
+					nil,
+				},
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.get.res, tt.mock.get.err)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return("", nil)
+
+			repoMock.
+				On("UpdateCourseData", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.update.err)
+
+			got, err := svc.UpdateCourseDesc(tt.args.ctx, tt.args.courseId, tt.args.input, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr == nil {
+				repoMock.AssertCalled(t, "UpdateCourseData", mock.Anything, mock.Anything, &db_models.Course{
+					ID:         courseId,
+					CourseName: newName,
+					Creator:    creatorId,
+				})
+			}
+		})
+	}
+}
+
+func TestCourseService_DeleteCourseSection(t *testing.T) {
+	type mockRepo struct {
+		getSection struct {
+			res *db_models.Material
+			err error
+		}
+		getMaterials struct {
+			res []*db_models.Material
+			err error
+		}
+	}
+
+	type args struct {
+		ctx       context.Context
+		sectionId string
+		cascade   bool
+		userId    string
+	}
+
+	section := &db_models.Material{
+		ID:       syllabusId,
+		CourseID: courseId,
+		Name:     "Section 1",
+		Type:     "section",
+	}
+
+	materials := []*db_models.Material{
+		{
+			ID:        materialId,
+			CourseID:  courseId,
+			Type:      "text",
+			SectionID: syllabusId,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    mockRepo
+		wantIds []string
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[DeleteCourseSection] Section still holds materials",
+			args: args{
+				context.TODO(),
+				syllabusId,
+				false,
+				creatorId,
+			},
+			mock: mockRepo{
+				getSection: struct {
+					res *db_models.Material
+					err error
+				}{section, nil},
+				getMaterials: struct {
+					res []*db_models.Material
+					err error
+				}{materials, nil},
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Section still has materials, delete them first or use cascade"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[DeleteCourseSection] Success to delete section with its materials",
+			args: args{
+				context.TODO(),
+				syllabusId,
+				true,
+				creatorId,
+			},
+			mock: mockRepo{
+				getSection: struct {
+					res *db_models.Material
+					err error
+				}{section, nil},
+				getMaterials: struct {
+					res []*db_models.Material
+					err error
+				}{materials, nil},
+			},
+			wantIds: []string{materialId, syllabusId},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Section Deleted Succesfully",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.getSection.res, tt.mock.getSection.err)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCourseMaterialByCourseIDAndSectionID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.getMaterials.res, tt.mock.getMaterials.err)
+
+			repoMock.
+				On("DeleteCourseMaterials", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			got, err := svc.DeleteCourseSection(tt.args.ctx, tt.args.sectionId, tt.args.cascade, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantIds != nil {
+				repoMock.AssertCalled(t, "DeleteCourseMaterials", mock.Anything, mock.Anything, tt.wantIds)
+			} else {
+				repoMock.AssertNotCalled(t, "DeleteCourseMaterials", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCourseService_UpdateCourseMaterial(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		materialId string
+		input      *models.CourseMaterialUpdate
+		userId     string
+	}
+
+	sectionType := "section"
+	newContent := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[UpdateCourseMaterial] Success to update material content",
+			args: args{
+				context.TODO(),
+				materialId,
+				&models.CourseMaterialUpdate{
+					Content: &newContent,
+				},
+				creatorId,
+			},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Material Updated Succesfully",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[UpdateCourseMaterial] Material cannot be turned into a section",
+			args: args{
+				context.TODO(),
+				materialId,
+				&models.CourseMaterialUpdate{
+					Type: &sectionType,
+				},
+				creatorId,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Material type cannot be section"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, mock.Anything).
+				Return(&db_models.Material{ID: materialId, CourseID: courseId, Type: "video", SectionID: syllabusId}, nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("UpdateCourseMaterial", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			got, err := svc.UpdateCourseMaterial(tt.args.ctx, tt.args.materialId, tt.args.input, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_ReorderSyllabus(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		courseId string
+		input    *models.SyllabusOrderInput
+		userId   string
+	}
+
+	syllabus := []*db_models.Syllabus{
+		{
+			ID:       syllabusId,
+			CourseID: courseId,
+			Name:     "Section 1",
+			Type:     "section",
+		},
+		{
+			ID:       syllabusId2,
+			CourseID: courseId,
+			Name:     "Section 2",
+			Type:     "section",
+			Position: 1,
+		},
+		{
+			ID:        materialId,
+			CourseID:  courseId,
+			Name:      "Material 1",
+			Type:      "video",
+			SectionID: &syllabusId,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantPositions []*db_models.MaterialPosition
+		want          *models.CourseUpdateResponse
+		wantErr       error
+	}{
+		{
+			name: "[ReorderSyllabus] Success to swap sections and move material",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.SyllabusOrderInput{
+					Sections: []*models.SectionOrder{
+						{ID: syllabusId2, Materials: []string{materialId}},
+						{ID: syllabusId},
+					},
+				},
+				creatorId,
+			},
+			wantPositions: []*db_models.MaterialPosition{
+				{ID: syllabusId2, SectionID: "", Position: 0},
+				{ID: materialId, SectionID: syllabusId2, Position: 0},
+				{ID: syllabusId, SectionID: "", Position: 1},
+			},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Syllabus Reordered Succesfully",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ReorderSyllabus] Order misses items and references unknown material",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.SyllabusOrderInput{
+					Sections: []*models.SectionOrder{
+						{ID: syllabusId, Materials: []string{materialId, syllabusId3}},
+					},
+				},
+				creatorId,
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid syllabus order"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "sections[0].materials[1]", Reason: "Material does not belong to this course"},
+				{Field: "sections", Reason: "Section 2 is missing from the new order"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, mock.Anything).
+				Return(syllabus, nil)
+
+			repoMock.
+				On("UpdateMaterialPositions", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			got, err := svc.ReorderSyllabus(tt.args.ctx, tt.args.courseId, tt.args.input, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantPositions != nil {
+				repoMock.AssertCalled(t, "UpdateMaterialPositions", mock.Anything, mock.Anything, tt.wantPositions)
+			} else {
+				repoMock.AssertNotCalled(t, "UpdateMaterialPositions", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCourseService_SubmitCourseForReview(t *testing.T) {
+	type mockRepo struct {
+		course   *db_models.Course
+		syllabus []*db_models.Syllabus
+	}
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+		userId   string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    mockRepo
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[SubmitCourseForReview] Success to submit draft course",
+			args: args{
+				context.TODO(),
+				courseId,
+				creatorId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId, Status: models.CourseStatusDraft},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+				},
+			},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Course Submitted For Review",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[SubmitCourseForReview] Course without sections",
+			args: args{
+				context.TODO(),
+				courseId,
+				creatorId,
+			},
+			mock: mockRepo{
+				course:   &db_models.Course{ID: courseId, Creator: creatorId, Status: models.CourseStatusDraft},
+				syllabus: []*db_models.Syllabus{},
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Course needs at least one section before it can be reviewed"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[SubmitCourseForReview] Course is already published",
+			args: args{
+				context.TODO(),
+				courseId,
+				creatorId,
+			},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Creator: creatorId, Status: models.CourseStatusPublished},
+				syllabus: []*db_models.Syllabus{
+					{ID: syllabusId, CourseID: courseId, Type: "section"},
+				},
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("Course with status %s cannot be moved to %s", models.CourseStatusPublished, models.CourseStatusInReview), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.course, nil)
+
+			repoMock.
+				On("GetCourseSyllabusByCourseID", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock.syllabus, nil)
+
+			repoMock.
+				On("UpdateCourseStatus", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			got, err := svc.SubmitCourseForReview(tt.args.ctx, tt.args.courseId, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_PublishCourse(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		courseId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    *db_models.Course
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[PublishCourse] Success to publish reviewed course",
+			args: args{
+				context.TODO(),
+				courseId,
+			},
+			mock: &db_models.Course{ID: courseId, Creator: creatorId, Status: models.CourseStatusInReview},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Course Published Succesfully",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[PublishCourse] Draft course cannot be published",
+			args: args{
+				context.TODO(),
+				courseId,
+			},
+			mock:    &db_models.Course{ID: courseId, Creator: creatorId, Status: models.CourseStatusDraft},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("Course with status %s cannot be moved to %s", models.CourseStatusDraft, models.CourseStatusPublished), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mock, nil)
+
+			repoMock.
+				On("UpdateCourseStatus", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			got, err := svc.PublishCourse(tt.args.ctx, tt.args.courseId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr == nil {
+				repoMock.AssertCalled(t, "UpdateCourseStatus", mock.Anything, mock.Anything, &models.CourseStatusUpdate{
+					Id:     courseId,
+					Status: models.CourseStatusPublished,
+				})
+			}
+		})
+	}
+}
+
+func TestCourseService_SetCourseTopics(t *testing.T) {
+	topicId := uuid.New().String()
+	missingTopicId := uuid.New().String()
+	brokenTopicId := uuid.New().String()
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+		input    *models.CourseTopicInput
+		userId   string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.CourseUpdateResponse
+		wantErr error
+	}{
+		{
+			name: "[SetCourseTopics] Success to set course topics",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseTopicInput{Topics: []string{topicId, topicId}},
+				creatorId,
+			},
+			want: &models.CourseUpdateResponse{
+				Status:  "Success",
+				Message: "Course Topics Updated Succesfully",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[SetCourseTopics] Unknown topic",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseTopicInput{Topics: []string{topicId, missingTopicId}},
+				creatorId,
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid course topics"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "topics[1]", Reason: "Topic not found"},
+			}),
+		},
+		{
+			name: "[SetCourseTopics] Failed to get topic",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseTopicInput{Topics: []string{brokenTopicId}},
+				creatorId,
+			},
+			want:    nil,
+			wantErr: errors.New("connection refused"),
+		},
+		{
+			name: "[SetCourseTopics] Not the course creator",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CourseTopicInput{Topics: []string{topicId}},
+				userId,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, mock.Anything).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return("", nil)
+
+			repoMock.
+				On("GetTopicById", mock.Anything, mock.Anything, topicId).
+				Return(&db_models.Topic{ID: topicId, Name: "Programming"}, nil)
+
+			repoMock.
+				On("GetTopicById", mock.Anything, mock.Anything, missingTopicId).
+				Return(nil, er.NewError(fmt.Errorf("%s", "Topic not found"), http.StatusNotFound, nil))
+
+			repoMock.
+				On("GetTopicById", mock.Anything, mock.Anything, brokenTopicId).
+				Return(nil, errors.New("connection refused"))
+
+			repoMock.
+				On("SetCourseTopics", mock.Anything, mock.Anything, courseId, []string{topicId}).
+				Return(nil)
+
+			got, err := svc.SetCourseTopics(tt.args.ctx, tt.args.courseId, tt.args.input, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestCourseService_CreateTopic(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *models.TopicInput
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockErr error
+		wantErr error
+	}{
+		{
+			name: "[CreateTopic] Success to create topic",
+			args: args{
+				context.TODO(),
+				&models.TopicInput{Name: "Programming"},
+			},
+			mockErr: nil,
+			wantErr: nil,
+		},
+		{
+			name: "[CreateTopic] Failed to insert topic",
+			args: args{
+				context.TODO(),
+				&models.TopicInput{Name: "Programming"},
+			},
+			mockErr: errors.New("Duplicate entry"),
+			wantErr: errors.New("Duplicate entry"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.CourseRepository)
+			svc := course.NewService(sqlxDB)
+			svc.InjectCourseRepository(repoMock)
+
+			repoMock.
+				On("InsertTopic", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.mockErr)
+
+			got, err := svc.CreateTopic(tt.args.ctx, tt.args.input)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr == nil {
+				assert.Equal(t, "Topic Created Succesfully", got.Message, tt.name)
+				assert.NotEmpty(t, got.ID, tt.name)
+			}
+		})
+	}
+}
+
+func TestCourseService_ReEnroll(t *testing.T) {
+	notFound := er.NewError(fmt.Errorf("%s", "Enrollment request not found"), http.StatusNotFound, nil)
+
+	type mockRepo struct {
+		course         *db_models.Course
+		enrolled       bool
+		completed      bool
+		enrolledBefore bool
+		insertErr      error
+	}
+
+	type args struct {
+		ctx          context.Context
+		userId       string
+		courseId     string
+		joinCode     string
+		keepProgress bool
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		mock       mockRepo
+		want       *models.EnrollResponse
+		wantQueued *db_models.EnrollmentRequest
+		wantErr    error
+	}{
+		{
+			name: "[ReEnroll] Success to re-enroll to a course",
+			args: args{context.TODO(), userId, courseId, "", true},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				enrolledBefore: true,
+			},
+			want: &models.EnrollResponse{
+				Status:  "Success",
+				Message: "You have successfully re-enrolled to the course.",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ReEnroll] Never enrolled to the course",
+			args: args{context.TODO(), userId, courseId, "", true},
+			mock: mockRepo{
+				course: &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have never enrolled to the course"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ReEnroll] Already enrolled to the course",
+			args: args{context.TODO(), userId, courseId, "", false},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				enrolled:       true,
+				enrolledBefore: true,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are already enrolled to the course"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ReEnroll] Already completed the course",
+			args: args{context.TODO(), userId, courseId, "", false},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished},
+				completed:      true,
+				enrolledBefore: true,
 			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You have already completed the course"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ReEnroll] Course is not published",
+			args: args{context.TODO(), userId, courseId, "", false},
 			mock: mockRepo{
-				err: nil,
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusArchived},
+				enrolledBefore: true,
 			},
-			want: &models.CourseCreationResponse{
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "The course is not open for enrollment"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[ReEnroll] Code protected course without a join code",
+			args: args{context.TODO(), userId, courseId, "", true},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCode, EnrollmentKey: "SECRET"},
+				enrolledBefore: true,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "A valid join code is required to enroll to the course"), http.StatusForbidden, nil),
+		},
+		{
+			name: "[ReEnroll] Approval course queues the learner",
+			args: args{context.TODO(), userId, courseId, "", false},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeApproval},
+				enrolledBefore: true,
+			},
+			want: &models.EnrollResponse{
 				Status:  "Success",
-				Message: "Course Description Created Succesfully",
-				Id: "",
+				Message: "Your enrollment request is waiting for approval.",
 			},
-			wantErr: nil,
+			wantQueued: &db_models.EnrollmentRequest{CourseID: courseId, UserID: userId, Action: models.EnrollmentActionReEnroll, KeepProgress: false, Status: models.EnrollmentRequestPending},
+			wantErr:    nil,
+		},
+		{
+			name: "[ReEnroll] Full capped course puts the learner on the waitlist",
+			args: args{context.TODO(), userId, courseId, "", true},
+			mock: mockRepo{
+				course:         &db_models.Course{ID: courseId, Status: models.CourseStatusPublished, EnrollmentMode: models.EnrollmentModeCapped, Capacity: 2},
+				enrolledBefore: true,
+				insertErr:      er.NewError(fmt.Errorf("%s", "The course is full"), http.StatusConflict, nil),
+			},
+			want: &models.EnrollResponse{
+				Status:  "Success",
+				Message: "The course is full, you have been added to the waitlist.",
+			},
+			wantQueued: &db_models.EnrollmentRequest{CourseID: courseId, UserID: userId, Action: models.EnrollmentActionReEnroll, KeepProgress: true, Status: models.EnrollmentRequestWaitlisted},
+			wantErr:    nil,
 		},
 	}
 
@@ -1284,56 +3395,88 @@ func TestCourseService_CreateCourseDesc(t *testing.T) {
 			svc.InjectCourseRepository(repoMock)
 
 			repoMock.
-				On("InsertCourseData",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.err)
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(tt.mock.course, nil)
 
-			got, err := svc.CreateCourseDesc(tt.args.ctx, tt.args.course, tt.args.creatorId)
-			
-			assert.Equal(t, tt.want.Status, got.Status, tt.name)
-			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			repoMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.enrolled, nil)
+
+			repoMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.completed, nil)
+
+			repoMock.
+				On("HasEnrollmentHistory", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.mock.enrolledBefore, nil)
+
+			repoMock.
+				On("GetMissingPrerequisites", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return([]*db_models.Course{}, nil)
+
+			repoMock.
+				On("GetEnrollmentRequest", mock.Anything, mock.Anything, tt.args.courseId, tt.args.userId).
+				Return(nil, notFound)
+
+			repoMock.
+				On("InsertEnrollment", mock.Anything, mock.Anything, &models.EnrollInput{
+					UserID:       tt.args.userId,
+					CourseID:     tt.args.courseId,
+					Action:       models.EnrollmentActionReEnroll,
+					KeepProgress: tt.args.keepProgress,
+					Capacity:     tt.mock.course.Capacity,
+				}).
+				Return(tt.mock.insertErr)
+
+			var queued *db_models.EnrollmentRequest
+			repoMock.
+				On("UpsertEnrollmentRequest", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					queued = args.Get(2).(*db_models.EnrollmentRequest)
+				}).
+				Return(nil)
+
+			got, err := svc.ReEnroll(tt.args.ctx, tt.args.userId, tt.args.courseId, tt.args.joinCode, tt.args.keepProgress)
+
+			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Equal(t, tt.wantQueued, queued, tt.name)
 		})
 	}
 }
 
-func TestCourseService_CreateCourseSection(t *testing.T) {
-	type mockRepo struct {
-		err error
-	}
-
+func TestCourseService_Unenroll(t *testing.T) {
 	type args struct {
-		ctx        context.Context
-		input     *models.CourseSectionInput
-		creatorId  string
+		ctx          context.Context
+		userId       string
+		courseId     string
+		keepProgress bool
 	}
 
 	tests := []struct {
 		name     string
 		args     args
-		mock     mockRepo
-		want    *models.CourseCreationResponse
+		enrolled bool
+		want     *models.EnrollResponse
 		wantErr  error
 	}{
 		{
-			name: "[CreateCourseSection] Success to create course with section",
-			args: args{
-				context.TODO(),
-				&models.CourseSectionInput{
-					Name: "",
-					CourseID: "",
-				},
-				creatorId,
-			},
-			mock: mockRepo{
-				err: nil,
-			},
-			want: &models.CourseCreationResponse{
+			name:     "[Unenroll] Success to unenroll from a course",
+			args:     args{context.TODO(), userId, courseId, false},
+			enrolled: true,
+			want: &models.EnrollResponse{
 				Status:  "Success",
-				Message: "Section Created Succesfully",
-				Id: "",
+				Message: "You have successfully unenrolled from the course.",
 			},
 			wantErr: nil,
 		},
+		{
+			name:     "[Unenroll] Not enrolled to the course",
+			args:     args{context.TODO(), userId, courseId, true},
+			enrolled: false,
+			want:     nil,
+			wantErr:  er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1345,79 +3488,98 @@ func TestCourseService_CreateCourseSection(t *testing.T) {
 			svc.InjectCourseRepository(repoMock)
 
 			repoMock.
-				On("InsertCourseMaterial",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.err)
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, tt.args.userId, tt.args.courseId).
+				Return(tt.enrolled, nil)
 
-			got, err := svc.CreateCourseSection(tt.args.ctx, tt.args.input, tt.args.creatorId)
-			
-			assert.Equal(t, tt.want.Status, got.Status, tt.name)
-			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			repoMock.
+				On("DeleteEnrollment", mock.Anything, mock.Anything, &models.EnrollInput{
+					UserID:       tt.args.userId,
+					CourseID:     tt.args.courseId,
+					Action:       models.EnrollmentActionUnenroll,
+					KeepProgress: tt.args.keepProgress,
+				}).
+				Return(nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(&db_models.Course{ID: tt.args.courseId, EnrollmentMode: models.EnrollmentModeOpen}, nil)
+
+			got, err := svc.Unenroll(tt.args.ctx, tt.args.userId, tt.args.courseId, tt.args.keepProgress)
+
+			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
 		})
 	}
 }
 
-func TestCourseService_CreateCourseMaterial(t *testing.T) {
-	type mockRepo struct {
-		getId struct {
-			id  string
-			err error
-		}
-		getMat struct {
-			res *db_models.Material
-			err  error
-		}
-		insert struct {
-			err error
-		}
-	}
+func TestCourseService_SetCoursePrerequisites(t *testing.T) {
+	missingCourseId := uuid.New().String()
+	brokenCourseId := uuid.New().String()
 
 	type args struct {
-		ctx        context.Context
-		input     *models.CourseMaterialInput
-		creatorId  string
+		ctx      context.Context
+		courseId string
+		input    *models.CoursePrerequisiteInput
+		userId   string
 	}
 
 	tests := []struct {
-		name     string
-		args     args
-		mock     mockRepo
-		want    *models.CourseCreationResponse
-		wantErr  error
+		name    string
+		args    args
+		want    *models.CourseUpdateResponse
+		wantErr error
 	}{
 		{
-			name: "[CreateCourseMaterial] Success to create course with material",
+			name: "[SetCoursePrerequisites] Success to set course prerequisites",
 			args: args{
 				context.TODO(),
-				&models.CourseMaterialInput{
-					Name: "",
-					Type: "",
-					Content: "",
-					ContentText: "",
-					SectionID: "",
-				},
+				courseId,
+				&models.CoursePrerequisiteInput{Prerequisites: []string{courseId2, courseId2}},
 				creatorId,
 			},
-			mock: mockRepo{
-				getId: struct{id string; err error}{
-					syllabusId,
-					nil,
-				},
-				getMat: struct{res *db_models.Material; err error}{
-					nil,
-					nil,
-				},
-				insert: struct{err error}{
-					nil,
-				},
-			},
-			want: &models.CourseCreationResponse{
+			want: &models.CourseUpdateResponse{
 				Status:  "Success",
-				Message: "Section Created Succesfully",
-				Id: "",
+				Message: "Course Prerequisites Updated Succesfully",
 			},
 			wantErr: nil,
 		},
+		{
+			name: "[SetCoursePrerequisites] Invalid prerequisites",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CoursePrerequisiteInput{Prerequisites: []string{courseId, missingCourseId, courseId3}},
+				creatorId,
+			},
+			want: nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid course prerequisites"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "prerequisites[0]", Reason: "A course cannot be its own prerequisite"},
+				{Field: "prerequisites[1]", Reason: "Course not found"},
+				{Field: "prerequisites[2]", Reason: "Course already requires this course"},
+			}),
+		},
+		{
+			name: "[SetCoursePrerequisites] Failed to get prerequisite",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CoursePrerequisiteInput{Prerequisites: []string{brokenCourseId}},
+				creatorId,
+			},
+			want:    nil,
+			wantErr: errors.New("connection refused"),
+		},
+		{
+			name: "[SetCoursePrerequisites] Not the course creator",
+			args: args{
+				context.TODO(),
+				courseId,
+				&models.CoursePrerequisiteInput{Prerequisites: []string{courseId2}},
+				userId,
+			},
+			want:    nil,
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to modify this course"), http.StatusForbidden, nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1429,72 +3591,96 @@ func TestCourseService_CreateCourseMaterial(t *testing.T) {
 			svc.InjectCourseRepository(repoMock)
 
 			repoMock.
-				On("GetCourseIDByMaterialID",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.getId.id, tt.mock.getId.err)
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
 
 			repoMock.
-				On("GetMaterialByID",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.getMat.res, tt.mock.getMat.err)
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return("", nil)
 
 			repoMock.
-				On("InsertCourseMaterial",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.insert.err)
+				On("GetCourseById", mock.Anything, mock.Anything, courseId2).
+				Return(&db_models.Course{ID: courseId2, Creator: creatorId}, nil)
 
-			got, err := svc.CreateCourseMaterial(tt.args.ctx, tt.args.input, tt.args.creatorId)
-			
-			assert.Equal(t, tt.want.Status, got.Status, tt.name)
-			assert.Equal(t, tt.want.Message, got.Message, tt.name)
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId3).
+				Return(&db_models.Course{ID: courseId3, Creator: creatorId}, nil)
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, missingCourseId).
+				Return(nil, er.NewError(fmt.Errorf("%s", "Course not found"), http.StatusNotFound, nil))
+
+			repoMock.
+				On("GetCourseById", mock.Anything, mock.Anything, brokenCourseId).
+				Return(nil, errors.New("connection refused"))
+
+			// courseId3 already requires courseId, so it cannot become its prerequisite.
+			repoMock.
+				On("GetPrerequisitesByCourseID", mock.Anything, mock.Anything, courseId3).
+				Return([]*db_models.Course{{ID: courseId2}, {ID: courseId}}, nil)
+
+			repoMock.
+				On("GetPrerequisitesByCourseID", mock.Anything, mock.Anything, courseId2).
+				Return([]*db_models.Course{}, nil)
+
+			repoMock.
+				On("SetCoursePrerequisites", mock.Anything, mock.Anything, courseId, []string{courseId2}).
+				Return(nil)
+
+			got, err := svc.SetCoursePrerequisites(tt.args.ctx, tt.args.courseId, tt.args.input, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
 		})
 	}
 }
 
-func TestCourseService_GetCourseByCreatorID(t *testing.T) {
-	type mockRepo struct {
-		res []*db_models.Course
-		err error
+func TestCourseService_CloneCourse(t *testing.T) {
+	source := &db_models.Course{
+		ID:         courseId,
+		CourseName: "Introduction to Programming",
+		Creator:    creatorId,
+		Status:     models.CourseStatusPublished,
+		Sequential: true,
+		MinScore:   70,
 	}
 
 	type args struct {
-		ctx context.Context
-		creatorId  string
+		ctx      context.Context
+		courseId string
+		input    *models.CourseCloneInput
+		userId   string
+		isAdmin  bool
 	}
 
 	tests := []struct {
-		name     string
-		args     args
-		mock     mockRepo
-		want     []*models.Course
-		wantErr  error
+		name        string
+		args        args
+		wantCreator string
+		wantStatus  string
+		wantName    string
+		wantErr     error
 	}{
 		{
-			name: "[GetCourseByCreatorID] Success to get course by creator ID",
-			args: args{
-				context.TODO(),
-				creatorId,
-			},
-			mock: mockRepo{
-				res: []*db_models.Course{
-					{
-						ID: courseId,
-						CourseName: "",
-						Description: "",
-						Thumbnail: "",
-						Creator: creatorId,
-					},
-				},
-				err: nil,
-			},
-			want: []*models.Course{
-				{
-					ID: courseId,
-					CourseName: "",
-					Description: "",
-					Thumbnail: "",
-					Creator: creatorId,
-				},
-			},
-			wantErr: nil,
+			name:        "[CloneCourse] Success to clone own course",
+			args:        args{context.TODO(), courseId, &models.CourseCloneInput{}, creatorId, false},
+			wantCreator: creatorId,
+			wantStatus:  models.CourseStatusDraft,
+			wantName:    "Introduction to Programming",
+			wantErr:     nil,
+		},
+		{
+			name:        "[CloneCourse] Success to clone course as a draft owned by the caller",
+			args:        args{context.TODO(), courseId, &models.CourseCloneInput{CourseName: "Introduction to Programming 2023"}, userId, true},
+			wantCreator: userId,
+			wantStatus:  models.CourseStatusDraft,
+			wantName:    "Introduction to Programming 2023",
+			wantErr:     nil,
+		},
+		{
+			name:    "[CloneCourse] Not allowed to clone course of other creator",
+			args:    args{context.TODO(), courseId, &models.CourseCloneInput{}, userId, false},
+			wantErr: er.NewError(fmt.Errorf("%s", "You are not allowed to clone this course"), http.StatusForbidden, nil),
 		},
 	}
 
@@ -1507,13 +3693,54 @@ func TestCourseService_GetCourseByCreatorID(t *testing.T) {
 			svc.InjectCourseRepository(repoMock)
 
 			repoMock.
-				On("GetCourseByCreatorID",mock.Anything, mock.Anything, mock.Anything).
-				Return(tt.mock.res, tt.mock.err)
+				On("GetCourseById", mock.Anything, mock.Anything, tt.args.courseId).
+				Return(source, nil)
+
+			repoMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return("", nil)
+
+			repoMock.
+				On("GetMaterialsByCourseID", mock.Anything, mock.Anything, tt.args.courseId).
+				Return([]*db_models.Material{
+					{ID: syllabusId, CourseID: courseId, Name: "Week 1", Type: "section"},
+					{ID: materialId, CourseID: courseId, Name: "Quiz 1", Type: "assignment", SectionID: syllabusId, Content: materialId},
+				}, nil)
+
+			var clone *db_models.CourseClone
+			repoMock.
+				On("CloneCourse", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					clone = args.Get(2).(*db_models.CourseClone)
+				}).
+				Return(nil)
+
+			got, err := svc.CloneCourse(tt.args.ctx, tt.args.courseId, tt.args.input, tt.args.userId, tt.args.isAdmin)
 
-			got, err := svc.GetCourseByCreatorID(tt.args.ctx, tt.args.creatorId)
-			
-			assert.Equal(t, tt.want, got, tt.name)
 			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Course Cloned Succesfully", got.Message, tt.name)
+			assert.Equal(t, clone.Course.ID, got.Id, tt.name)
+			assert.NotEqual(t, courseId, clone.Course.ID, tt.name)
+			assert.Equal(t, courseId, clone.SourceID, tt.name)
+			assert.Equal(t, tt.wantCreator, clone.Course.Creator, tt.name)
+			assert.Equal(t, tt.wantStatus, clone.Course.Status, tt.name)
+			assert.Equal(t, tt.wantName, clone.Course.CourseName, tt.name)
+			assert.True(t, clone.Course.Sequential, tt.name)
+			assert.Equal(t, 70, clone.Course.MinScore, tt.name)
+
+			assert.Len(t, clone.Materials, 2, tt.name)
+			section, material := clone.Materials[0], clone.Materials[1]
+			assert.NotEqual(t, syllabusId, section.ID, tt.name)
+			assert.NotEqual(t, materialId, material.ID, tt.name)
+			assert.Equal(t, clone.Course.ID, material.CourseID, tt.name)
+			assert.Equal(t, section.ID, material.SectionID, tt.name)
+			assert.Equal(t, material.ID, material.Content, tt.name)
+			assert.Equal(t, []db_models.ClonedAssignment{{SourceID: materialId, ID: material.ID}}, clone.Assignments, tt.name)
 		})
 	}
 }
@@ -3,7 +3,10 @@ package course
 import (
 	"errors"
 
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/user/user_repository"
 )
 
@@ -22,3 +25,27 @@ func (svc *courseService) InjectUserRepository(repo user_repository.UserReposito
 	}
 	return errors.New("user repository not found")
 }
+
+func (svc *courseService) InjectAssignmentRepository(repo assignment_repository.AssignmentRepository) error {
+	if repo != nil {
+		svc.assignmentRepository = repo
+		return nil
+	}
+	return errors.New("assignment repository not found")
+}
+
+func (svc *courseService) InjectProblemRepository(repo problem_repository.ProblemRepository) error {
+	if repo != nil {
+		svc.problemRepository = repo
+		return nil
+	}
+	return errors.New("problem repository not found")
+}
+
+func (svc *courseService) InjectSearchService(searchService search.SearchService) error {
+	if searchService != nil {
+		svc.searchService = searchService
+		return nil
+	}
+	return errors.New("search service not found")
+}
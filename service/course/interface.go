@@ -6,27 +6,91 @@ import (
 
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/assignment/assignment_repository"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/user/user_repository"
 )
 
 type CourseService interface {
 	InjectCourseRepository(course_repository.CourseRepository) error
 	InjectUserRepository(repo user_repository.UserRepository) error
+	InjectAssignmentRepository(repo assignment_repository.AssignmentRepository) error
+	InjectProblemRepository(repo problem_repository.ProblemRepository) error
+	InjectSearchService(searchService search.SearchService) error
 	GetCourseDetail(ctx context.Context, id string) (*models.Course, error)
 	GetCompeletedCourse(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.Course, uint64, error)
 	GetOnProgressCourse(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.Course, uint64, error)
-	GetCoursePagination(ctx context.Context, meta *pagination.Meta) ([]*models.Course, uint64, error)
-	GetCourseSyllabus(ctx context.Context, courseId string) (*models.SyllabusResponse, error)
-	GetCourseMaterial(ctx context.Context, courseId string, sectionId string) (*models.SectionContentResponse, error)
-	Enroll(ctx context.Context, userId string, courseId string) (*models.EnrollResponse, error)
-	StoreUserProgress(ctx context.Context, userId, materialId string) (*models.StoreProgressResponse, error)
+	GetCoursePagination(ctx context.Context, meta *pagination.Meta, filter models.CourseFilter) ([]*models.Course, uint64, error)
+	GetCourseSyllabus(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.SyllabusResponse, error)
+	GetCourseMaterial(ctx context.Context, courseId string, sectionId string, userId string, isAdmin bool) (*models.SectionContentResponse, error)
+	Enroll(ctx context.Context, userId string, courseId string, joinCode string) (*models.EnrollResponse, error)
+	ReEnroll(ctx context.Context, userId string, courseId string, joinCode string, keepProgress bool) (*models.EnrollResponse, error)
+	Unenroll(ctx context.Context, userId string, courseId string, keepProgress bool) (*models.EnrollResponse, error)
+	GetEnrollmentHistory(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.EnrollmentHistory, uint64, error)
+	CheckMaterialAccess(ctx context.Context, userId, materialId string) (string, error)
+	StoreUserProgress(ctx context.Context, userId, materialId string, event *models.ProgressEvent) (*models.StoreProgressResponse, error)
 	ComputeUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressPercentageResponse, error)
+	CheckCourseCompletion(ctx context.Context, userId, courseId string) (bool, error)
 	GetUserProgress(ctx context.Context, userId, courseId string) (*models.GetProgressResponse, error)
+	GetResumePoints(ctx context.Context, meta *pagination.Meta, userId string) ([]*models.CourseResume, uint64, error)
 	CreateNewCourse(ctx context.Context, course *models.CourseCreation) (*models.CourseCreationResponse, error)
 	CreateCourseDesc(ctx context.Context, course *models.CourseDescriptionInput, creatorId string) (*models.CourseCreationResponse, error)
 	CreateCourseSection(ctx context.Context, course *models.CourseSectionInput, creatorId string) (*models.CourseCreationResponse, error)
 	CreateCourseMaterial(ctx context.Context, course *models.CourseMaterialInput, creatorId string) (*models.CourseCreationResponse, error)
 	UploadImage(ctx context.Context, request *http.Request, baseURL string) (*models.UploadImageResponse, error)
+	CloneCourse(ctx context.Context, courseId string, input *models.CourseCloneInput, userId string, isAdmin bool) (*models.CourseCreationResponse, error)
+	ExportCourse(ctx context.Context, courseId string, userId string, isAdmin bool) ([]byte, error)
+	ImportCourse(ctx context.Context, data []byte, userId string, baseURL string) (*models.CourseCreationResponse, error)
+	ImportContentPackage(ctx context.Context, data []byte, userId string, baseURL string) (*models.ContentPackageImportResponse, error)
 	GetCourseByCreatorID(ctx context.Context, creatorId string) ([]*models.Course, error)
+	UpdateCourseDesc(ctx context.Context, courseId string, input *models.CourseDescriptionUpdate, userId string) (*models.CourseUpdateResponse, error)
+	DeleteCourse(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error)
+	UpdateCourseSection(ctx context.Context, sectionId string, input *models.CourseSectionUpdateInput, userId string) (*models.CourseUpdateResponse, error)
+	DeleteCourseSection(ctx context.Context, sectionId string, cascade bool, userId string) (*models.CourseUpdateResponse, error)
+	UpdateCourseMaterial(ctx context.Context, materialId string, input *models.CourseMaterialUpdate, userId string) (*models.CourseUpdateResponse, error)
+	DeleteCourseMaterial(ctx context.Context, materialId string, userId string) (*models.CourseUpdateResponse, error)
+	GetCourseReviewList(ctx context.Context, meta *pagination.Meta) ([]*models.Course, uint64, error)
+	SubmitCourseForReview(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error)
+	PublishCourse(ctx context.Context, courseId string) (*models.CourseUpdateResponse, error)
+	RejectCourse(ctx context.Context, courseId string) (*models.CourseUpdateResponse, error)
+	ArchiveCourse(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CourseUpdateResponse, error)
+	GetTopicList(ctx context.Context) ([]*models.Topic, error)
+	CreateTopic(ctx context.Context, input *models.TopicInput) (*models.TopicResponse, error)
+	UpdateTopic(ctx context.Context, id string, input *models.TopicInput) (*models.TopicResponse, error)
+	DeleteTopic(ctx context.Context, id string) (*models.TopicResponse, error)
+	SetCourseTopics(ctx context.Context, courseId string, input *models.CourseTopicInput, userId string) (*models.CourseUpdateResponse, error)
+	GetCoursePrerequisites(ctx context.Context, courseId string, userId string) ([]*models.Prerequisite, error)
+	SetCoursePrerequisites(ctx context.Context, courseId string, input *models.CoursePrerequisiteInput, userId string) (*models.CourseUpdateResponse, error)
+	UpdateCourseAccess(ctx context.Context, courseId string, input *models.CourseAccessInput, userId string) (*models.CourseUpdateResponse, error)
+	ReorderSyllabus(ctx context.Context, courseId string, input *models.SyllabusOrderInput, userId string) (*models.CourseUpdateResponse, error)
+	GetCourseCollaborators(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.CourseCollaborator, error)
+	InviteCollaborator(ctx context.Context, courseId string, input *models.CollaboratorInviteInput, userId string) (*models.CourseUpdateResponse, error)
+	AcceptCollaboration(ctx context.Context, courseId string, userId string) (*models.CourseUpdateResponse, error)
+	UpdateCollaboratorRole(ctx context.Context, courseId string, collaboratorId string, input *models.CollaboratorRoleInput, userId string) (*models.CourseUpdateResponse, error)
+	RemoveCollaborator(ctx context.Context, courseId string, collaboratorId string, userId string) (*models.CourseUpdateResponse, error)
+	GetCollaborationInvitations(ctx context.Context, userId string) ([]*models.CollaboratorInvitation, error)
+	GetCourseReviews(ctx context.Context, meta *pagination.Meta, courseId string) ([]*models.CourseReview, uint64, error)
+	ReviewCourse(ctx context.Context, courseId string, input *models.CourseReviewInput, userId string) (*models.CourseUpdateResponse, error)
+	ModerateCourseReview(ctx context.Context, courseId string, reviewerId string, input *models.ReviewModerationInput) (*models.CourseUpdateResponse, error)
+	GetCourseAnnouncements(ctx context.Context, meta *pagination.Meta, courseId string, userId string, isAdmin bool) ([]*models.CourseAnnouncement, uint64, error)
+	CreateCourseAnnouncement(ctx context.Context, courseId string, input *models.CourseAnnouncementInput, userId string) (*models.CourseCreationResponse, error)
+	UpdateCourseAnnouncement(ctx context.Context, announcementId string, input *models.CourseAnnouncementInput, userId string) (*models.CourseUpdateResponse, error)
+	DeleteCourseAnnouncement(ctx context.Context, announcementId string, userId string) (*models.CourseUpdateResponse, error)
+	MarkAnnouncementRead(ctx context.Context, announcementId string, userId string, isAdmin bool) (*models.CourseUpdateResponse, error)
+	GetUnreadAnnouncements(ctx context.Context, userId string) ([]*models.UnreadAnnouncement, error)
+	GetCourseCohorts(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.Cohort, error)
+	CreateCohort(ctx context.Context, courseId string, input *models.CohortInput, userId string) (*models.CohortResponse, error)
+	UpdateCohort(ctx context.Context, cohortId string, input *models.CohortInput, userId string) (*models.CohortResponse, error)
+	DeleteCohort(ctx context.Context, cohortId string, userId string) (*models.CourseUpdateResponse, error)
+	GetCohortRoster(ctx context.Context, cohortId string, userId string, isAdmin bool) ([]*models.CohortMember, error)
+	UpdateEnrollmentSettings(ctx context.Context, courseId string, input *models.EnrollmentSettingsInput, userId string) (*models.CourseUpdateResponse, error)
+	GetEnrollmentRequests(ctx context.Context, meta *pagination.Meta, courseId string, status string, userId string) ([]*models.EnrollmentRequest, uint64, error)
+	ApproveEnrollmentRequest(ctx context.Context, courseId string, requesterId string, userId string) (*models.CourseUpdateResponse, error)
+	DenyEnrollmentRequest(ctx context.Context, courseId string, requesterId string, userId string) (*models.CourseUpdateResponse, error)
+	GetEnrollmentAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.EnrollmentStat, error)
+	GetFunnelAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CourseFunnel, error)
+	GetScoreAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) ([]*models.AssignmentScoreStat, error)
+	GetCompletionAnalytics(ctx context.Context, courseId string, userId string, isAdmin bool) (*models.CompletionTime, error)
 }
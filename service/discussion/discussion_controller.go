@@ -0,0 +1,197 @@
+package discussion
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	custom_validator "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/databases/validator"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type DiscussionController struct {
+	discussionService DiscussionService
+}
+
+func NewController(svc DiscussionService) *DiscussionController {
+	return &DiscussionController{
+		discussionService: svc,
+	}
+}
+
+func (ctl *DiscussionController) handleGetThreads(c echo.Context, targetType string) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	targetId := c.Param("id")
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.discussionService.GetThreads(ctx, &meta, targetType, targetId, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) handleCreateThread(c echo.Context, targetType string) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	targetId := c.Param("id")
+
+	input := new(models.DiscussionInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.discussionService.CreateThread(ctx, targetType, targetId, input, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) HandleGetMaterialThreads(c echo.Context) error {
+	return ctl.handleGetThreads(c, models.DiscussionTargetMaterial)
+}
+
+func (ctl *DiscussionController) HandleCreateMaterialThread(c echo.Context) error {
+	return ctl.handleCreateThread(c, models.DiscussionTargetMaterial)
+}
+
+func (ctl *DiscussionController) HandleGetAssignmentThreads(c echo.Context) error {
+	return ctl.handleGetThreads(c, models.DiscussionTargetAssignment)
+}
+
+func (ctl *DiscussionController) HandleCreateAssignmentThread(c echo.Context) error {
+	return ctl.handleCreateThread(c, models.DiscussionTargetAssignment)
+}
+
+func (ctl *DiscussionController) HandleGetReplies(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	id := c.Param("id")
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.discussionService.GetReplies(ctx, &meta, id, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) HandleCreateReply(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	id := c.Param("id")
+
+	input := new(models.DiscussionInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.discussionService.CreateReply(ctx, id, input, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) HandleUpdateDiscussion(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	id := c.Param("id")
+
+	input := new(models.DiscussionInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.discussionService.UpdateDiscussion(ctx, id, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) HandleDeleteDiscussion(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	id := c.Param("id")
+
+	resp, err := ctl.discussionService.DeleteDiscussion(ctx, id, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *DiscussionController) HandleMarkAnswered(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	isAdmin := c.Get("isAdmin") == true
+	id := c.Param("id")
+
+	input := new(models.DiscussionAnsweredInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	resp, err := ctl.discussionService.MarkAnswered(ctx, id, input, userId, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,181 @@
+package discussion_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type discussionRepository struct{}
+
+func NewRepository() DiscussionRepository {
+	return &discussionRepository{}
+}
+
+func (repo *discussionRepository) GetTableName() string {
+	return "discussion"
+}
+
+func (repo *discussionRepository) querySelectDiscussion() sq.SelectBuilder {
+	builder := sq.Select(
+		"discussion.id",
+		"discussion.course_id",
+		"discussion.target_type",
+		"discussion.target_id",
+		"discussion.parent_id",
+		"discussion.user_id",
+		"COALESCE(User.username, '') AS username",
+		"discussion.content",
+		"discussion.answered",
+		"(SELECT COUNT(*) FROM discussion AS reply WHERE reply.parent_id = discussion.id) AS reply_count",
+		"discussion.created_at",
+		"discussion.updated_at",
+	).From(repo.GetTableName()).
+		LeftJoin("User ON User.id = discussion.user_id")
+
+	return builder
+}
+
+// getDiscussionPage runs the select and count queries of a paginated
+// discussion listing sharing the same conditions.
+func (repo *discussionRepository) getDiscussionPage(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, where sq.Eq, order string) ([]*db_models.Discussion, uint64, error) {
+	var discussions []*db_models.Discussion
+	var count uint64
+
+	selectQuery, args, err := repo.querySelectDiscussion().Where(where).OrderBy(order).ToSql()
+	if err != nil {
+		return discussions, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &discussions, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return discussions, count, nil
+		}
+		return discussions, count, err
+	}
+
+	countQuery, args, err := sq.Select("count(discussion.id)").From(repo.GetTableName()).Where(where).ToSql()
+	if err != nil {
+		return discussions, count, err
+	}
+
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		return discussions, count, err
+	}
+
+	return discussions, count, nil
+}
+
+func (repo *discussionRepository) GetThreads(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, targetType string, targetId string) ([]*db_models.Discussion, uint64, error) {
+	return repo.getDiscussionPage(ctx, db, meta, sq.Eq{
+		"discussion.target_type": targetType,
+		"discussion.target_id":   targetId,
+		"discussion.parent_id":   "",
+	}, "discussion.created_at DESC")
+}
+
+func (repo *discussionRepository) GetReplies(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, parentId string) ([]*db_models.Discussion, uint64, error) {
+	return repo.getDiscussionPage(ctx, db, meta, sq.Eq{
+		"discussion.parent_id": parentId,
+	}, "discussion.created_at")
+}
+
+func (repo *discussionRepository) GetDiscussionById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Discussion, error) {
+	out := new(db_models.Discussion)
+	query, args, err := repo.querySelectDiscussion().Where(sq.Eq{"discussion.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Discussion not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *discussionRepository) InsertDiscussion(ctx context.Context, db *sqlx.DB, discussion *db_models.Discussion) error {
+	query, args, err := sq.Insert(repo.GetTableName()).
+		Columns("id", "course_id", "target_type", "target_id", "parent_id", "user_id", "content").
+		Values(discussion.ID, discussion.CourseID, discussion.TargetType, discussion.TargetID, discussion.ParentID, discussion.UserID, discussion.Content).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *discussionRepository) UpdateDiscussionContent(ctx context.Context, db *sqlx.DB, id string, content string) error {
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("content", content).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *discussionRepository) SetDiscussionAnswered(ctx context.Context, db *sqlx.DB, id string, answered bool) error {
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("answered", answered).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteDiscussion removes a discussion together with its replies.
+func (repo *discussionRepository) DeleteDiscussion(ctx context.Context, db *sqlx.DB, id string) error {
+	query, args, err := sq.Delete(repo.GetTableName()).
+		Where(sq.Or{
+			sq.Eq{"id": id},
+			sq.Eq{"parent_id": id},
+		}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package discussion_repository_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion/discussion_repository"
+)
+
+var (
+	discussionId = uuid.New().String()
+	courseId     = uuid.New().String()
+	materialId   = uuid.New().String()
+	userId       = uuid.New().String()
+)
+
+const querySelectDiscussion = `SELECT discussion.id, discussion.course_id, discussion.target_type, discussion.target_id, discussion.parent_id, discussion.user_id, COALESCE(User.username, '') AS username, discussion.content, discussion.answered, (SELECT COUNT(*) FROM discussion AS reply WHERE reply.parent_id = discussion.id) AS reply_count, discussion.created_at, discussion.updated_at FROM discussion LEFT JOIN User ON User.id = discussion.user_id`
+
+func TestDiscussionRepository_GetThreads(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(querySelectDiscussion+` WHERE discussion.parent_id = ? AND discussion.target_id = ? AND discussion.target_type = ? ORDER BY discussion.created_at DESC limit 0,10`)).
+		WithArgs("", materialId, "material").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "course_id", "target_type", "target_id", "parent_id", "user_id", "username", "content", "answered", "reply_count"}).
+			AddRow(discussionId, courseId, "material", materialId, "", userId, "john", "How do I start?", false, 2))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(discussion.id) FROM discussion WHERE discussion.parent_id = ? AND discussion.target_id = ? AND discussion.target_type = ?`)).
+		WithArgs("", materialId, "material").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	r := discussion_repository.NewRepository()
+	got, count, err := r.GetThreads(context.TODO(), sqlxDB, &pagination.Meta{Page: 1, Limit: 10}, "material", materialId)
+	assert.Nil(t, err, "[GetThreads] Success to get threads")
+	assert.Equal(t, uint64(1), count, "[GetThreads] Success to get threads")
+	assert.Equal(t, []*db_models.Discussion{
+		{ID: discussionId, CourseID: courseId, TargetType: "material", TargetID: materialId, UserID: userId, Username: "john", Content: "How do I start?", ReplyCount: 2},
+	}, got, "[GetThreads] Success to get threads")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetThreads] Success to get threads")
+}
+
+func TestDiscussionRepository_GetDiscussionById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectQuery(regexp.QuoteMeta(querySelectDiscussion + ` WHERE discussion.id = ?`)).
+		WithArgs(discussionId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	r := discussion_repository.NewRepository()
+	got, err := r.GetDiscussionById(context.TODO(), sqlxDB, discussionId)
+	assert.Nil(t, got, "[GetDiscussionById] Discussion not found")
+	assert.Equal(t, er.NewError(fmt.Errorf("%s", "Discussion not found"), http.StatusNotFound, nil), err, "[GetDiscussionById] Discussion not found")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[GetDiscussionById] Discussion not found")
+}
+
+func TestDiscussionRepository_InsertDiscussion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO discussion (id,course_id,target_type,target_id,parent_id,user_id,content) VALUES (?,?,?,?,?,?,?)`)).
+		WithArgs(discussionId, courseId, "material", materialId, "", userId, "How do I start?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := discussion_repository.NewRepository()
+	err = r.InsertDiscussion(context.TODO(), sqlxDB, &db_models.Discussion{
+		ID:         discussionId,
+		CourseID:   courseId,
+		TargetType: "material",
+		TargetID:   materialId,
+		UserID:     userId,
+		Content:    "How do I start?",
+	})
+	assert.Nil(t, err, "[InsertDiscussion] Success to insert discussion")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[InsertDiscussion] Success to insert discussion")
+}
+
+func TestDiscussionRepository_DeleteDiscussion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM discussion WHERE (id = ? OR parent_id = ?)`)).
+		WithArgs(discussionId, discussionId).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	r := discussion_repository.NewRepository()
+	err = r.DeleteDiscussion(context.TODO(), sqlxDB, discussionId)
+	assert.Nil(t, err, "[DeleteDiscussion] Success to delete discussion")
+	assert.Nil(t, mock.ExpectationsWereMet(), "[DeleteDiscussion] Success to delete discussion")
+}
@@ -0,0 +1,20 @@
+package discussion_repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type DiscussionRepository interface {
+	GetTableName() string
+	GetThreads(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, targetType string, targetId string) ([]*db_models.Discussion, uint64, error)
+	GetReplies(ctx context.Context, db *sqlx.DB, meta *pagination.Meta, parentId string) ([]*db_models.Discussion, uint64, error)
+	GetDiscussionById(ctx context.Context, db *sqlx.DB, id string) (*db_models.Discussion, error)
+	InsertDiscussion(ctx context.Context, db *sqlx.DB, discussion *db_models.Discussion) error
+	UpdateDiscussionContent(ctx context.Context, db *sqlx.DB, id string, content string) error
+	SetDiscussionAnswered(ctx context.Context, db *sqlx.DB, id string, answered bool) error
+	DeleteDiscussion(ctx context.Context, db *sqlx.DB, id string) error
+}
@@ -0,0 +1,331 @@
+package discussion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion/discussion_repository"
+)
+
+type discussionService struct {
+	db               *sqlx.DB
+	repository       discussion_repository.DiscussionRepository
+	courseRepository course_repository.CourseRepository
+}
+
+func NewService(db *sqlx.DB) DiscussionService {
+	return &discussionService{
+		db: db,
+	}
+}
+
+func toDiscussion(item *db_models.Discussion) *models.Discussion {
+	return &models.Discussion{
+		ID:         item.ID,
+		TargetType: item.TargetType,
+		TargetID:   item.TargetID,
+		ParentID:   item.ParentID,
+		UserID:     item.UserID,
+		Username:   item.Username,
+		Content:    item.Content,
+		Answered:   item.Answered,
+		ReplyCount: item.ReplyCount,
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.UpdatedAt,
+	}
+}
+
+// getTargetCourse returns the course the material or assignment belongs to.
+// Assignments are found through the assignment material sharing their id.
+func (serv *discussionService) getTargetCourse(ctx context.Context, targetType string, targetId string) (*db_models.Course, error) {
+	if targetType != models.DiscussionTargetMaterial && targetType != models.DiscussionTargetAssignment {
+		return nil, er.NewError(fmt.Errorf("%s", "Invalid discussion target"), http.StatusBadRequest, nil)
+	}
+
+	material, err := serv.courseRepository.GetMaterialByID(ctx, serv.db, targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	isAssignment := material.Type == "assignment"
+	if material.Type == "section" || isAssignment != (targetType == models.DiscussionTargetAssignment) {
+		return nil, er.NewError(fmt.Errorf("%s", "Discussion target not found"), http.StatusNotFound, nil)
+	}
+
+	return serv.courseRepository.GetCourseById(ctx, serv.db, material.CourseID)
+}
+
+// isCourseStaff tells whether the user created the course or has accepted an
+// invitation to collaborate on it.
+func (serv *discussionService) isCourseStaff(ctx context.Context, course *db_models.Course, userId string) (bool, error) {
+	if course.Creator == userId {
+		return true, nil
+	}
+
+	role, err := serv.courseRepository.GetCollaboratorRole(ctx, serv.db, course.ID, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return role != "", nil
+}
+
+// checkCourseAccess lets the staff of the course, admins and learners who are
+// enrolled to or have completed the course take part in its discussions.
+func (serv *discussionService) checkCourseAccess(ctx context.Context, course *db_models.Course, userId string, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+
+	staff, err := serv.isCourseStaff(ctx, course, userId)
+	if err != nil {
+		return err
+	}
+
+	if staff {
+		return nil
+	}
+
+	enrolled, err := serv.courseRepository.IsUserEnrolledToCourse(ctx, serv.db, userId, course.ID)
+	if err != nil {
+		return err
+	}
+
+	if enrolled {
+		return nil
+	}
+
+	completed, err := serv.courseRepository.IsCourseCompleted(ctx, serv.db, userId, course.ID)
+	if err != nil {
+		return err
+	}
+
+	if completed {
+		return nil
+	}
+
+	return er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+}
+
+// getThread loads a thread and checks the user may take part in the
+// discussions of its course.
+func (serv *discussionService) getThread(ctx context.Context, id string, userId string, isAdmin bool) (*db_models.Discussion, *db_models.Course, error) {
+	thread, err := serv.repository.GetDiscussionById(ctx, serv.db, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if thread.ParentID != "" {
+		return nil, nil, er.NewError(fmt.Errorf("%s", "The discussion is not a thread"), http.StatusBadRequest, nil)
+	}
+
+	course, err := serv.courseRepository.GetCourseById(ctx, serv.db, thread.CourseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = serv.checkCourseAccess(ctx, course, userId, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return thread, course, nil
+}
+
+func (serv *discussionService) GetThreads(ctx context.Context, meta *pagination.Meta, targetType string, targetId string, userId string, isAdmin bool) ([]*models.Discussion, uint64, error) {
+	threads := []*models.Discussion{}
+
+	course, err := serv.getTargetCourse(ctx, targetType, targetId)
+	if err != nil {
+		return threads, 0, err
+	}
+
+	err = serv.checkCourseAccess(ctx, course, userId, isAdmin)
+	if err != nil {
+		return threads, 0, err
+	}
+
+	db_threads, count, err := serv.repository.GetThreads(ctx, serv.db, meta, targetType, targetId)
+	if err != nil {
+		return threads, count, err
+	}
+
+	for _, item := range db_threads {
+		threads = append(threads, toDiscussion(item))
+	}
+
+	return threads, count, nil
+}
+
+func (serv *discussionService) CreateThread(ctx context.Context, targetType string, targetId string, input *models.DiscussionInput, userId string, isAdmin bool) (*models.DiscussionResponse, error) {
+	course, err := serv.getTargetCourse(ctx, targetType, targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.checkCourseAccess(ctx, course, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	err = serv.repository.InsertDiscussion(ctx, serv.db, &db_models.Discussion{
+		ID:         id,
+		CourseID:   course.ID,
+		TargetType: targetType,
+		TargetID:   targetId,
+		UserID:     userId,
+		Content:    input.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.DiscussionResponse{
+		Status:  "Success",
+		Message: "Discussion Created Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *discussionService) GetReplies(ctx context.Context, meta *pagination.Meta, id string, userId string, isAdmin bool) ([]*models.Discussion, uint64, error) {
+	replies := []*models.Discussion{}
+
+	_, _, err := serv.getThread(ctx, id, userId, isAdmin)
+	if err != nil {
+		return replies, 0, err
+	}
+
+	db_replies, count, err := serv.repository.GetReplies(ctx, serv.db, meta, id)
+	if err != nil {
+		return replies, count, err
+	}
+
+	for _, item := range db_replies {
+		replies = append(replies, toDiscussion(item))
+	}
+
+	return replies, count, nil
+}
+
+func (serv *discussionService) CreateReply(ctx context.Context, id string, input *models.DiscussionInput, userId string, isAdmin bool) (*models.DiscussionResponse, error) {
+	thread, _, err := serv.getThread(ctx, id, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	replyId := uuid.New().String()
+	err = serv.repository.InsertDiscussion(ctx, serv.db, &db_models.Discussion{
+		ID:         replyId,
+		CourseID:   thread.CourseID,
+		TargetType: thread.TargetType,
+		TargetID:   thread.TargetID,
+		ParentID:   thread.ID,
+		UserID:     userId,
+		Content:    input.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.DiscussionResponse{
+		Status:  "Success",
+		Message: "Reply Created Succesfully",
+		ID:      replyId,
+	}
+
+	return resp, nil
+}
+
+func (serv *discussionService) UpdateDiscussion(ctx context.Context, id string, input *models.DiscussionInput, userId string) (*models.DiscussionResponse, error) {
+	discussion, err := serv.repository.GetDiscussionById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if discussion.UserID != userId {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to modify this discussion"), http.StatusForbidden, nil)
+	}
+
+	err = serv.repository.UpdateDiscussionContent(ctx, serv.db, id, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.DiscussionResponse{
+		Status:  "Success",
+		Message: "Discussion Updated Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+// DeleteDiscussion removes a thread with its replies or a single reply. Admins
+// may delete any discussion.
+func (serv *discussionService) DeleteDiscussion(ctx context.Context, id string, userId string, isAdmin bool) (*models.DiscussionResponse, error) {
+	discussion, err := serv.repository.GetDiscussionById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if discussion.UserID != userId && !isAdmin {
+		return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to modify this discussion"), http.StatusForbidden, nil)
+	}
+
+	err = serv.repository.DeleteDiscussion(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.DiscussionResponse{
+		Status:  "Success",
+		Message: "Discussion Deleted Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+// MarkAnswered lets the staff of the course flag whether a thread has been
+// answered.
+func (serv *discussionService) MarkAnswered(ctx context.Context, id string, input *models.DiscussionAnsweredInput, userId string, isAdmin bool) (*models.DiscussionResponse, error) {
+	thread, course, err := serv.getThread(ctx, id, userId, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin {
+		staff, err := serv.isCourseStaff(ctx, course, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !staff {
+			return nil, er.NewError(fmt.Errorf("%s", "You are not allowed to modify this discussion"), http.StatusForbidden, nil)
+		}
+	}
+
+	err = serv.repository.SetDiscussionAnswered(ctx, serv.db, thread.ID, input.Answered)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.DiscussionResponse{
+		Status:  "Success",
+		Message: "Discussion Updated Succesfully",
+		ID:      thread.ID,
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,319 @@
+package discussion_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion"
+)
+
+var (
+	courseId       = uuid.New().String()
+	creatorId      = uuid.New().String()
+	userId         = uuid.New().String()
+	materialId     = uuid.New().String()
+	threadId       = uuid.New().String()
+	replyId        = uuid.New().String()
+	notAllowed     = er.NewError(fmt.Errorf("%s", "You are not allowed to modify this discussion"), http.StatusForbidden, nil)
+	notEnrolled    = er.NewError(fmt.Errorf("%s", "You are not enrolled to the course"), http.StatusBadRequest, nil)
+	targetNotFound = er.NewError(fmt.Errorf("%s", "Discussion target not found"), http.StatusNotFound, nil)
+)
+
+func TestDiscussionService_CreateThread(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetType   string
+		materialType string
+		enrolled     bool
+		wantErr      error
+	}{
+		{
+			name:         "[CreateThread] Success to create thread on material",
+			targetType:   models.DiscussionTargetMaterial,
+			materialType: "video",
+			enrolled:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "[CreateThread] Success to create thread on assignment",
+			targetType:   models.DiscussionTargetAssignment,
+			materialType: "assignment",
+			enrolled:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "[CreateThread] User is not enrolled to the course",
+			targetType:   models.DiscussionTargetMaterial,
+			materialType: "video",
+			enrolled:     false,
+			wantErr:      notEnrolled,
+		},
+		{
+			name:         "[CreateThread] Target type does not match the material",
+			targetType:   models.DiscussionTargetAssignment,
+			materialType: "video",
+			enrolled:     true,
+			wantErr:      targetNotFound,
+		},
+		{
+			name:         "[CreateThread] Sections can not be discussed",
+			targetType:   models.DiscussionTargetMaterial,
+			materialType: "section",
+			enrolled:     true,
+			wantErr:      targetNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.DiscussionRepository)
+			courseMock := new(mocks.CourseRepository)
+			svc := discussion.NewService(sqlxDB)
+			svc.InjectDiscussionRepository(repoMock)
+			svc.InjectCourseRepository(courseMock)
+
+			courseMock.
+				On("GetMaterialByID", mock.Anything, mock.Anything, materialId).
+				Return(&db_models.Material{ID: materialId, CourseID: courseId, Type: tt.materialType}, nil)
+
+			courseMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			courseMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, userId).
+				Return("", nil)
+
+			courseMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, userId, courseId).
+				Return(tt.enrolled, nil)
+
+			courseMock.
+				On("IsCourseCompleted", mock.Anything, mock.Anything, userId, courseId).
+				Return(false, nil)
+
+			var inserted *db_models.Discussion
+			repoMock.
+				On("InsertDiscussion", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.Discussion)
+				}).
+				Return(nil)
+
+			got, err := svc.CreateThread(context.TODO(), tt.targetType, materialId, &models.DiscussionInput{Content: "How do I start?"}, userId, false)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Discussion Created Succesfully", got.Message, tt.name)
+			assert.Equal(t, &db_models.Discussion{
+				ID:         got.ID,
+				CourseID:   courseId,
+				TargetType: tt.targetType,
+				TargetID:   materialId,
+				UserID:     userId,
+				Content:    "How do I start?",
+			}, inserted, tt.name)
+		})
+	}
+}
+
+func TestDiscussionService_CreateReply(t *testing.T) {
+	tests := []struct {
+		name       string
+		discussion *db_models.Discussion
+		wantErr    error
+	}{
+		{
+			name:       "[CreateReply] Success to reply to a thread",
+			discussion: &db_models.Discussion{ID: threadId, CourseID: courseId, TargetType: models.DiscussionTargetMaterial, TargetID: materialId},
+			wantErr:    nil,
+		},
+		{
+			name:       "[CreateReply] Replies can not be replied to",
+			discussion: &db_models.Discussion{ID: replyId, CourseID: courseId, TargetType: models.DiscussionTargetMaterial, TargetID: materialId, ParentID: threadId},
+			wantErr:    er.NewError(fmt.Errorf("%s", "The discussion is not a thread"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.DiscussionRepository)
+			courseMock := new(mocks.CourseRepository)
+			svc := discussion.NewService(sqlxDB)
+			svc.InjectDiscussionRepository(repoMock)
+			svc.InjectCourseRepository(courseMock)
+
+			repoMock.
+				On("GetDiscussionById", mock.Anything, mock.Anything, tt.discussion.ID).
+				Return(tt.discussion, nil)
+
+			courseMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			var inserted *db_models.Discussion
+			repoMock.
+				On("InsertDiscussion", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					inserted = args.Get(2).(*db_models.Discussion)
+				}).
+				Return(nil)
+
+			got, err := svc.CreateReply(context.TODO(), tt.discussion.ID, &models.DiscussionInput{Content: "Watch the first video"}, creatorId, false)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				assert.Nil(t, inserted, tt.name)
+				return
+			}
+
+			assert.Equal(t, "Reply Created Succesfully", got.Message, tt.name)
+			assert.Equal(t, threadId, inserted.ParentID, tt.name)
+			assert.Equal(t, materialId, inserted.TargetID, tt.name)
+		})
+	}
+}
+
+func TestDiscussionService_UpdateDiscussion(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  string
+		wantErr error
+	}{
+		{
+			name:    "[UpdateDiscussion] Author edits the discussion",
+			userId:  userId,
+			wantErr: nil,
+		},
+		{
+			name:    "[UpdateDiscussion] Only the author may edit the discussion",
+			userId:  creatorId,
+			wantErr: notAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.DiscussionRepository)
+			svc := discussion.NewService(sqlxDB)
+			svc.InjectDiscussionRepository(repoMock)
+
+			repoMock.
+				On("GetDiscussionById", mock.Anything, mock.Anything, threadId).
+				Return(&db_models.Discussion{ID: threadId, CourseID: courseId, UserID: userId}, nil)
+
+			repoMock.
+				On("UpdateDiscussionContent", mock.Anything, mock.Anything, threadId, "Edited").
+				Return(nil)
+
+			got, err := svc.UpdateDiscussion(context.TODO(), threadId, &models.DiscussionInput{Content: "Edited"}, tt.userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "UpdateDiscussionContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Discussion Updated Succesfully", got.Message, tt.name)
+		})
+	}
+}
+
+func TestDiscussionService_MarkAnswered(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  string
+		role    string
+		isAdmin bool
+		wantErr error
+	}{
+		{
+			name:    "[MarkAnswered] Creator marks the thread as answered",
+			userId:  creatorId,
+			wantErr: nil,
+		},
+		{
+			name:    "[MarkAnswered] Teaching assistant marks the thread as answered",
+			userId:  userId,
+			role:    models.CourseRoleTeachingAssistant,
+			wantErr: nil,
+		},
+		{
+			name:    "[MarkAnswered] Admin marks the thread as answered",
+			userId:  userId,
+			isAdmin: true,
+			wantErr: nil,
+		},
+		{
+			name:    "[MarkAnswered] Learners are not allowed to mark threads",
+			userId:  userId,
+			wantErr: notAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.DiscussionRepository)
+			courseMock := new(mocks.CourseRepository)
+			svc := discussion.NewService(sqlxDB)
+			svc.InjectDiscussionRepository(repoMock)
+			svc.InjectCourseRepository(courseMock)
+
+			repoMock.
+				On("GetDiscussionById", mock.Anything, mock.Anything, threadId).
+				Return(&db_models.Discussion{ID: threadId, CourseID: courseId, UserID: userId}, nil)
+
+			courseMock.
+				On("GetCourseById", mock.Anything, mock.Anything, courseId).
+				Return(&db_models.Course{ID: courseId, Creator: creatorId}, nil)
+
+			courseMock.
+				On("GetCollaboratorRole", mock.Anything, mock.Anything, courseId, tt.userId).
+				Return(tt.role, nil)
+
+			courseMock.
+				On("IsUserEnrolledToCourse", mock.Anything, mock.Anything, tt.userId, courseId).
+				Return(true, nil)
+
+			repoMock.
+				On("SetDiscussionAnswered", mock.Anything, mock.Anything, threadId, true).
+				Return(nil)
+
+			got, err := svc.MarkAnswered(context.TODO(), threadId, &models.DiscussionAnsweredInput{Answered: true}, tt.userId, tt.isAdmin)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr != nil {
+				assert.Nil(t, got, tt.name)
+				repoMock.AssertNotCalled(t, "SetDiscussionAnswered", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Discussion Updated Succesfully", got.Message, tt.name)
+		})
+	}
+}
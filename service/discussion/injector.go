@@ -0,0 +1,24 @@
+package discussion
+
+import (
+	"errors"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion/discussion_repository"
+)
+
+func (svc *discussionService) InjectDiscussionRepository(repo discussion_repository.DiscussionRepository) error {
+	if repo != nil {
+		svc.repository = repo
+		return nil
+	}
+	return errors.New("discussion repository not found")
+}
+
+func (svc *discussionService) InjectCourseRepository(repo course_repository.CourseRepository) error {
+	if repo != nil {
+		svc.courseRepository = repo
+		return nil
+	}
+	return errors.New("course repository not found")
+}
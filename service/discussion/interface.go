@@ -0,0 +1,22 @@
+package discussion
+
+import (
+	"context"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course/course_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/discussion/discussion_repository"
+)
+
+type DiscussionService interface {
+	InjectDiscussionRepository(repo discussion_repository.DiscussionRepository) error
+	InjectCourseRepository(repo course_repository.CourseRepository) error
+	GetThreads(ctx context.Context, meta *pagination.Meta, targetType string, targetId string, userId string, isAdmin bool) ([]*models.Discussion, uint64, error)
+	CreateThread(ctx context.Context, targetType string, targetId string, input *models.DiscussionInput, userId string, isAdmin bool) (*models.DiscussionResponse, error)
+	GetReplies(ctx context.Context, meta *pagination.Meta, id string, userId string, isAdmin bool) ([]*models.Discussion, uint64, error)
+	CreateReply(ctx context.Context, id string, input *models.DiscussionInput, userId string, isAdmin bool) (*models.DiscussionResponse, error)
+	UpdateDiscussion(ctx context.Context, id string, input *models.DiscussionInput, userId string) (*models.DiscussionResponse, error)
+	DeleteDiscussion(ctx context.Context, id string, userId string, isAdmin bool) (*models.DiscussionResponse, error)
+	MarkAnswered(ctx context.Context, id string, input *models.DiscussionAnsweredInput, userId string, isAdmin bool) (*models.DiscussionResponse, error)
+}
@@ -0,0 +1,24 @@
+package learning_path
+
+import (
+	"errors"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path/learning_path_repository"
+)
+
+func (svc *learningPathService) InjectLearningPathRepository(repo learning_path_repository.LearningPathRepository) error {
+	if repo != nil {
+		svc.repository = repo
+		return nil
+	}
+	return errors.New("learning path repository not found")
+}
+
+func (svc *learningPathService) InjectCourseService(courseService course.CourseService) error {
+	if courseService != nil {
+		svc.courseService = courseService
+		return nil
+	}
+	return errors.New("course service not found")
+}
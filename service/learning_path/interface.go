@@ -0,0 +1,21 @@
+package learning_path
+
+import (
+	"context"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path/learning_path_repository"
+)
+
+type LearningPathService interface {
+	InjectLearningPathRepository(learning_path_repository.LearningPathRepository) error
+	InjectCourseService(course.CourseService) error
+	GetLearningPathList(ctx context.Context, meta *pagination.Meta) ([]*models.LearningPath, uint64, error)
+	GetLearningPath(ctx context.Context, id string) (*models.LearningPath, error)
+	CreateLearningPath(ctx context.Context, input *models.LearningPathInput, creatorId string) (*models.LearningPathResponse, error)
+	UpdateLearningPath(ctx context.Context, id string, input *models.LearningPathInput) (*models.LearningPathResponse, error)
+	DeleteLearningPath(ctx context.Context, id string) (*models.LearningPathResponse, error)
+	GetLearningPathProgress(ctx context.Context, id string, userId string) (*models.LearningPathProgress, error)
+}
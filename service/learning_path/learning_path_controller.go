@@ -0,0 +1,126 @@
+package learning_path
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	custom_validator "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/databases/validator"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type LearningPathController struct {
+	learningPathService LearningPathService
+}
+
+func NewController(svc LearningPathService) *LearningPathController {
+	return &LearningPathController{
+		learningPathService: svc,
+	}
+}
+
+func (ctl *LearningPathController) HandleGetLearningPathList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+
+	items, count, err := ctl.learningPathService.GetLearningPathList(ctx, &meta)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *LearningPathController) HandleGetLearningPath(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	resp, err := ctl.learningPathService.GetLearningPath(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *LearningPathController) HandleGetLearningPathProgress(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+	id := c.Param("id")
+
+	resp, err := ctl.learningPathService.GetLearningPathProgress(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *LearningPathController) HandleCreateLearningPath(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(string)
+
+	input := new(models.LearningPathInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.learningPathService.CreateLearningPath(ctx, input, userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *LearningPathController) HandleUpdateLearningPath(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	input := new(models.LearningPathInput)
+	if err := c.Bind(input); err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, custom_validator.BuildCustomErrors((err)))
+	}
+
+	resp, err := ctl.learningPathService.UpdateLearningPath(ctx, id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctl *LearningPathController) HandleDeleteLearningPath(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	resp, err := ctl.learningPathService.DeleteLearningPath(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,21 @@
+package learning_path_repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type LearningPathRepository interface {
+	GetTableName() string
+	GetLearningPathList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta) ([]*db_models.LearningPath, uint64, error)
+	GetLearningPathById(ctx context.Context, db *sqlx.DB, id string) (*db_models.LearningPath, error)
+	GetLearningPathCourses(ctx context.Context, db *sqlx.DB, id string) ([]*db_models.LearningPathCourse, error)
+	GetLearningPathCourseStatus(ctx context.Context, db *sqlx.DB, id string, userId string) ([]*db_models.LearningPathCourseStatus, error)
+	GetExistingCourseIDs(ctx context.Context, db *sqlx.DB, ids []string) ([]string, error)
+	InsertLearningPath(ctx context.Context, db *sqlx.DB, path *db_models.LearningPath, courseIds []string) error
+	UpdateLearningPath(ctx context.Context, db *sqlx.DB, path *db_models.LearningPath, courseIds []string) error
+	DeleteLearningPath(ctx context.Context, db *sqlx.DB, id string) error
+}
@@ -0,0 +1,284 @@
+package learning_path_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type learningPathRepository struct{}
+
+func NewRepository() LearningPathRepository {
+	return &learningPathRepository{}
+}
+
+func (repo *learningPathRepository) GetTableName() string {
+	return "learning_path"
+}
+
+func (repo *learningPathRepository) querySelectLearningPath() sq.SelectBuilder {
+	builder := sq.Select(
+		"id",
+		"name",
+		"description",
+		"creator",
+	).From(repo.GetTableName())
+
+	return builder
+}
+
+func (repo *learningPathRepository) GetLearningPathList(ctx context.Context, db *sqlx.DB, meta *pagination.Meta) ([]*db_models.LearningPath, uint64, error) {
+	var paths []*db_models.LearningPath
+	var count uint64
+
+	selectQuery, args, err := repo.querySelectLearningPath().OrderBy("name").ToSql()
+	if err != nil {
+		return paths, count, err
+	}
+
+	query := fmt.Sprintf("%s limit %d,%d", selectQuery, (meta.Page-1)*meta.Limit, meta.Limit)
+	err = db.SelectContext(ctx, &paths, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return paths, count, nil
+		}
+		return paths, count, err
+	}
+
+	countQuery, args, err := sq.Select("count(id)").From(repo.GetTableName()).ToSql()
+	if err != nil {
+		return paths, count, err
+	}
+
+	err = db.GetContext(ctx, &count, countQuery, args...)
+	if err != nil {
+		return paths, count, err
+	}
+
+	return paths, count, nil
+}
+
+func (repo *learningPathRepository) GetLearningPathById(ctx context.Context, db *sqlx.DB, id string) (*db_models.LearningPath, error) {
+	out := new(db_models.LearningPath)
+	query, args, err := repo.querySelectLearningPath().Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.GetContext(ctx, out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, er.NewError(fmt.Errorf("%s", "Learning path not found"), http.StatusNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (repo *learningPathRepository) GetLearningPathCourses(ctx context.Context, db *sqlx.DB, id string) ([]*db_models.LearningPathCourse, error) {
+	var courses []*db_models.LearningPathCourse
+
+	query, args, err := sq.Select(
+		"learning_path_course.learning_path_id",
+		"learning_path_course.course_id",
+		"Course.course_name",
+		"Course.thumbnail",
+		"learning_path_course.position",
+	).From("learning_path_course").
+		Join("Course ON Course.id = learning_path_course.course_id").
+		Where(sq.Eq{"learning_path_course.learning_path_id": id}).
+		OrderBy("learning_path_course.position").
+		ToSql()
+	if err != nil {
+		return courses, err
+	}
+
+	err = db.SelectContext(ctx, &courses, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return courses, nil
+		}
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+// GetLearningPathCourseStatus returns the courses of the learning path in
+// order, each flagged with whether the user has completed or is enrolled to
+// it.
+func (repo *learningPathRepository) GetLearningPathCourseStatus(ctx context.Context, db *sqlx.DB, id string, userId string) ([]*db_models.LearningPathCourseStatus, error) {
+	var courses []*db_models.LearningPathCourseStatus
+
+	query, args, err := sq.Select(
+		"learning_path_course.course_id",
+		"Course.course_name",
+		"learning_path_course.position",
+	).Column(sq.Expr("EXISTS(SELECT 1 FROM solved_course WHERE solved_course.user_id = ? AND solved_course.course_id = learning_path_course.course_id) AS completed", userId)).
+		Column(sq.Expr("EXISTS(SELECT 1 FROM on_progress_course WHERE on_progress_course.user_id = ? AND on_progress_course.course_id = learning_path_course.course_id) AS enrolled", userId)).
+		From("learning_path_course").
+		Join("Course ON Course.id = learning_path_course.course_id").
+		Where(sq.Eq{"learning_path_course.learning_path_id": id}).
+		OrderBy("learning_path_course.position").
+		ToSql()
+	if err != nil {
+		return courses, err
+	}
+
+	err = db.SelectContext(ctx, &courses, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return courses, nil
+		}
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+func (repo *learningPathRepository) GetExistingCourseIDs(ctx context.Context, db *sqlx.DB, ids []string) ([]string, error) {
+	var out []string
+
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	query, args, err := sq.Select("id").From("Course").Where(sq.Eq{"id": ids}).ToSql()
+	if err != nil {
+		return out, err
+	}
+
+	err = db.SelectContext(ctx, &out, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return out, nil
+		}
+		return out, err
+	}
+
+	return out, nil
+}
+
+func (repo *learningPathRepository) insertLearningPathCourses(ctx context.Context, tx *sqlx.Tx, id string, courseIds []string) error {
+	if len(courseIds) == 0 {
+		return nil
+	}
+
+	queryBuilder := sq.Insert("learning_path_course").Columns("learning_path_id", "course_id", "position")
+	for position, courseId := range courseIds {
+		queryBuilder = queryBuilder.Values(id, courseId, position)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (repo *learningPathRepository) InsertLearningPath(ctx context.Context, db *sqlx.DB, path *db_models.LearningPath, courseIds []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Insert(repo.GetTableName()).
+		Columns("id", "name", "description", "creator").
+		Values(path.ID, path.Name, path.Description, path.Creator).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertLearningPathCourses(ctx, tx, path.ID, courseIds)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateLearningPath updates the learning path and replaces its courses, the
+// position of each course follows the order of courseIds.
+func (repo *learningPathRepository) UpdateLearningPath(ctx context.Context, db *sqlx.DB, path *db_models.LearningPath, courseIds []string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Update(repo.GetTableName()).
+		Set("name", path.Name).
+		Set("description", path.Description).
+		Where(sq.Eq{"id": path.ID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = sq.Delete("learning_path_course").Where(sq.Eq{"learning_path_id": path.ID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertLearningPathCourses(ctx, tx, path.ID, courseIds)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *learningPathRepository) DeleteLearningPath(ctx context.Context, db *sqlx.DB, id string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Delete("learning_path_course").Where(sq.Eq{"learning_path_id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = sq.Delete(repo.GetTableName()).Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,130 @@
+package learning_path_repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path/learning_path_repository"
+)
+
+var (
+	learningPathId = uuid.New().String()
+	userId         = uuid.New().String()
+	courseId       = uuid.New().String()
+	courseId2      = uuid.New().String()
+)
+
+func TestLearningPathRepository_InsertLearningPath(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		path      *db_models.LearningPath
+		courseIds []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[InsertLearningPath] Success to insert learning path",
+			args: args{
+				context.TODO(),
+				&db_models.LearningPath{
+					ID:          learningPathId,
+					Name:        "Algorithm",
+					Description: "From the basics to advanced algorithms",
+					Creator:     userId,
+				},
+				[]string{courseId, courseId2},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO learning_path (id,name,description,creator) VALUES (?,?,?,?)`)).
+				WithArgs(tt.args.path.ID, tt.args.path.Name, tt.args.path.Description, tt.args.path.Creator).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO learning_path_course (learning_path_id,course_id,position) VALUES (?,?,?),(?,?,?)`)).
+				WithArgs(tt.args.path.ID, courseId, 0, tt.args.path.ID, courseId2, 1).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+
+			r := learning_path_repository.NewRepository()
+			err = r.InsertLearningPath(tt.args.ctx, sqlxDB, tt.args.path, tt.args.courseIds)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestLearningPathRepository_GetLearningPathCourseStatus(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     string
+		userId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []*db_models.LearningPathCourseStatus
+		wantErr error
+	}{
+		{
+			name: "[GetLearningPathCourseStatus] Success to get course status of the learning path",
+			args: args{
+				context.TODO(),
+				learningPathId,
+				userId,
+			},
+			want: []*db_models.LearningPathCourseStatus{
+				{CourseID: courseId, CourseName: "Introduction to Algorithm", Position: 0, Completed: true, Enrolled: false},
+				{CourseID: courseId2, CourseName: "Advanced Algorithm", Position: 1, Completed: false, Enrolled: true},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			rows := sqlmock.NewRows([]string{"course_id", "course_name", "position", "completed", "enrolled"})
+			for _, row := range tt.want {
+				rows.AddRow(row.CourseID, row.CourseName, row.Position, row.Completed, row.Enrolled)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT learning_path_course.course_id, Course.course_name, learning_path_course.position, EXISTS(SELECT 1 FROM solved_course WHERE solved_course.user_id = ? AND solved_course.course_id = learning_path_course.course_id) AS completed, EXISTS(SELECT 1 FROM on_progress_course WHERE on_progress_course.user_id = ? AND on_progress_course.course_id = learning_path_course.course_id) AS enrolled FROM learning_path_course JOIN Course ON Course.id = learning_path_course.course_id WHERE learning_path_course.learning_path_id = ? ORDER BY learning_path_course.position`)).
+				WithArgs(tt.args.userId, tt.args.userId, tt.args.id).
+				WillReturnRows(rows)
+
+			r := learning_path_repository.NewRepository()
+			got, err := r.GetLearningPathCourseStatus(tt.args.ctx, sqlxDB, tt.args.id, tt.args.userId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
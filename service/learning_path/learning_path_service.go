@@ -0,0 +1,278 @@
+package learning_path
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/course"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path/learning_path_repository"
+)
+
+type learningPathService struct {
+	db            *sqlx.DB
+	repository    learning_path_repository.LearningPathRepository
+	courseService course.CourseService
+}
+
+func NewService(db *sqlx.DB) LearningPathService {
+	return &learningPathService{
+		db: db,
+	}
+}
+
+func (serv *learningPathService) getLearningPathCourses(ctx context.Context, id string) ([]*models.LearningPathCourse, error) {
+	courses := []*models.LearningPathCourse{}
+
+	db_courses, err := serv.repository.GetLearningPathCourses(ctx, serv.db, id)
+	if err != nil {
+		return courses, err
+	}
+
+	for _, item := range db_courses {
+		courses = append(courses, &models.LearningPathCourse{
+			ID:         item.CourseID,
+			CourseName: item.CourseName,
+			Thumbnail:  item.Thumbnail,
+			Position:   item.Position,
+		})
+	}
+
+	return courses, nil
+}
+
+func (serv *learningPathService) GetLearningPathList(ctx context.Context, meta *pagination.Meta) ([]*models.LearningPath, uint64, error) {
+	paths := []*models.LearningPath{}
+
+	db_paths, count, err := serv.repository.GetLearningPathList(ctx, serv.db, meta)
+	if err != nil {
+		return paths, count, err
+	}
+
+	for _, item := range db_paths {
+		courses, err := serv.getLearningPathCourses(ctx, item.ID)
+		if err != nil {
+			return paths, count, err
+		}
+
+		paths = append(paths, &models.LearningPath{
+			ID:          item.ID,
+			Name:        item.Name,
+			Description: item.Description,
+			Creator:     item.Creator,
+			Courses:     courses,
+		})
+	}
+
+	return paths, count, nil
+}
+
+func (serv *learningPathService) GetLearningPath(ctx context.Context, id string) (*models.LearningPath, error) {
+	path, err := serv.repository.GetLearningPathById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := serv.getLearningPathCourses(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.LearningPath{
+		ID:          path.ID,
+		Name:        path.Name,
+		Description: path.Description,
+		Creator:     path.Creator,
+		Courses:     courses,
+	}
+
+	return out, nil
+}
+
+// validateCourses makes sure every course of the learning path exists and
+// appears only once.
+func (serv *learningPathService) validateCourses(ctx context.Context, courseIds []string) error {
+	existing, err := serv.repository.GetExistingCourseIDs(ctx, serv.db, courseIds)
+	if err != nil {
+		return err
+	}
+
+	exists := map[string]bool{}
+	for _, id := range existing {
+		exists[id] = true
+	}
+
+	var errs []er.ErrorStruct
+	seen := map[string]bool{}
+
+	for idx, courseId := range courseIds {
+		field := fmt.Sprintf("courses[%d]", idx)
+
+		if seen[courseId] {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "Course is already in the learning path"})
+			continue
+		}
+		seen[courseId] = true
+
+		if !exists[courseId] {
+			errs = append(errs, er.ErrorStruct{Field: field, Reason: "Course not found"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return er.NewError(fmt.Errorf("%s", "Invalid learning path courses"), http.StatusBadRequest, &errs)
+	}
+
+	return nil
+}
+
+func (serv *learningPathService) CreateLearningPath(ctx context.Context, input *models.LearningPathInput, creatorId string) (*models.LearningPathResponse, error) {
+	err := serv.validateCourses(ctx, input.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	path := &db_models.LearningPath{
+		ID:          uuid.New().String(),
+		Name:        input.Name,
+		Description: input.Description,
+		Creator:     creatorId,
+	}
+
+	err = serv.repository.InsertLearningPath(ctx, serv.db, path, input.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.LearningPathResponse{
+		Status:  "Success",
+		Message: "Learning Path Created Succesfully",
+		ID:      path.ID,
+	}
+
+	return resp, nil
+}
+
+func (serv *learningPathService) UpdateLearningPath(ctx context.Context, id string, input *models.LearningPathInput) (*models.LearningPathResponse, error) {
+	path, err := serv.repository.GetLearningPathById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.validateCourses(ctx, input.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	path.Name = input.Name
+	path.Description = input.Description
+
+	err = serv.repository.UpdateLearningPath(ctx, serv.db, path, input.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.LearningPathResponse{
+		Status:  "Success",
+		Message: "Learning Path Updated Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+func (serv *learningPathService) DeleteLearningPath(ctx context.Context, id string) (*models.LearningPathResponse, error) {
+	_, err := serv.repository.GetLearningPathById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.repository.DeleteLearningPath(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.LearningPathResponse{
+		Status:  "Success",
+		Message: "Learning Path Deleted Succesfully",
+		ID:      id,
+	}
+
+	return resp, nil
+}
+
+// GetLearningPathProgress aggregates the user's progress over every course of
+// the learning path. Completed courses count as 100%, courses the user is
+// enrolled to count with their current progress and the rest count as 0%.
+// A course the user has not started is locked while any of its
+// prerequisites is not completed.
+func (serv *learningPathService) GetLearningPathProgress(ctx context.Context, id string, userId string) (*models.LearningPathProgress, error) {
+	_, err := serv.repository.GetLearningPathById(ctx, serv.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	db_courses, err := serv.repository.GetLearningPathCourseStatus(ctx, serv.db, id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.LearningPathProgress{
+		LearningPathID: id,
+		TotalCourses:   len(db_courses),
+		Courses:        []*models.LearningPathCourseProgress{},
+	}
+
+	total := 0
+	for _, item := range db_courses {
+		progress := &models.LearningPathCourseProgress{
+			ID:         item.CourseID,
+			CourseName: item.CourseName,
+			Position:   item.Position,
+			Status:     models.LearningPathCourseNotStarted,
+		}
+
+		switch {
+		case item.Completed:
+			progress.Status = models.LearningPathCourseCompleted
+			progress.Percentage = 100
+			out.CompletedCourses += 1
+		case item.Enrolled:
+			percentage, err := serv.courseService.ComputeUserProgress(ctx, userId, item.CourseID)
+			if err != nil {
+				return nil, err
+			}
+
+			progress.Status = models.LearningPathCourseOnProgress
+			progress.Percentage = percentage.Percentage
+		default:
+			prerequisites, err := serv.courseService.GetCoursePrerequisites(ctx, item.CourseID, userId)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, prerequisite := range prerequisites {
+				if !prerequisite.Completed {
+					progress.Locked = true
+					break
+				}
+			}
+		}
+
+		total += progress.Percentage
+		out.Courses = append(out.Courses, progress)
+	}
+
+	if len(db_courses) != 0 {
+		out.Percentage = int(math.Round(float64(total) / float64(len(db_courses))))
+	}
+
+	return out, nil
+}
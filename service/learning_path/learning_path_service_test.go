@@ -0,0 +1,187 @@
+package learning_path_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/learning_path"
+)
+
+var (
+	learningPathId = uuid.New().String()
+	userId         = uuid.New().String()
+	courseId       = uuid.New().String()
+	courseId2      = uuid.New().String()
+	courseId3      = uuid.New().String()
+)
+
+func TestLearningPathService_CreateLearningPath(t *testing.T) {
+	missingCourseId := uuid.New().String()
+
+	type args struct {
+		ctx   context.Context
+		input *models.LearningPathInput
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[CreateLearningPath] Success to create a learning path",
+			args: args{
+				context.TODO(),
+				&models.LearningPathInput{
+					Name:        "Algorithm",
+					Description: "From the basics to advanced algorithms",
+					Courses:     []string{courseId, courseId2},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[CreateLearningPath] Invalid courses",
+			args: args{
+				context.TODO(),
+				&models.LearningPathInput{
+					Name:        "Algorithm",
+					Description: "From the basics to advanced algorithms",
+					Courses:     []string{courseId, missingCourseId, courseId},
+				},
+			},
+			wantErr: er.NewError(fmt.Errorf("%s", "Invalid learning path courses"), http.StatusBadRequest, &[]er.ErrorStruct{
+				{Field: "courses[1]", Reason: "Course not found"},
+				{Field: "courses[2]", Reason: "Course is already in the learning path"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.LearningPathRepository)
+			svc := learning_path.NewService(sqlxDB)
+			svc.InjectLearningPathRepository(repoMock)
+
+			repoMock.
+				On("GetExistingCourseIDs", mock.Anything, mock.Anything, tt.args.input.Courses).
+				Return([]string{courseId, courseId2}, nil)
+
+			repoMock.
+				On("InsertLearningPath", mock.Anything, mock.Anything, mock.Anything, tt.args.input.Courses).
+				Return(nil)
+
+			got, err := svc.CreateLearningPath(tt.args.ctx, tt.args.input, userId)
+
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			if tt.wantErr == nil {
+				assert.Equal(t, "Success", got.Status, tt.name)
+				assert.NotEmpty(t, got.ID, tt.name)
+			}
+		})
+	}
+}
+
+func TestLearningPathService_GetLearningPathProgress(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     string
+		userId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		courses []*db_models.LearningPathCourseStatus
+		want    *models.LearningPathProgress
+		wantErr error
+	}{
+		{
+			name: "[GetLearningPathProgress] Success to aggregate learning path progress",
+			args: args{
+				context.TODO(),
+				learningPathId,
+				userId,
+			},
+			courses: []*db_models.LearningPathCourseStatus{
+				{CourseID: courseId, CourseName: "Introduction to Algorithm", Position: 0, Completed: true},
+				{CourseID: courseId2, CourseName: "Data Structure", Position: 1, Enrolled: true},
+				{CourseID: courseId3, CourseName: "Advanced Algorithm", Position: 2},
+			},
+			want: &models.LearningPathProgress{
+				LearningPathID:   learningPathId,
+				Percentage:       50,
+				CompletedCourses: 1,
+				TotalCourses:     3,
+				Courses: []*models.LearningPathCourseProgress{
+					{ID: courseId, CourseName: "Introduction to Algorithm", Position: 0, Status: models.LearningPathCourseCompleted, Percentage: 100},
+					{ID: courseId2, CourseName: "Data Structure", Position: 1, Status: models.LearningPathCourseOnProgress, Percentage: 50},
+					{ID: courseId3, CourseName: "Advanced Algorithm", Position: 2, Status: models.LearningPathCourseNotStarted, Percentage: 0, Locked: true},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetLearningPathProgress] Empty learning path",
+			args: args{
+				context.TODO(),
+				learningPathId,
+				userId,
+			},
+			courses: []*db_models.LearningPathCourseStatus{},
+			want: &models.LearningPathProgress{
+				LearningPathID: learningPathId,
+				Courses:        []*models.LearningPathCourseProgress{},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlxDB, _ := sqlx.Open("test", "test")
+
+			repoMock := new(mocks.LearningPathRepository)
+			courseServiceMock := new(mocks.CourseService)
+			svc := learning_path.NewService(sqlxDB)
+			svc.InjectLearningPathRepository(repoMock)
+			svc.InjectCourseService(courseServiceMock)
+
+			repoMock.
+				On("GetLearningPathById", mock.Anything, mock.Anything, tt.args.id).
+				Return(&db_models.LearningPath{ID: learningPathId, Name: "Algorithm"}, nil)
+
+			repoMock.
+				On("GetLearningPathCourseStatus", mock.Anything, mock.Anything, tt.args.id, tt.args.userId).
+				Return(tt.courses, nil)
+
+			courseServiceMock.
+				On("ComputeUserProgress", mock.Anything, tt.args.userId, courseId2).
+				Return(&models.GetProgressPercentageResponse{Percentage: 50}, nil)
+
+			courseServiceMock.
+				On("GetCoursePrerequisites", mock.Anything, courseId3, tt.args.userId).
+				Return([]*models.Prerequisite{
+					{ID: courseId, CourseName: "Introduction to Algorithm", Completed: true},
+					{ID: courseId2, CourseName: "Data Structure", Completed: false},
+				}, nil)
+
+			got, err := svc.GetLearningPathProgress(tt.args.ctx, tt.args.id, tt.args.userId)
+
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 )
 
 func (svc *problemService) InjectRepository(repo problem_repository.ProblemRepository) error {
@@ -14,3 +15,10 @@ func (svc *problemService) InjectRepository(repo problem_repository.ProblemRepos
 	return errors.New("problem repository not found")
 }
 
+func (svc *problemService) InjectSearchService(searchService search.SearchService) error {
+	if searchService != nil {
+		svc.searchService = searchService
+		return nil
+	}
+	return errors.New("search service not found")
+}
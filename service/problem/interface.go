@@ -5,10 +5,12 @@ import (
 
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 )
 
 type ProblemService interface {
 	InjectRepository(problem_repository.ProblemRepository) error
+	InjectSearchService(search.SearchService) error
 	GetProblemCandidate(ctx context.Context, id string) (*models.ProblemCandidate, error)
 	CreateNewProblem(ctx context.Context, problem *models.ProblemCreationInput) (*models.ProblemCreationResponse, error)
 	GetProblemStatus(ctx context.Context, id string) (*models.ProblemStatusList, error)
@@ -8,11 +8,13 @@ import (
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
 	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
 	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/problem/problem_repository"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
 )
 
 type problemService struct {
-	db         *sqlx.DB
-	repository problem_repository.ProblemRepository
+	db            *sqlx.DB
+	repository    problem_repository.ProblemRepository
+	searchService search.SearchService
 }
 
 func NewService(db *sqlx.DB) ProblemService {
@@ -145,6 +147,10 @@ func (svc *problemService) AcceptProblem(ctx context.Context, id string) (*model
 		return nil, err
 	}
 
+	if svc.searchService != nil {
+		svc.searchService.TrySyncProblem(ctx, id)
+	}
+
 	out := &models.ProblemCreationResponse{
 		Status:  "Success",
 		Message: "Problem Updated Succesfully",
@@ -164,6 +170,10 @@ func (svc *problemService) RejectProblem(ctx context.Context, id string) (*model
 		return nil, err
 	}
 
+	if svc.searchService != nil {
+		svc.searchService.TrySyncProblem(ctx, id)
+	}
+
 	out := &models.ProblemCreationResponse{
 		Status:  "Success",
 		Message: "Problem Updated Succesfully",
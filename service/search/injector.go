@@ -0,0 +1,24 @@
+package search
+
+import (
+	"errors"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_repository"
+)
+
+func (svc *searchService) InjectSearchRepository(repo search_repository.SearchRepository) error {
+	if repo != nil {
+		svc.searchRepository = repo
+		return nil
+	}
+	return errors.New("search repository not found")
+}
+
+func (svc *searchService) InjectSearchIndex(index search_index.SearchIndex) error {
+	if index != nil {
+		svc.searchIndex = index
+		return nil
+	}
+	return errors.New("search index not found")
+}
@@ -0,0 +1,21 @@
+package search
+
+import (
+	"context"
+
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_repository"
+)
+
+type SearchService interface {
+	InjectSearchRepository(search_repository.SearchRepository) error
+	InjectSearchIndex(search_index.SearchIndex) error
+	Rebuild(ctx context.Context) error
+	SyncCourse(ctx context.Context, courseId string) error
+	SyncProblem(ctx context.Context, problemId string) error
+	TrySyncCourse(ctx context.Context, courseId string)
+	TrySyncProblem(ctx context.Context, problemId string)
+	Search(ctx context.Context, meta *pagination.Meta, filter models.SearchFilter) ([]*models.SearchResult, uint64, error)
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+)
+
+type SearchController struct {
+	searchService SearchService
+}
+
+func NewController(svc SearchService) *SearchController {
+	return &SearchController{
+		searchService: svc,
+	}
+}
+
+func (ctl *SearchController) HandleSearch(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var resp pagination.PaginationResponse
+
+	meta := pagination.Meta{}
+	meta.FromContext(c)
+	if meta.Page < 1 {
+		meta.Page = 1
+	}
+	if meta.Limit < 1 {
+		meta.Limit = 10
+	}
+
+	filter := models.SearchFilter{}
+	filter.FromContext(c)
+
+	items, count, err := ctl.searchService.Search(ctx, &meta, filter)
+	if err != nil {
+		return err
+	}
+
+	resp = pagination.PaginationResponse{
+		Meta: &pagination.Meta{
+			Count: count,
+			Page:  meta.Page,
+			Limit: meta.Limit,
+		},
+		Items: items,
+	}
+
+	resp.SetTotalPage()
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,30 @@
+package search_index
+
+const (
+	DocumentTypeCourse   = "course"
+	DocumentTypeMaterial = "material"
+	DocumentTypeProblem  = "problem"
+)
+
+// Document is a single searchable item. ParentID groups documents that
+// belong to another document, e.g. the materials of a course.
+type Document struct {
+	Type     string
+	ID       string
+	ParentID string
+	Title    string
+	Body     string
+}
+
+type Hit struct {
+	Document *Document
+	Score    float64
+}
+
+type SearchIndex interface {
+	Index(doc *Document)
+	Remove(docType string, id string)
+	RemoveByParent(parentId string)
+	Search(query string, types []string) []*Hit
+	Size() int
+}
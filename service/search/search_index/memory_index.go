@@ -0,0 +1,195 @@
+package search_index
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// titleWeight is how much more a term found in the title is worth compared
+// to the same term found in the body.
+const titleWeight = 3.0
+
+type entry struct {
+	doc   *Document
+	title map[string]int
+	body  map[string]int
+}
+
+// memoryIndex is an in-memory inverted index ranking documents with a
+// tf-idf score.
+type memoryIndex struct {
+	mu       sync.RWMutex
+	entries  map[string]*entry
+	postings map[string]map[string]struct{}
+}
+
+func NewMemoryIndex() SearchIndex {
+	return &memoryIndex{
+		entries:  map[string]*entry{},
+		postings: map[string]map[string]struct{}{},
+	}
+}
+
+func documentKey(docType string, id string) string {
+	return docType + ":" + id
+}
+
+// Tokenize lowercases text and splits it on anything that is not a letter
+// or a digit.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func termFrequency(text string) map[string]int {
+	freq := map[string]int{}
+	for _, term := range Tokenize(text) {
+		freq[term]++
+	}
+
+	return freq
+}
+
+func (idx *memoryIndex) Index(doc *Document) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	key := documentKey(doc.Type, doc.ID)
+	idx.remove(key)
+
+	e := &entry{
+		doc:   doc,
+		title: termFrequency(doc.Title),
+		body:  termFrequency(doc.Body),
+	}
+	idx.entries[key] = e
+
+	for _, terms := range []map[string]int{e.title, e.body} {
+		for term := range terms {
+			if idx.postings[term] == nil {
+				idx.postings[term] = map[string]struct{}{}
+			}
+			idx.postings[term][key] = struct{}{}
+		}
+	}
+}
+
+func (idx *memoryIndex) Remove(docType string, id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(documentKey(docType, id))
+}
+
+func (idx *memoryIndex) RemoveByParent(parentId string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for key, e := range idx.entries {
+		if e.doc.ParentID == parentId {
+			idx.remove(key)
+		}
+	}
+}
+
+func (idx *memoryIndex) remove(key string) {
+	e, ok := idx.entries[key]
+	if !ok {
+		return
+	}
+
+	for _, terms := range []map[string]int{e.title, e.body} {
+		for term := range terms {
+			delete(idx.postings[term], key)
+			if len(idx.postings[term]) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+	}
+
+	delete(idx.entries, key)
+}
+
+func (idx *memoryIndex) Search(query string, types []string) []*Hit {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	terms := uniqueTerms(Tokenize(query))
+	if len(terms) == 0 {
+		return []*Hit{}
+	}
+
+	allowed := map[string]bool{}
+	for _, t := range types {
+		allowed[t] = true
+	}
+
+	scores := map[string]float64{}
+	matched := map[string]int{}
+	total := float64(len(idx.entries))
+
+	for _, term := range terms {
+		keys := idx.postings[term]
+		if len(keys) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + total/float64(len(keys)))
+		for key := range keys {
+			e := idx.entries[key]
+			if len(allowed) > 0 && !allowed[e.doc.Type] {
+				continue
+			}
+
+			tf := titleWeight*float64(e.title[term]) + float64(e.body[term])
+			scores[key] += idf * (1 + math.Log(tf))
+			matched[key]++
+		}
+	}
+
+	hits := []*Hit{}
+	for key, score := range scores {
+		// documents matching more of the query terms rank higher
+		coverage := float64(matched[key]) / float64(len(terms))
+		hits = append(hits, &Hit{
+			Document: idx.entries[key].doc,
+			Score:    score * coverage,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Document.Title != hits[j].Document.Title {
+			return hits[i].Document.Title < hits[j].Document.Title
+		}
+		return hits[i].Document.ID < hits[j].Document.ID
+	})
+
+	return hits
+}
+
+func (idx *memoryIndex) Size() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.entries)
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			out = append(out, term)
+		}
+	}
+
+	return out
+}
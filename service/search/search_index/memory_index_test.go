@@ -0,0 +1,87 @@
+package search_index_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+)
+
+func newTestIndex() search_index.SearchIndex {
+	idx := search_index.NewMemoryIndex()
+	idx.Index(&search_index.Document{Type: search_index.DocumentTypeCourse, ID: "c1", Title: "Golang Basics", Body: "Learn the go programming language"})
+	idx.Index(&search_index.Document{Type: search_index.DocumentTypeCourse, ID: "c2", Title: "Cooking", Body: "Recipes for programming students"})
+	idx.Index(&search_index.Document{Type: search_index.DocumentTypeMaterial, ID: "m1", ParentID: "c1", Title: "Goroutines", Body: "Concurrency in golang"})
+	idx.Index(&search_index.Document{Type: search_index.DocumentTypeProblem, ID: "p1", Title: "Sum of two numbers", Body: "math"})
+	return idx
+}
+
+func TestMemoryIndex_Search(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		types   []string
+		wantIds []string
+	}{
+		{
+			name:    "[Search] Title matches rank above body matches",
+			query:   "golang",
+			types:   nil,
+			wantIds: []string{"c1", "m1"},
+		},
+		{
+			name:    "[Search] Documents matching every term rank first",
+			query:   "golang programming",
+			types:   nil,
+			wantIds: []string{"c1", "c2", "m1"},
+		},
+		{
+			name:    "[Search] Filter by document type",
+			query:   "golang",
+			types:   []string{search_index.DocumentTypeMaterial},
+			wantIds: []string{"m1"},
+		},
+		{
+			name:    "[Search] Query is case insensitive",
+			query:   "SUM",
+			types:   nil,
+			wantIds: []string{"p1"},
+		},
+		{
+			name:    "[Search] No match",
+			query:   "rust",
+			types:   nil,
+			wantIds: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := newTestIndex()
+
+			ids := []string{}
+			for _, hit := range idx.Search(tt.query, tt.types) {
+				ids = append(ids, hit.Document.ID)
+			}
+
+			assert.Equal(t, tt.wantIds, ids, tt.name)
+		})
+	}
+}
+
+func TestMemoryIndex_Remove(t *testing.T) {
+	idx := newTestIndex()
+
+	idx.RemoveByParent("c1")
+	assert.Equal(t, 3, idx.Size())
+	assert.Equal(t, 1, len(idx.Search("golang", nil)))
+
+	idx.Remove(search_index.DocumentTypeCourse, "c1")
+	assert.Equal(t, 2, idx.Size())
+	assert.Equal(t, 0, len(idx.Search("golang", nil)))
+
+	idx.Index(&search_index.Document{Type: search_index.DocumentTypeCourse, ID: "c2", Title: "Baking", Body: ""})
+	assert.Equal(t, 2, idx.Size())
+	assert.Equal(t, 0, len(idx.Search("cooking", nil)))
+	assert.Equal(t, 1, len(idx.Search("baking", nil)))
+}
@@ -0,0 +1,14 @@
+package search_repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+type SearchRepository interface {
+	GetCourseDocuments(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.SearchDocument, error)
+	GetMaterialDocuments(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.SearchDocument, error)
+	GetProblemDocuments(ctx context.Context, db *sqlx.DB, problemId string) ([]*db_models.SearchDocument, error)
+}
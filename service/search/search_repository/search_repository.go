@@ -0,0 +1,92 @@
+package search_repository
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+)
+
+type searchRepository struct{}
+
+func NewRepository() SearchRepository {
+	return &searchRepository{}
+}
+
+func (repo *searchRepository) selectDocuments(ctx context.Context, db *sqlx.DB, builder sq.SelectBuilder) ([]*db_models.SearchDocument, error) {
+	docs := []*db_models.SearchDocument{}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return docs, err
+	}
+
+	err = db.SelectContext(ctx, &docs, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return docs, nil
+		}
+
+		return docs, err
+	}
+
+	return docs, nil
+}
+
+// GetCourseDocuments returns the searchable fields of published courses. An
+// empty courseId returns every published course.
+func (repo *searchRepository) GetCourseDocuments(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.SearchDocument, error) {
+	builder := sq.Select(
+		"id",
+		"'' AS parent_id",
+		"COALESCE(course_name, '') AS title",
+		"COALESCE(description, '') AS body",
+	).From("Course").Where(sq.Eq{"status": models.CourseStatusPublished})
+
+	if len(courseId) > 0 {
+		builder = builder.Where(sq.Eq{"id": courseId})
+	}
+
+	return repo.selectDocuments(ctx, db, builder)
+}
+
+// GetMaterialDocuments returns the searchable fields of the materials of
+// published courses. An empty courseId returns the materials of every
+// published course.
+func (repo *searchRepository) GetMaterialDocuments(ctx context.Context, db *sqlx.DB, courseId string) ([]*db_models.SearchDocument, error) {
+	builder := sq.Select(
+		"m.id",
+		"m.course_id AS parent_id",
+		"COALESCE(m.name, '') AS title",
+		"COALESCE(m.content_text, '') AS body",
+	).From("course_material m").
+		Join("Course c ON c.id = m.course_id").
+		Where(sq.Eq{"c.status": models.CourseStatusPublished}).
+		Where(sq.NotEq{"m.type": "section"})
+
+	if len(courseId) > 0 {
+		builder = builder.Where(sq.Eq{"m.course_id": courseId})
+	}
+
+	return repo.selectDocuments(ctx, db, builder)
+}
+
+// GetProblemDocuments returns the searchable fields of accepted problems. An
+// empty problemId returns every accepted problem.
+func (repo *searchRepository) GetProblemDocuments(ctx context.Context, db *sqlx.DB, problemId string) ([]*db_models.SearchDocument, error) {
+	builder := sq.Select(
+		"id",
+		"'' AS parent_id",
+		"COALESCE(title, '') AS title",
+		"COALESCE(topic, '') AS body",
+	).From("Candidate_Problem").Where(sq.Eq{"status": "accepted"})
+
+	if len(problemId) > 0 {
+		builder = builder.Where(sq.Eq{"id": problemId})
+	}
+
+	return repo.selectDocuments(ctx, db, builder)
+}
@@ -0,0 +1,133 @@
+package search_repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_repository"
+)
+
+func TestSearchRepository_GetMaterialDocuments(t *testing.T) {
+
+	type args struct {
+		ctx      context.Context
+		courseId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		query   string
+		want    []*db_models.SearchDocument
+		wantErr error
+	}{
+		{
+			name: "[GetMaterialDocuments] Success to get every published material.",
+			args: args{
+				context.TODO(),
+				"",
+			},
+			query: `SELECT m.id, m.course_id AS parent_id, COALESCE(m.name, '') AS title, COALESCE(m.content_text, '') AS body FROM course_material m JOIN Course c ON c.id = m.course_id WHERE c.status = ? AND m.type <> ?`,
+			want: []*db_models.SearchDocument{
+				{ID: "material-1", ParentID: "course-1", Title: "Variables", Body: "Declaring variables"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[GetMaterialDocuments] Success to get materials of a course.",
+			args: args{
+				context.TODO(),
+				"course-1",
+			},
+			query: `SELECT m.id, m.course_id AS parent_id, COALESCE(m.name, '') AS title, COALESCE(m.content_text, '') AS body FROM course_material m JOIN Course c ON c.id = m.course_id WHERE c.status = ? AND m.type <> ? AND m.course_id = ?`,
+			want: []*db_models.SearchDocument{
+				{ID: "material-1", ParentID: "course-1", Title: "Variables", Body: "Declaring variables"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			rows := sqlmock.NewRows([]string{"id", "parent_id", "title", "body"})
+			for _, row := range tt.want {
+				rows.AddRow(row.ID, row.ParentID, row.Title, row.Body)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query) + "$").WillReturnRows(rows)
+
+			r := search_repository.NewRepository()
+			got, err := r.GetMaterialDocuments(tt.args.ctx, sqlxDB, tt.args.courseId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
+
+func TestSearchRepository_GetProblemDocuments(t *testing.T) {
+
+	type args struct {
+		ctx       context.Context
+		problemId string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []*db_models.SearchDocument
+		wantErr error
+	}{
+		{
+			name: "[GetProblemDocuments] Success to get accepted problem.",
+			args: args{
+				context.TODO(),
+				"problem-1",
+			},
+			want: []*db_models.SearchDocument{
+				{ID: "problem-1", ParentID: "", Title: "Reverse a string", Body: "strings"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+			selectQuery := regexp.QuoteMeta(`SELECT id, '' AS parent_id, COALESCE(title, '') AS title, COALESCE(topic, '') AS body FROM Candidate_Problem WHERE status = ? AND id = ?`)
+
+			rows := sqlmock.NewRows([]string{"id", "parent_id", "title", "body"})
+			for _, row := range tt.want {
+				rows.AddRow(row.ID, row.ParentID, row.Title, row.Body)
+			}
+
+			mock.ExpectQuery(selectQuery).
+				WithArgs("accepted", tt.args.problemId).
+				WillReturnRows(rows)
+
+			r := search_repository.NewRepository()
+			got, err := r.GetProblemDocuments(tt.args.ctx, sqlxDB, tt.args.problemId)
+			assert.Equal(t, tt.want, got, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+			assert.Nil(t, mock.ExpectationsWereMet(), tt.name)
+		})
+	}
+}
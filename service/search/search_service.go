@@ -0,0 +1,202 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_repository"
+)
+
+const snippetLength = 160
+
+type searchService struct {
+	db               *sqlx.DB
+	searchRepository search_repository.SearchRepository
+	searchIndex      search_index.SearchIndex
+}
+
+func NewService(db *sqlx.DB) SearchService {
+	return &searchService{
+		db: db,
+	}
+}
+
+func (svc *searchService) indexDocuments(docType string, docs []*db_models.SearchDocument) {
+	for _, doc := range docs {
+		svc.searchIndex.Index(&search_index.Document{
+			Type:     docType,
+			ID:       doc.ID,
+			ParentID: doc.ParentID,
+			Title:    doc.Title,
+			Body:     doc.Body,
+		})
+	}
+}
+
+// Rebuild loads every published course, its materials and every accepted
+// problem into the search index.
+func (svc *searchService) Rebuild(ctx context.Context) error {
+	courses, err := svc.searchRepository.GetCourseDocuments(ctx, svc.db, "")
+	if err != nil {
+		return err
+	}
+
+	materials, err := svc.searchRepository.GetMaterialDocuments(ctx, svc.db, "")
+	if err != nil {
+		return err
+	}
+
+	problems, err := svc.searchRepository.GetProblemDocuments(ctx, svc.db, "")
+	if err != nil {
+		return err
+	}
+
+	svc.indexDocuments(search_index.DocumentTypeCourse, courses)
+	svc.indexDocuments(search_index.DocumentTypeMaterial, materials)
+	svc.indexDocuments(search_index.DocumentTypeProblem, problems)
+
+	return nil
+}
+
+// SyncCourse refreshes a course and its materials in the search index. The
+// course is dropped from the index when it is no longer published.
+func (svc *searchService) SyncCourse(ctx context.Context, courseId string) error {
+	courses, err := svc.searchRepository.GetCourseDocuments(ctx, svc.db, courseId)
+	if err != nil {
+		return err
+	}
+
+	materials, err := svc.searchRepository.GetMaterialDocuments(ctx, svc.db, courseId)
+	if err != nil {
+		return err
+	}
+
+	svc.searchIndex.Remove(search_index.DocumentTypeCourse, courseId)
+	svc.searchIndex.RemoveByParent(courseId)
+
+	svc.indexDocuments(search_index.DocumentTypeCourse, courses)
+	svc.indexDocuments(search_index.DocumentTypeMaterial, materials)
+
+	return nil
+}
+
+// SyncProblem refreshes a problem in the search index. The problem is
+// dropped from the index when it is not accepted.
+func (svc *searchService) SyncProblem(ctx context.Context, problemId string) error {
+	problems, err := svc.searchRepository.GetProblemDocuments(ctx, svc.db, problemId)
+	if err != nil {
+		return err
+	}
+
+	svc.searchIndex.Remove(search_index.DocumentTypeProblem, problemId)
+	svc.indexDocuments(search_index.DocumentTypeProblem, problems)
+
+	return nil
+}
+
+// TrySyncCourse is SyncCourse for the writes that trigger it. The index is
+// only a cache of the database, so a failed refresh is logged instead of
+// failing the write.
+func (svc *searchService) TrySyncCourse(ctx context.Context, courseId string) {
+	if err := svc.SyncCourse(ctx, courseId); err != nil {
+		log.Printf("search: failed to sync course %s: %v", courseId, err)
+	}
+}
+
+// TrySyncProblem is SyncProblem for the writes that trigger it, failures are
+// logged like in TrySyncCourse.
+func (svc *searchService) TrySyncProblem(ctx context.Context, problemId string) {
+	if err := svc.SyncProblem(ctx, problemId); err != nil {
+		log.Printf("search: failed to sync problem %s: %v", problemId, err)
+	}
+}
+
+func (svc *searchService) Search(ctx context.Context, meta *pagination.Meta, filter models.SearchFilter) ([]*models.SearchResult, uint64, error) {
+	results := []*models.SearchResult{}
+
+	if len(filter.Query) == 0 {
+		return results, 0, er.NewError(fmt.Errorf("%s", "Search query is required"), http.StatusBadRequest, nil)
+	}
+
+	for _, t := range filter.Types {
+		if t != search_index.DocumentTypeCourse && t != search_index.DocumentTypeMaterial && t != search_index.DocumentTypeProblem {
+			return results, 0, er.NewError(fmt.Errorf("Invalid search type %s", t), http.StatusBadRequest, nil)
+		}
+	}
+
+	hits := svc.searchIndex.Search(filter.Query, filter.Types)
+	count := uint64(len(hits))
+
+	start := (meta.Page - 1) * meta.Limit
+	if start >= len(hits) {
+		return results, count, nil
+	}
+
+	end := start + meta.Limit
+	if end > len(hits) {
+		end = len(hits)
+	}
+
+	terms := search_index.Tokenize(filter.Query)
+	for _, hit := range hits[start:end] {
+		temp := models.SearchResult{
+			Type:    hit.Document.Type,
+			ID:      hit.Document.ID,
+			Title:   hit.Document.Title,
+			Snippet: buildSnippet(hit.Document.Body, terms),
+			Score:   hit.Score,
+		}
+
+		if hit.Document.Type == search_index.DocumentTypeMaterial {
+			temp.CourseID = hit.Document.ParentID
+		}
+
+		results = append(results, &temp)
+	}
+
+	return results, count, nil
+}
+
+// buildSnippet cuts a window of the body around the first query term it
+// contains, falling back to the start of the body.
+func buildSnippet(body string, terms []string) string {
+	text := []rune(body)
+	if len(text) <= snippetLength {
+		return body
+	}
+
+	lower := strings.ToLower(body)
+	start := 0
+	for _, term := range terms {
+		if pos := strings.Index(lower, term); pos >= 0 {
+			start = len([]rune(lower[:pos])) - snippetLength/4
+			break
+		}
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if start > len(text)-snippetLength {
+		start = len(text) - snippetLength
+	}
+
+	snippet := string(text[start : start+snippetLength])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if start+snippetLength < len(text) {
+		snippet = snippet + "..."
+	}
+
+	return snippet
+}
@@ -0,0 +1,200 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	er "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/error"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/mocks"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models"
+	db_models "gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/db"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/models/pagination"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search"
+	"gitlab.informatika.org/andrc1613/if3250_2022_08_freeocp/service/search/search_index"
+)
+
+func newTestService(repoMock *mocks.SearchRepository) search.SearchService {
+	sqlxDB, _ := sqlx.Open("test", "test")
+
+	svc := search.NewService(sqlxDB)
+	svc.InjectSearchRepository(repoMock)
+	svc.InjectSearchIndex(search_index.NewMemoryIndex())
+
+	repoMock.
+		On("GetCourseDocuments", mock.Anything, mock.Anything, "").
+		Return([]*db_models.SearchDocument{
+			{ID: "course-1", Title: "Golang Basics", Body: "Learn golang"},
+		}, nil)
+	repoMock.
+		On("GetMaterialDocuments", mock.Anything, mock.Anything, "").
+		Return([]*db_models.SearchDocument{
+			{ID: "material-1", ParentID: "course-1", Title: "Variables", Body: "Declaring variables in golang"},
+		}, nil)
+	repoMock.
+		On("GetProblemDocuments", mock.Anything, mock.Anything, "").
+		Return([]*db_models.SearchDocument{
+			{ID: "problem-1", Title: "Reverse a string", Body: "strings"},
+		}, nil)
+
+	svc.Rebuild(context.TODO())
+
+	return svc
+}
+
+func TestSearchService_Search(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		meta   *pagination.Meta
+		filter models.SearchFilter
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantIds   []string
+		wantCount uint64
+		wantErr   error
+	}{
+		{
+			name: "[Search] Success to search courses and materials",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Page: 1, Limit: 10},
+				models.SearchFilter{Query: "golang"},
+			},
+			wantIds:   []string{"course-1", "material-1"},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "[Search] Success to paginate results",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Page: 2, Limit: 1},
+				models.SearchFilter{Query: "golang"},
+			},
+			wantIds:   []string{"material-1"},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "[Search] Success to search problems only",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Page: 1, Limit: 10},
+				models.SearchFilter{Query: "string", Types: []string{"problem"}},
+			},
+			wantIds:   []string{"problem-1"},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "[Search] Empty query",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Page: 1, Limit: 10},
+				models.SearchFilter{Query: ""},
+			},
+			wantIds:   []string{},
+			wantCount: 0,
+			wantErr:   er.NewError(fmt.Errorf("%s", "Search query is required"), http.StatusBadRequest, nil),
+		},
+		{
+			name: "[Search] Invalid type",
+			args: args{
+				context.TODO(),
+				&pagination.Meta{Page: 1, Limit: 10},
+				models.SearchFilter{Query: "golang", Types: []string{"user"}},
+			},
+			wantIds:   []string{},
+			wantCount: 0,
+			wantErr:   er.NewError(fmt.Errorf("Invalid search type %s", "user"), http.StatusBadRequest, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(new(mocks.SearchRepository))
+
+			got, count, err := svc.Search(tt.args.ctx, tt.args.meta, tt.args.filter)
+
+			ids := []string{}
+			for _, result := range got {
+				ids = append(ids, result.ID)
+			}
+
+			assert.Equal(t, tt.wantIds, ids, tt.name)
+			assert.Equal(t, tt.wantCount, count, tt.name)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestSearchService_SyncCourse(t *testing.T) {
+	tests := []struct {
+		name      string
+		courses   []*db_models.SearchDocument
+		materials []*db_models.SearchDocument
+		wantIds   []string
+	}{
+		{
+			name: "[SyncCourse] Success to refresh published course",
+			courses: []*db_models.SearchDocument{
+				{ID: "course-1", Title: "Golang Advanced", Body: "Generics"},
+			},
+			materials: []*db_models.SearchDocument{},
+			wantIds:   []string{"course-1"},
+		},
+		{
+			name:      "[SyncCourse] Success to drop unpublished course",
+			courses:   []*db_models.SearchDocument{},
+			materials: []*db_models.SearchDocument{},
+			wantIds:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := new(mocks.SearchRepository)
+			svc := newTestService(repoMock)
+
+			repoMock.
+				On("GetCourseDocuments", mock.Anything, mock.Anything, "course-1").
+				Return(tt.courses, nil)
+			repoMock.
+				On("GetMaterialDocuments", mock.Anything, mock.Anything, "course-1").
+				Return(tt.materials, nil)
+
+			err := svc.SyncCourse(context.TODO(), "course-1")
+			assert.Nil(t, err, tt.name)
+
+			got, _, _ := svc.Search(context.TODO(), &pagination.Meta{Page: 1, Limit: 10}, models.SearchFilter{Query: "golang"})
+
+			ids := []string{}
+			for _, result := range got {
+				ids = append(ids, result.ID)
+			}
+
+			assert.Equal(t, tt.wantIds, ids, tt.name)
+		})
+	}
+}
+
+func TestSearchService_TrySyncCourse(t *testing.T) {
+	repoMock := new(mocks.SearchRepository)
+	svc := newTestService(repoMock)
+
+	repoMock.
+		On("GetCourseDocuments", mock.Anything, mock.Anything, "course-1").
+		Return(nil, fmt.Errorf("%s", "connection refused"))
+
+	svc.TrySyncCourse(context.TODO(), "course-1")
+
+	got, _, _ := svc.Search(context.TODO(), &pagination.Meta{Page: 1, Limit: 10}, models.SearchFilter{Query: "golang"})
+	assert.Equal(t, 2, len(got), "[TrySyncCourse] Failed refresh keeps the indexed course")
+}
@@ -27,6 +27,7 @@ func (repo *userRepository) queryGetUser() sq.SelectBuilder {
 		"username",
 		"password",
 		"isAdmin",
+		"COALESCE(fullname, '') AS fullname",
 	).From(repo.GetTableName())
 
 	return builder